@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// ExpandArgs replaces the values of the sub-command flags that accept files,
+// see Flag.File, of the form "@file" with the content of the file and "@-"
+// with the content read from stdin. Both the "-flag @file" and "-flag=@file"
+// forms are expanded. A single trailing newline is removed from the content.
+// Values starting with "@@" are kept as is minus the first "@" so that values
+// starting with "@" may still be given. The other arguments are returned as
+// is.
+//
+// The first two arguments must be the command and sub-command names, the
+// arguments are returned as is otherwise. stdin may be nil in which case "@-"
+// values cause an error. stdin is read at most once.
+func ExpandArgs(args []string, stdin io.Reader, cmds []*Command) ([]string, error) {
+	res := append([]string{}, args...)
+	if len(args) < 2 {
+		return res, nil
+	}
+	cmd := findCommand(cmds, args[0])
+	if cmd == nil {
+		return res, nil
+	}
+	sub := findCommand(cmd.Subcommands, args[1])
+	if sub == nil {
+		return res, nil
+	}
+	var stdinRead bool
+	for i := 2; i < len(args); i++ {
+		var (
+			arg         = args[i]
+			prefix, val string
+			f           *Flag
+		)
+		if idx := strings.Index(arg, "="); idx > 0 {
+			f = findFlag(sub.Flags, arg[:idx])
+			prefix, val = arg[:idx+1], arg[idx+1:]
+		} else if f = findFlag(sub.Flags, arg); f != nil {
+			if i+1 == len(args) {
+				break
+			}
+			i++
+			val = args[i]
+		}
+		if f == nil || !f.File {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(val, "@@"):
+			val = val[1:]
+		case val == "@-":
+			if stdin == nil {
+				return nil, fmt.Errorf("cannot read %q: stdin is not available", val)
+			}
+			if stdinRead {
+				return nil, fmt.Errorf("cannot read %q: stdin was already read", val)
+			}
+			b, err := ioutil.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read stdin: %s", err)
+			}
+			stdinRead = true
+			val = trimNewline(string(b))
+		case strings.HasPrefix(val, "@") && len(val) > 1:
+			b, err := ioutil.ReadFile(val[1:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %q: %s", val[1:], err)
+			}
+			val = trimNewline(string(b))
+		}
+		res[i] = prefix + val
+	}
+	return res, nil
+}
+
+// SplitArgs splits the given command line into arguments. Arguments are
+// separated by whitespace. Single quotes preserve the literal value of the
+// characters they enclose, double quotes preserve the literal value of the
+// characters they enclose except for backslashes that escape double quotes
+// and backslashes. Outside of quotes a backslash preserves the literal value
+// of the next character.
+func SplitArgs(line string) ([]string, error) {
+	args, _, err := tokenize(line)
+	return args, err
+}
+
+// tokenize splits line into arguments. It returns the arguments parsed so far
+// and whether the line ends with an argument separator. The error is not nil
+// if the line ends with an unterminated quote or escape, in which case the
+// last argument contains the partial value.
+func tokenize(line string) ([]string, bool, error) {
+	var (
+		args    []string
+		cur     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	switch {
+	case quote != 0:
+		return args, false, fmt.Errorf("unterminated %c quote", quote)
+	case escaped:
+		return args, false, fmt.Errorf("unterminated escape")
+	}
+	return args, !inArg, nil
+}
+
+// trimNewline removes a single trailing newline from s.
+func trimNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandArgs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goacli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "body.json")
+	if err := ioutil.WriteFile(path, []byte("{\"a\": 1}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmds := []*Command{{
+		Name: "svc",
+		Subcommands: []*Command{{
+			Name:  "method",
+			Flags: []*Flag{{Name: "id"}, {Name: "name"}, {Name: "body", File: true}, {Name: "x", File: true}},
+		}},
+	}}
+	cases := map[string]struct {
+		Args     []string
+		Stdin    io.Reader
+		Expected []string
+		Error    string
+	}{
+		"none":          {Args: []string{"svc", "method", "-id", "1"}, Expected: []string{"svc", "method", "-id", "1"}},
+		"file":          {Args: []string{"svc", "method", "-body", "@" + path}, Expected: []string{"svc", "method", "-body", `{"a": 1}`}},
+		"flag-file":     {Args: []string{"svc", "method", "--body=@" + path}, Expected: []string{"svc", "method", `--body={"a": 1}`}},
+		"stdin":         {Args: []string{"svc", "method", "-body", "@-"}, Stdin: strings.NewReader("payload\r\n"), Expected: []string{"svc", "method", "-body", "payload"}},
+		"escape":        {Args: []string{"svc", "method", "-body", "@@user"}, Expected: []string{"svc", "method", "-body", "@user"}},
+		"at":            {Args: []string{"svc", "method", "-body", "@"}, Expected: []string{"svc", "method", "-body", "@"}},
+		"not-file-flag": {Args: []string{"svc", "method", "-name", "@user", "--id=@" + path}, Expected: []string{"svc", "method", "-name", "@user", "--id=@" + path}},
+		"not-flag":      {Args: []string{"svc", "method", "@" + path}, Expected: []string{"svc", "method", "@" + path}},
+		"unknown-cmd":   {Args: []string{"other", "method", "-body", "@" + path}, Expected: []string{"other", "method", "-body", "@" + path}},
+		"missing-value": {Args: []string{"svc", "method", "-body"}, Expected: []string{"svc", "method", "-body"}},
+		"missing-file":  {Args: []string{"svc", "method", "-body", "@" + filepath.Join(dir, "missing")}, Error: "failed to read"},
+		"no-stdin":      {Args: []string{"svc", "method", "-body", "@-"}, Error: `cannot read "@-": stdin is not available`},
+		"stdin-twice":   {Args: []string{"svc", "method", "-body", "@-", "-x=@-"}, Stdin: strings.NewReader("a"), Error: `cannot read "@-": stdin was already read`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			args, err := ExpandArgs(tc.Args, tc.Stdin, cmds)
+			if tc.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Error) {
+					t.Fatalf("got error %v, expected %q", err, tc.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(args, tc.Expected) {
+				t.Errorf("got %q, expected %q", args, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	cases := map[string]struct {
+		Line     string
+		Expected []string
+		Error    string
+	}{
+		"empty":         {Line: "  ", Expected: nil},
+		"words":         {Line: "svc  method\t-id 1", Expected: []string{"svc", "method", "-id", "1"}},
+		"single-quotes": {Line: `-body '{"a": "b c"}'`, Expected: []string{"-body", `{"a": "b c"}`}},
+		"double-quotes": {Line: `-body "{\"a\": \"b\\n\"}"`, Expected: []string{"-body", `{"a": "b\n"}`}},
+		"escape":        {Line: `a\ b c`, Expected: []string{"a b", "c"}},
+		"empty-quotes":  {Line: `a '' b`, Expected: []string{"a", "", "b"}},
+		"unterminated":  {Line: `a 'b`, Error: "unterminated ' quote"},
+		"trailing-esc":  {Line: `a \`, Error: "unterminated escape"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			args, err := SplitArgs(tc.Line)
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("got error %v, expected %q", err, tc.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(args, tc.Expected) {
+				t.Errorf("got %q, expected %q", args, tc.Expected)
+			}
+		})
+	}
+}
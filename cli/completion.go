@@ -0,0 +1,384 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+type (
+	// completionData is the data used to render the completion scripts.
+	completionData struct {
+		// Prog is the name of the command line client.
+		Prog string
+		// Func is the name of the completion function without leading
+		// underscores.
+		Func string
+		// Global lists the flags that may appear before the command.
+		Global []*Flag
+		// GlobalValue lists the names of the global flags that take a
+		// value.
+		GlobalValue []string
+		// Commands lists the commands.
+		Commands []*Command
+	}
+)
+
+// Shells lists the shells supported by WriteCompletion.
+var Shells = []string{"bash", "zsh", "fish"}
+
+var (
+	// completionTmpls contains the completion script templates indexed by
+	// shell.
+	completionTmpls = map[string]*template.Template{}
+
+	// nonIdentRegexp matches the characters that may not appear in a shell
+	// function name.
+	nonIdentRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+func init() {
+	funcs := template.FuncMap{
+		"flagPattern": flagPattern,
+		"words":       words,
+		"sq":          singleQuote,
+		"zdesc":       zshDescribe,
+		"fq":          fishQuote,
+		"summary":     summary,
+		"has":         contains,
+		"join":        strings.Join,
+	}
+	for shell, src := range map[string]string{"bash": bashT, "zsh": zshT, "fish": fishT} {
+		completionTmpls[shell] = template.Must(template.New(shell).Funcs(funcs).Parse(src))
+	}
+}
+
+// WriteCompletion writes the script that completes the commands,
+// sub-commands, flags and flag values of the command line client prog to w.
+// shell is the name of the targeted shell, one of "bash", "zsh" or "fish".
+// global defines the flags that may be given before the command. The script
+// also completes the "completion" command whose sub-commands are the
+// supported shells.
+func WriteCompletion(w io.Writer, shell, prog string, global *flag.FlagSet, cmds []*Command) error {
+	tmpl, ok := completionTmpls[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (valid shells: %s)", shell, strings.Join(Shells, "|"))
+	}
+	data := &completionData{
+		Prog:     prog,
+		Func:     nonIdentRegexp.ReplaceAllString(prog, "_"),
+		Commands: append(append([]*Command{}, cmds...), completionCommand()),
+	}
+	if global != nil {
+		global.VisitAll(func(f *flag.Flag) {
+			data.Global = append(data.Global, &Flag{Name: f.Name, Description: f.Usage})
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !b.IsBoolFlag() {
+				data.GlobalValue = append(data.GlobalValue, f.Name)
+			}
+		})
+	}
+	return tmpl.Execute(w, data)
+}
+
+// completionCommand returns the "completion" command.
+func completionCommand() *Command {
+	subs := make([]*Command, len(Shells))
+	for i, s := range Shells {
+		subs[i] = &Command{Name: s, Description: fmt.Sprintf("Print the %s completion script.", s)}
+	}
+	return &Command{
+		Name:        "completion",
+		Description: "Print the shell completion script.",
+		Subcommands: subs,
+	}
+}
+
+// flagPattern returns the shell case pattern matching the given flag name or
+// names prefixed with one or two dashes.
+func flagPattern(v interface{}) string {
+	names, ok := v.([]string)
+	if !ok {
+		names = []string{v.(string)}
+	}
+	pats := make([]string, 0, 2*len(names))
+	for _, n := range names {
+		pats = append(pats, "-"+n, "--"+n)
+	}
+	return strings.Join(pats, "|")
+}
+
+// words returns the single-quoted space separated list of the given words
+// prefixed with prefix. Words containing whitespace or quotes cannot be
+// completed by compgen and are skipped.
+func words(prefix string, ws interface{}) string {
+	var res []string
+	add := func(w string) {
+		if !strings.ContainsAny(w, " \t\n'\"\\") {
+			res = append(res, prefix+w)
+		}
+	}
+	switch actual := ws.(type) {
+	case []string:
+		for _, w := range actual {
+			add(w)
+		}
+	case []*Command:
+		for _, c := range actual {
+			add(c.Name)
+		}
+	case []*Flag:
+		for _, f := range actual {
+			add(f.Name)
+		}
+	}
+	return singleQuote(strings.Join(res, " "))
+}
+
+// singleQuote quotes s for bash and zsh.
+func singleQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// zshDescribe returns the quoted _describe entry for the given name and
+// description.
+func zshDescribe(name, desc string) string {
+	entry := strings.Replace(name, ":", `\:`, -1)
+	if desc = summary(desc); desc != "" {
+		entry += ":" + desc
+	}
+	return singleQuote(entry)
+}
+
+// fishQuote quotes s for fish.
+func fishQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return "'" + strings.Replace(s, "'", `\'`, -1) + "'"
+}
+
+// summary returns the first line of the given description.
+func summary(desc string) string {
+	return strings.TrimSpace(strings.SplitN(desc, "\n", 2)[0])
+}
+
+// input: completionData
+const bashT = `# bash completion for {{ .Prog }}
+#
+# Load the completion in the current shell with:
+#
+#     source <({{ .Prog }} completion bash)
+
+_{{ .Func }}() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    local cmd="" sub="" words="" i w
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        w="${COMP_WORDS[i]}"
+        if [[ -z "$cmd" ]]; then
+            case "$w" in
+{{- if .GlobalValue }}
+            {{ flagPattern .GlobalValue }}) ((i++)); continue ;;
+{{- end }}
+            -*) continue ;;
+            esac
+            cmd="$w"
+        elif [[ -z "$sub" && "$w" != -* ]]; then
+            sub="$w"
+        fi
+    done
+    if [[ -z "$cmd" ]]; then
+{{- if .GlobalValue }}
+        case "$prev" in
+        {{ flagPattern .GlobalValue }}) return ;;
+        esac
+{{- end }}
+        if [[ "$cur" == -* ]]; then
+            words={{ words "-" .Global }}
+        else
+            words={{ words "" .Commands }}
+        fi
+    elif [[ -z "$sub" ]]; then
+        case "$cmd" in
+{{- range .Commands }}
+        {{ sq .Name }}) words={{ words "" .Subcommands }} ;;
+{{- end }}
+        esac
+    else
+        case "$cmd $sub" in
+{{- range $cmd := .Commands }}
+	{{- range .Subcommands }}
+		{{- if .Flags }}
+        {{ sq (printf "%s %s" $cmd.Name .Name) }})
+            case "$prev" in
+			{{- range .Flags }}
+				{{- if .Values }}
+            {{ flagPattern .Name }}) words={{ words "" .Values }} ;;
+				{{- end }}
+			{{- end }}
+            -*=*) words={{ words "--" .Flags }} ;;
+            -*) ;;
+            *) words={{ words "--" .Flags }} ;;
+            esac
+            ;;
+		{{- end }}
+	{{- end }}
+{{- end }}
+        esac
+    fi
+    COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+
+complete -o default -F _{{ .Func }} {{ .Prog }}
+`
+
+// input: completionData
+const zshT = `#compdef {{ .Prog }}
+
+# zsh completion for {{ .Prog }}
+#
+# Load the completion in the current shell with:
+#
+#     source <({{ .Prog }} completion zsh)
+#
+# or save the script in a file named _{{ .Prog }} in a directory listed in $fpath.
+
+_{{ .Func }}() {
+    local cur="${words[CURRENT]}" prev="${words[CURRENT-1]}"
+    local cmd="" sub="" i w
+    local -a cands
+    for ((i = 2; i < CURRENT; i++)); do
+        w="${words[i]}"
+        if [[ -z "$cmd" ]]; then
+            case "$w" in
+{{- if .GlobalValue }}
+            {{ flagPattern .GlobalValue }}) ((i++)); continue ;;
+{{- end }}
+            -*) continue ;;
+            esac
+            cmd="$w"
+        elif [[ -z "$sub" && "$w" != -* ]]; then
+            sub="$w"
+        fi
+    done
+    if [[ -z "$cmd" ]]; then
+{{- if .GlobalValue }}
+        case "$prev" in
+        {{ flagPattern .GlobalValue }}) _files; return ;;
+        esac
+{{- end }}
+        if [[ "$cur" == -* ]]; then
+            cands=({{ range .Global }} {{ zdesc (printf "-%s" .Name) .Description }}{{ end }} )
+        else
+            cands=({{ range .Commands }} {{ zdesc .Name .Description }}{{ end }} )
+        fi
+    elif [[ -z "$sub" ]]; then
+        case "$cmd" in
+{{- range .Commands }}
+        {{ sq .Name }}) cands=({{ range .Subcommands }} {{ zdesc .Name .Description }}{{ end }} ) ;;
+{{- end }}
+        esac
+    else
+        case "$cmd $sub" in
+{{- range $cmd := .Commands }}
+	{{- range .Subcommands }}
+		{{- if .Flags }}
+        {{ sq (printf "%s %s" $cmd.Name .Name) }})
+            case "$prev" in
+			{{- range .Flags }}
+				{{- if .Values }}
+            {{ flagPattern .Name }}) cands=({{ range .Values }} {{ zdesc . "" }}{{ end }} ) ;;
+				{{- end }}
+			{{- end }}
+            -*=*) cands=({{ range .Flags }} {{ zdesc (printf "--%s" .Name) .Description }}{{ end }} ) ;;
+            -*) ;;
+            *) cands=({{ range .Flags }} {{ zdesc (printf "--%s" .Name) .Description }}{{ end }} ) ;;
+            esac
+            ;;
+		{{- end }}
+	{{- end }}
+{{- end }}
+        esac
+    fi
+    if (( ${#cands} )); then
+        _describe -t values {{ sq .Prog }} cands
+    else
+        _files
+    fi
+}
+
+if [[ "$funcstack[1]" == "_{{ .Prog }}" ]]; then
+    _{{ .Func }} "$@"
+else
+    compdef _{{ .Func }} {{ .Prog }}
+fi
+`
+
+// input: completionData
+const fishT = `# fish completion for {{ .Prog }}
+#
+# Load the completion in the current shell with:
+#
+#     {{ .Prog }} completion fish | source
+#
+# or save the script in a file named {{ .Prog }}.fish in
+# ~/.config/fish/completions.
+
+function __{{ .Func }}_args
+    set -l words (commandline -opc)
+    set -e words[1]
+    set -l args
+    set -l skip 0
+    for w in $words
+        if test $skip -eq 1
+            set skip 0
+            continue
+        end
+        switch $w
+{{- if .GlobalValue }}
+            case{{ range .GlobalValue }} {{ fq (printf "-%s" .) }} {{ fq (printf "--%s" .) }}{{ end }}
+                if test (count $args) -eq 0
+                    set skip 1
+                end
+{{- end }}
+            case '-*'
+            case '*'
+                set args $args $w
+        end
+    end
+    printf '%s\n' $args
+end
+
+function __{{ .Func }}_needs_command
+    set -l args (__{{ .Func }}_args)
+    test (count $args) -eq 0
+end
+
+function __{{ .Func }}_needs_subcommand
+    set -l args (__{{ .Func }}_args)
+    test (count $args) -eq 1; and test "$args[1]" = "$argv[1]"
+end
+
+function __{{ .Func }}_using
+    set -l args (__{{ .Func }}_args)
+    test (count $args) -ge 2; and test "$args[1]" = "$argv[1]"; and test "$args[2]" = "$argv[2]"
+end
+{{ range .Global }}
+complete -c {{ $.Prog }} -n __{{ $.Func }}_needs_command -o {{ fq .Name }}{{ if has $.GlobalValue .Name }} -r{{ end }}{{ with summary .Description }} -d {{ fq . }}{{ end }}
+{{- end }}
+{{- range .Commands }}
+complete -c {{ $.Prog }} -n __{{ $.Func }}_needs_command -f -a {{ fq .Name }}{{ with summary .Description }} -d {{ fq . }}{{ end }}
+{{- end }}
+{{- range $cmd := .Commands }}
+	{{- range .Subcommands }}
+complete -c {{ $.Prog }} -n {{ fq (printf "__%s_needs_subcommand %s" $.Func $cmd.Name) }} -f -a {{ fq .Name }}{{ with summary .Description }} -d {{ fq . }}{{ end }}
+	{{- end }}
+{{- end }}
+{{- range $cmd := .Commands }}
+	{{- range $sub := .Subcommands }}
+		{{- range .Flags }}
+complete -c {{ $.Prog }} -n {{ fq (printf "__%s_using %s %s" $.Func $cmd.Name $sub.Name) }} -l {{ fq .Name }} -r{{ if .Values }} -f -a {{ fq (join .Values " ") }}{{ end }}{{ with summary .Description }} -d {{ fq . }}{{ end }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+`
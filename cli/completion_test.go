@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	global := flag.NewFlagSet("test", flag.ContinueOnError)
+	global.String("host", "localhost", "Server host")
+	global.Bool("v", false, "Print details")
+	cases := map[string][]string{
+		"bash": {
+			"_storage_cli() {",
+			"-host|--host) ((i++)); continue ;;",
+			"words='-host -v'",
+			"words='storage sommelier completion'",
+			"'storage') words='list show' ;;",
+			"-view|--view) words='default tiny' ;;",
+			"complete -o default -F _storage_cli storage-cli",
+		},
+		"zsh": {
+			"#compdef storage-cli",
+			"cands=( '-host:Server host' '-v:Print details' )",
+			"-view|--view) cands=( 'default' 'tiny' ) ;;",
+			`if [[ "$funcstack[1]" == "_storage-cli" ]]; then`,
+		},
+		"fish": {
+			"case '-host' '--host'",
+			"complete -c storage-cli -n __storage_cli_needs_command -o 'v' -d 'Print details'",
+			"complete -c storage-cli -n '__storage_cli_needs_subcommand storage' -f -a 'list'",
+			"complete -c storage-cli -n '__storage_cli_using storage list' -l 'view' -r -f -a 'default tiny'",
+		},
+	}
+	for shell, expected := range cases {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteCompletion(&buf, shell, "storage-cli", global, testCommands); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, e := range expected {
+				if !strings.Contains(buf.String(), e) {
+					t.Errorf("script does not contain %q:\n%s", e, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWriteCompletionUnsupportedShell(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCompletion(&buf, "csh", "storage-cli", nil, testCommands)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if e := `unsupported shell "csh" (valid shells: bash|zsh|fish)`; err.Error() != e {
+		t.Errorf("got error %q, expected %q", err.Error(), e)
+	}
+}
+
+func TestBashCompletion(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not available")
+	}
+	global := flag.NewFlagSet("test", flag.ContinueOnError)
+	global.String("host", "localhost", "Server host")
+	global.Bool("v", false, "Print details")
+	var script bytes.Buffer
+	if err := WriteCompletion(&script, "bash", "storage-cli", global, testCommands); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cases := map[string]struct {
+		Line     string
+		Expected string
+	}{
+		"commands":       {"storage-cli ", "storage sommelier completion"},
+		"global-flags":   {"storage-cli -", "-host -v"},
+		"global-value":   {"storage-cli -host ", ""},
+		"after-global":   {"storage-cli -host localhost -v s", "storage sommelier"},
+		"subcommands":    {"storage-cli storage ", "list show"},
+		"flags":          {"storage-cli storage list ", "--view --limit"},
+		"flag-values":    {"storage-cli storage list --view t", "tiny"},
+		"after-value":    {"storage-cli storage list --view tiny --l", "--limit"},
+		"free-value":     {"storage-cli storage list --limit ", ""},
+		"shells":         {"storage-cli completion ", "bash zsh fish"},
+		"unknown-prefix": {"storage-cli x", ""},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			src := script.String() + `
+COMP_WORDS=(` + tc.Line + `)
+[[ "` + tc.Line + `" == *" " ]] && COMP_WORDS+=("")
+COMP_CWORD=$((${#COMP_WORDS[@]} - 1))
+_storage_cli
+echo "${COMPREPLY[*]}"
+`
+			out, err := exec.Command("bash", "-c", src).CombinedOutput()
+			if err != nil {
+				t.Fatalf("bash failed: %s\n%s", err, out)
+			}
+			if got := strings.TrimSpace(string(out)); got != tc.Expected {
+				t.Errorf("got %q, expected %q", got, tc.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultProfile is the name of the profile used when none is
+	// specified.
+	DefaultProfile = "default"
+
+	// CredentialToken identifies the flags that provide JWT or OAuth2
+	// tokens.
+	CredentialToken = "token"
+	// CredentialAPIKey identifies the flags that provide API keys.
+	CredentialAPIKey = "api-key"
+)
+
+// Defaults provides the default values of the flags that are not given on the
+// command line. The values are read from environment variables and from a
+// profile of a configuration file, environment variables take precedence.
+//
+// The default value of a global flag is the value of the environment variable
+// named after the flag, e.g. PREFIX_URL for the "url" flag, or else the value of
+// the flag in the profile.
+//
+// The default value of a sub-command flag is the value of the environment
+// variable named after the command, sub-command and flag, e.g.
+// PREFIX_STORAGE_SHOW_ID for the "id" flag of the "storage show" command, or
+// else the value of the flag in the sub-command section of the profile.
+//
+// The configuration file is a YAML document that maps profile names to
+// profiles. A profile maps flag names to values and command names to
+// sub-command names to flag values:
+//
+//	default:
+//	  url: http://localhost:8080
+//	staging:
+//	  url: https://staging.example.com
+//	  token: d3b07384d113
+//	  storage:
+//	    show:
+//	      view: tiny
+//
+// Flag values that are objects or arrays are serialized to JSON.
+//
+// The default value of a flag that provides credentials (see Flag.Credential)
+// is looked up in order from:
+//
+//  1. the environment variable named after the command, sub-command and flag,
+//  2. the credential set with SetCredential,
+//  3. the flag in the sub-command section of the profile,
+//  4. the environment variable named after the flag, e.g. PREFIX_TOKEN,
+//  5. the flag at the top level of the profile.
+//
+// This makes it possible to provide credentials used by many commands once.
+type Defaults struct {
+	prefix      string
+	profile     yaml.MapSlice
+	credentials map[string]string
+}
+
+// LoadDefaults loads the defaults read from the environment variables whose
+// names start with prefix followed by an underscore and from the given
+// profile of the configuration file at path.
+//
+// If path is empty LoadDefaults uses the value of the PREFIX_CONFIG
+// environment variable or DefaultConfigPath if it is not set either. If
+// profile is empty LoadDefaults uses the value of the PREFIX_PROFILE
+// environment variable or DefaultProfile if it is not set either. It is not
+// an error for the default configuration file or profile not to exist.
+func LoadDefaults(prefix, path, profile string) (*Defaults, error) {
+	d := &Defaults{prefix: prefix}
+	if path == "" {
+		path = os.Getenv(prefix + "_CONFIG")
+	}
+	explicitPath := path != ""
+	if !explicitPath {
+		path = DefaultConfigPath(prefix)
+	}
+	if profile == "" {
+		profile = os.Getenv(prefix + "_PROFILE")
+	}
+	explicitProfile := profile != ""
+	if !explicitProfile {
+		profile = DefaultProfile
+	}
+	if path == "" {
+		return d, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicitPath && !explicitProfile {
+			return d, nil
+		}
+		return nil, fmt.Errorf("failed to read configuration file: %s", err)
+	}
+	var profiles yaml.MapSlice
+	if err := yaml.Unmarshal(b, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %q: %s", path, err)
+	}
+	p, ok := lookup(profiles, profile)
+	if !ok {
+		if explicitProfile {
+			return nil, fmt.Errorf("profile %q not found in configuration file %q", profile, path)
+		}
+		return d, nil
+	}
+	if p != nil {
+		if d.profile, ok = p.(yaml.MapSlice); !ok {
+			return nil, fmt.Errorf("invalid profile %q in configuration file %q: must be a mapping", profile, path)
+		}
+	}
+	return d, nil
+}
+
+// DefaultConfigPath returns the path of the default configuration file for
+// the given environment variable prefix, e.g. "$HOME/.config/prefix/config.yaml"
+// on Linux. It returns an empty string if the user configuration directory
+// cannot be determined.
+func DefaultConfigPath(prefix string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, strings.ToLower(prefix), "config.yaml")
+}
+
+// SetFlags sets the flags of fs that were not given on the command line to
+// their default values if any. fs must have been parsed.
+func (d *Defaults) SetFlags(fs *flag.FlagSet) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if given[f.Name] || err != nil {
+			return
+		}
+		v, ok := d.lookup(f.Name)
+		if !ok {
+			return
+		}
+		if e := fs.Set(f.Name, v); e != nil {
+			err = fmt.Errorf("invalid default value %q for flag -%s: %s", v, f.Name, e)
+		}
+	})
+	return err
+}
+
+// SetCredential sets the default value of the flags that provide the given
+// kind of credential, see Flag.Credential. An empty value unsets it.
+func (d *Defaults) SetCredential(kind, value string) {
+	if d.credentials == nil {
+		d.credentials = make(map[string]string)
+	}
+	d.credentials[kind] = value
+}
+
+// Args returns a copy of the given arguments where the flags of the
+// sub-command that are not given are set to their default values if any. The
+// first two arguments must be the command and sub-command names, the
+// arguments are returned as is otherwise.
+func (d *Defaults) Args(args []string, cmds []*Command) []string {
+	res := append([]string{}, args...)
+	if len(args) < 2 {
+		return res
+	}
+	cmd := findCommand(cmds, args[0])
+	if cmd == nil {
+		return res
+	}
+	sub := findCommand(cmd.Subcommands, args[1])
+	if sub == nil {
+		return res
+	}
+	given := make(map[string]bool)
+	for _, arg := range args[2:] {
+		if f := findFlag(sub.Flags, strings.SplitN(arg, "=", 2)[0]); f != nil {
+			given[f.Name] = true
+		}
+	}
+	for _, f := range sub.Flags {
+		if given[f.Name] {
+			continue
+		}
+		if v, ok := d.flagDefault(cmd.Name, sub.Name, f); ok {
+			res = append(res, "--"+f.Name+"="+v)
+		}
+	}
+	return res
+}
+
+// flagDefault returns the default value of the given sub-command flag.
+func (d *Defaults) flagDefault(cmd, sub string, f *Flag) (string, bool) {
+	if f.Credential != "" {
+		if v, ok := os.LookupEnv(envName(d.prefix, cmd, sub, f.Name)); ok {
+			return v, true
+		}
+		if v := d.credentials[f.Credential]; v != "" {
+			return v, true
+		}
+	}
+	if v, ok := d.lookup(cmd, sub, f.Name); ok {
+		return v, true
+	}
+	if f.Credential != "" {
+		return d.lookup(f.Name)
+	}
+	return "", false
+}
+
+// lookup returns the default value for the flag identified by the given path,
+// either the flag name for global flags or the command, sub-command and flag
+// names for sub-command flags.
+func (d *Defaults) lookup(path ...string) (string, bool) {
+	if v, ok := os.LookupEnv(envName(d.prefix, path...)); ok {
+		return v, true
+	}
+	var val interface{} = d.profile
+	for _, key := range path {
+		m, ok := val.(yaml.MapSlice)
+		if !ok {
+			val = nil
+			break
+		}
+		if val, ok = lookup(m, key); !ok {
+			break
+		}
+	}
+	return flagValue(val)
+}
+
+// envName returns the name of the environment variable for the given flag
+// path.
+func envName(prefix string, path ...string) string {
+	name := strings.Join(append([]string{prefix}, path...), "_")
+	return strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// lookup returns the value of the given key in m.
+func lookup(m yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+// flagValue returns the flag value corresponding to the given profile value.
+// Objects and arrays are serialized to JSON. Nested profile sections (e.g. the
+// values of a command) are not flag values.
+func flagValue(v interface{}) (string, bool) {
+	switch actual := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return actual, true
+	case yaml.MapSlice, []interface{}:
+		b, err := json.Marshal(toJSON(actual))
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	default:
+		return fmt.Sprint(actual), true
+	}
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const testConfig = `default:
+  url: http://localhost:8080
+staging:
+  url: https://staging.example.com
+  view: default
+  limit: 10
+  storage:
+    list:
+      view: tiny
+    show:
+      id: abc
+scalar: foo
+`
+
+func TestLoadDefaults(t *testing.T) {
+	path := writeConfig(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	cases := map[string]struct {
+		Path    string
+		Profile string
+		Env     map[string]string
+		URL     string
+		Error   string
+	}{
+		"default-profile":   {Path: path, URL: "http://localhost:8080"},
+		"profile":           {Path: path, Profile: "staging", URL: "https://staging.example.com"},
+		"env-profile":       {Path: path, Env: map[string]string{"TEST_PROFILE": "staging"}, URL: "https://staging.example.com"},
+		"env-config":        {Env: map[string]string{"TEST_CONFIG": path}, URL: "http://localhost:8080"},
+		"env-url":           {Path: path, Profile: "staging", Env: map[string]string{"TEST_URL": "http://env"}, URL: "http://env"},
+		"missing-profile":   {Path: path, Profile: "prod", Error: `profile "prod" not found`},
+		"invalid-profile":   {Path: path, Profile: "scalar", Error: `invalid profile "scalar"`},
+		"missing-file":      {Path: path + ".missing", Error: "failed to read configuration file"},
+		"missing-default":   {Env: map[string]string{"HOME": filepath.Dir(path), "XDG_CONFIG_HOME": filepath.Dir(path)}, URL: "http://default"},
+		"missing-with-prof": {Path: path + ".missing", Profile: "staging", Error: "failed to read configuration file"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			defer setenv(tc.Env)()
+			d, err := LoadDefaults("TEST", tc.Path, tc.Profile)
+			if tc.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Error) {
+					t.Fatalf("got error %v, expected %q", err, tc.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			url := fs.String("url", "http://default", "")
+			if err := fs.Parse(nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.SetFlags(fs); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *url != tc.URL {
+				t.Errorf("got URL %q, expected %q", *url, tc.URL)
+			}
+		})
+	}
+}
+
+func TestDefaultsSetFlags(t *testing.T) {
+	path := writeConfig(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer setenv(map[string]string{"TEST_VERBOSE": "true"})()
+	d, err := LoadDefaults("TEST", path, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	url := fs.String("url", "", "")
+	verbose := fs.Bool("verbose", false, "")
+	limit := fs.Int("limit", 0, "")
+	if err := fs.Parse([]string{"-limit", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetFlags(fs); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *url != "https://staging.example.com" {
+		t.Errorf("got URL %q, expected profile value", *url)
+	}
+	if !*verbose {
+		t.Errorf("got verbose false, expected environment value")
+	}
+	if *limit != 5 {
+		t.Errorf("got limit %d, expected command line value 5", *limit)
+	}
+
+	defer setenv(map[string]string{"TEST_LIMIT": "many"})()
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("limit", 0, "")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetFlags(fs); err == nil || !strings.Contains(err.Error(), `invalid default value "many" for flag -limit`) {
+		t.Errorf("got error %v, expected invalid default value", err)
+	}
+}
+
+func TestDefaultsArgs(t *testing.T) {
+	path := writeConfig(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	d, err := LoadDefaults("TEST", path, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]struct {
+		Args     []string
+		Env      map[string]string
+		Expected []string
+	}{
+		"empty":            {Args: nil, Expected: []string{}},
+		"unknown":          {Args: []string{"cellar", "list"}, Expected: []string{"cellar", "list"}},
+		"profile":          {Args: []string{"storage", "list"}, Expected: []string{"storage", "list", "--view=tiny"}},
+		"given":            {Args: []string{"storage", "list", "-view", "default"}, Expected: []string{"storage", "list", "-view", "default"}},
+		"given-equal":      {Args: []string{"storage", "list", "--limit=3"}, Expected: []string{"storage", "list", "--limit=3", "--view=tiny"}},
+		"command-profile":  {Args: []string{"storage", "show"}, Expected: []string{"storage", "show", "--id=abc"}},
+		"unscoped-env":     {Args: []string{"storage", "list"}, Env: map[string]string{"TEST_LIMIT": "5"}, Expected: []string{"storage", "list", "--view=tiny"}},
+		"unscoped-env-set": {Args: []string{"storage", "show"}, Env: map[string]string{"TEST_ID": "env"}, Expected: []string{"storage", "show", "--id=abc"}},
+		"env-command": {
+			Args:     []string{"storage", "show"},
+			Env:      map[string]string{"TEST_ID": "env", "TEST_STORAGE_SHOW_ID": "cmd"},
+			Expected: []string{"storage", "show", "--id=cmd"},
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			defer setenv(tc.Env)()
+			args := d.Args(tc.Args, testCommands)
+			if !reflect.DeepEqual(args, tc.Expected) {
+				t.Errorf("got %q, expected %q", args, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDefaultsCredentials(t *testing.T) {
+	cmds := []*Command{{
+		Name: "storage",
+		Subcommands: []*Command{
+			{Name: "show", Flags: []*Flag{{Name: "id"}, {Name: "token", Credential: CredentialToken}}},
+			{Name: "remove", Flags: []*Flag{{Name: "key", Credential: CredentialAPIKey}}},
+		},
+	}}
+	d := &Defaults{prefix: "TEST"}
+	if err := yaml.Unmarshal([]byte("id: top\nkey: profile\n"), &d.profile); err != nil {
+		t.Fatal(err)
+	}
+	d.SetCredential(CredentialToken, "global")
+	cases := map[string]struct {
+		Args     []string
+		Env      map[string]string
+		Expected []string
+	}{
+		"token":         {Args: []string{"storage", "show"}, Expected: []string{"storage", "show", "--token=global"}},
+		"given":         {Args: []string{"storage", "show", "-token", "t"}, Expected: []string{"storage", "show", "-token", "t"}},
+		"env-command":   {Args: []string{"storage", "show"}, Env: map[string]string{"TEST_STORAGE_SHOW_TOKEN": "cmd"}, Expected: []string{"storage", "show", "--token=cmd"}},
+		"env-flag":      {Args: []string{"storage", "show"}, Env: map[string]string{"TEST_TOKEN": "env"}, Expected: []string{"storage", "show", "--token=global"}},
+		"no-credential": {Args: []string{"storage", "remove"}, Env: map[string]string{"TEST_KEY": "env"}, Expected: []string{"storage", "remove", "--key=env"}},
+		"profile":       {Args: []string{"storage", "remove"}, Expected: []string{"storage", "remove", "--key=profile"}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			defer setenv(tc.Env)()
+			args := d.Args(tc.Args, cmds)
+			if !reflect.DeepEqual(args, tc.Expected) {
+				t.Errorf("got %q, expected %q", args, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	d := &Defaults{}
+	if err := yaml.Unmarshal([]byte("body:\n  name: x\n  tags: [a, b]\n"), &d.profile); err != nil {
+		t.Fatal(err)
+	}
+	v, ok := d.lookup("body")
+	if !ok {
+		t.Fatal("body not found")
+	}
+	if expected := `{"name":"x","tags":["a","b"]}`; v != expected {
+		t.Errorf("got %q, expected %q", v, expected)
+	}
+}
+
+// writeConfig writes the test configuration file to a temporary directory and
+// returns its path.
+func writeConfig(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "goacli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(testConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setenv sets the given environment variables and returns a function that
+// restores their previous values.
+func setenv(env map[string]string) func() {
+	prev := make(map[string]*string, len(env))
+	for k, v := range env {
+		if p, ok := os.LookupEnv(k); ok {
+			prev[k] = &p
+		} else {
+			prev[k] = nil
+		}
+		os.Setenv(k, v)
+	}
+	return func() {
+		for k, p := range prev {
+			if p == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *p)
+			}
+		}
+	}
+}
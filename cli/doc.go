@@ -0,0 +1,10 @@
+/*
+Package cli contains the constructs used by the generated command line clients
+at runtime. The constructs include the output formatters, the expansion of
+the "@file" values of the flags that accept JSON and an interactive shell with
+tab completion of the commands, sub-commands and flags.
+
+The generated code imports the package as "goacli" to avoid conflicts with the
+generated "cli" packages.
+*/
+package cli
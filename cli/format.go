@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// FormatJSON renders the results as indented JSON.
+	FormatJSON = "json"
+	// FormatYAML renders the results as YAML.
+	FormatYAML = "yaml"
+	// FormatTable renders collections as a table with one row per element
+	// and one column per field. Objects are rendered as a table with one
+	// row per field. Other values are rendered as JSON.
+	FormatTable = "table"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatJSON, FormatYAML, FormatTable}
+
+// ValidateFormat returns an error if the given output format is not
+// supported.
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (valid formats: %s)", format, strings.Join(Formats, "|"))
+}
+
+// Print writes v to w using the given output format. The values are first
+// serialized to JSON so that the fields are named and ordered consistently
+// across the formats. Print writes nothing if v is nil.
+func Print(w io.Writer, v interface{}, format string) error {
+	if v == nil {
+		return nil
+	}
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	if format == FormatJSON {
+		b, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	val, err := decodeOrdered(b)
+	if err != nil {
+		return err
+	}
+	if format == FormatYAML {
+		b, err := yaml.Marshal(val)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	return printTable(w, val, v)
+}
+
+// printTable writes the table rendering of val to w. orig is the value
+// being printed, it is rendered as JSON if val cannot be rendered as a table.
+func printTable(w io.Writer, val, orig interface{}) error {
+	var (
+		header []string
+		rows   [][]string
+	)
+	switch actual := val.(type) {
+	case []interface{}:
+		for _, elem := range actual {
+			if obj, ok := elem.(yaml.MapSlice); ok {
+				for _, item := range obj {
+					if !contains(header, item.Key.(string)) {
+						header = append(header, item.Key.(string))
+					}
+				}
+			}
+		}
+		for _, elem := range actual {
+			obj, ok := elem.(yaml.MapSlice)
+			if !ok {
+				if len(header) > 0 {
+					return Print(w, orig, FormatJSON)
+				}
+				rows = append(rows, []string{cell(elem)})
+				continue
+			}
+			row := make([]string, len(header))
+			for _, item := range obj {
+				for i, h := range header {
+					if h == item.Key.(string) {
+						row[i] = cell(item.Value)
+					}
+				}
+			}
+			rows = append(rows, row)
+		}
+		if len(header) == 0 {
+			header = []string{"value"}
+		}
+	case yaml.MapSlice:
+		header = []string{"field", "value"}
+		for _, item := range actual {
+			rows = append(rows, []string{item.Key.(string), cell(item.Value)})
+		}
+	default:
+		return Print(w, orig, FormatJSON)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, h := range header {
+		header[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// cell returns the table cell rendering of v. Scalars are rendered as is,
+// objects and arrays are rendered as compact JSON.
+func cell(v interface{}) string {
+	switch actual := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	case bool:
+		return strconv.FormatBool(actual)
+	case int64:
+		return strconv.FormatInt(actual, 10)
+	case float64:
+		return strconv.FormatFloat(actual, 'g', -1, 64)
+	default:
+		b, _ := json.Marshal(toJSON(v))
+		return string(b)
+	}
+}
+
+// toJSON converts the ordered maps of v into maps that encoding/json can
+// serialize.
+func toJSON(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case yaml.MapSlice:
+		m := make(map[string]interface{}, len(actual))
+		for _, item := range actual {
+			m[fmt.Sprint(item.Key)] = toJSON(item.Value)
+		}
+		return m
+	case []interface{}:
+		res := make([]interface{}, len(actual))
+		for i, elem := range actual {
+			res[i] = toJSON(elem)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// decodeOrdered decodes the given JSON preserving the order of the object
+// fields. Objects are decoded into yaml.MapSlice values, integers into int64
+// values and other numbers into float64 values.
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+// decodeValue decodes the next JSON value read from dec.
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch actual := tok.(type) {
+	case json.Delim:
+		switch actual {
+		case '{':
+			var obj yaml.MapSlice
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				val, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, yaml.MapItem{Key: key.(string), Value: val})
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			if obj == nil {
+				obj = yaml.MapSlice{}
+			}
+			return obj, nil
+		case '[':
+			arr := []interface{}{}
+			for dec.More() {
+				val, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, val)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return arr, nil
+		}
+		return nil, fmt.Errorf("unexpected JSON delimiter %q", actual)
+	case json.Number:
+		if i, err := actual.Int64(); err == nil {
+			return i, nil
+		}
+		return actual.Float64()
+	default:
+		return actual, nil
+	}
+}
+
+// contains returns true if s contains v.
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
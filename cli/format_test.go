@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	type (
+		item struct {
+			ID   int               `json:"id"`
+			Name string            `json:"name,omitempty"`
+			Tags []string          `json:"tags,omitempty"`
+			Meta map[string]string `json:"meta,omitempty"`
+		}
+	)
+	var (
+		obj  = &item{ID: 1, Name: "a", Tags: []string{"x", "y"}}
+		coll = []*item{{ID: 1, Name: "a"}, {ID: 2, Meta: map[string]string{"k": "v"}}}
+	)
+	cases := map[string]struct {
+		Value    interface{}
+		Format   string
+		Expected string
+	}{
+		"nil":              {nil, FormatJSON, ""},
+		"json":             {obj, FormatJSON, "{\n    \"id\": 1,\n    \"name\": \"a\",\n    \"tags\": [\n        \"x\",\n        \"y\"\n    ]\n}\n"},
+		"yaml":             {obj, FormatYAML, "id: 1\nname: a\ntags:\n- x\n- \"y\"\n"},
+		"yaml-float":       {1.5, FormatYAML, "1.5\n"},
+		"table-object":     {obj, FormatTable, "FIELD  VALUE\nid     1\nname   a\ntags   [\"x\",\"y\"]\n"},
+		"table-collection": {coll, FormatTable, "ID  NAME  META\n1   a     \n2         {\"k\":\"v\"}\n"},
+		"table-scalars":    {[]string{"a", "b"}, FormatTable, "VALUE\na\nb\n"},
+		"table-scalar":     {"a", FormatTable, "\"a\"\n"},
+		"table-mixed":      {[]interface{}{obj, "b"}, FormatTable, "[\n    {\n        \"id\": 1,\n        \"name\": \"a\",\n        \"tags\": [\n            \"x\",\n            \"y\"\n        ]\n    },\n    \"b\"\n]\n"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Print(&buf, tc.Value, tc.Format); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if buf.String() != tc.Expected {
+				t.Errorf("got\n%s\nexpected\n%s", buf.String(), tc.Expected)
+			}
+		})
+	}
+}
+
+func TestPrintInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := Print(&buf, "a", "xml")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `invalid output format "xml" (valid formats: json|yaml|table)`
+	if err.Error() != expected {
+		t.Errorf("got error %q, expected %q", err.Error(), expected)
+	}
+}
@@ -0,0 +1,391 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// Command describes a command or sub-command of a command line client.
+	// The shell uses the commands to complete the command lines.
+	Command struct {
+		// Name is the command name.
+		Name string
+		// Description is the command help text.
+		Description string
+		// Subcommands lists the command sub-commands.
+		Subcommands []*Command
+		// Flags lists the command flags.
+		Flags []*Flag
+	}
+
+	// Flag describes a command flag.
+	Flag struct {
+		// Name is the flag name without leading dashes.
+		Name string
+		// Description is the flag help text.
+		Description string
+		// Values lists the possible values of the flag if any, for
+		// example the values of an enum.
+		Values []string
+		// Credential is the kind of credential provided by the flag if
+		// any, either CredentialToken or CredentialAPIKey.
+		Credential string
+		// File is true if the flag value may be read from a file with
+		// "@file" or from stdin with "@-", see ExpandArgs. It is set
+		// for the flags that accept JSON values such as request bodies.
+		File bool
+	}
+
+	// Shell is an interactive shell that reads command lines and executes
+	// them. Each command line consists of a command, a sub-command and the
+	// sub-command flags. When reading from a terminal the shell completes
+	// the commands, sub-commands, flags and flag values when the tab key is
+	// pressed and recalls the previous command lines with the up and down
+	// arrow keys.
+	//
+	// The built-in commands "help" and "exit" print the usage and exit the
+	// shell respectively.
+	Shell struct {
+		// Prompt is printed before reading each command line.
+		Prompt string
+		// Commands lists the commands used to complete the command
+		// lines.
+		Commands []*Command
+		// Exec executes the command given the command line arguments.
+		// The "@file" arguments are expanded before Exec is called.
+		Exec func(args []string) error
+		// Usage prints the shell usage, it is called by the "help"
+		// command.
+		Usage func()
+		// Err is the writer used to print errors, os.Stderr if nil.
+		Err io.Writer
+
+		history []string
+	}
+)
+
+var (
+	// errInterrupted is returned by readLine when the user presses
+	// Ctrl-C.
+	errInterrupted = errors.New("interrupted")
+
+	// builtins lists the shell built-in commands.
+	builtins = []string{"help", "exit"}
+)
+
+// Run reads and executes the command lines read from in until in is closed or
+// the "exit" command is executed. The prompt and the line being edited are
+// written to out. Run only supports line editing and completion if in is a
+// terminal, otherwise it reads the command lines without printing the prompt.
+func (s *Shell) Run(in io.Reader, out io.Writer) error {
+	if f, ok := in.(*os.File); ok && isTerminal(int(f.Fd())) {
+		return s.runTerminal(f, out)
+	}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if s.execute(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// Complete returns the completion candidates for the last word of the given
+// command line.
+func (s *Shell) Complete(line string) []string {
+	start := strings.LastIndexAny(line, " \t") + 1
+	partial := line[start:]
+	if strings.ContainsAny(partial, `'"\`) {
+		return nil
+	}
+	words, _, err := tokenize(line[:start])
+	if err != nil {
+		return nil
+	}
+	var res []string
+	for _, c := range s.candidates(words, partial) {
+		if strings.HasPrefix(c, partial) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// runTerminal reads the command lines from the terminal f in raw mode.
+func (s *Shell) runTerminal(f *os.File, out io.Writer) error {
+	r := bufio.NewReader(f)
+	for {
+		restore, err := makeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		line, err := s.readLine(r, out)
+		restore()
+		if err == errInterrupted {
+			continue
+		}
+		if err == io.EOF {
+			fmt.Fprintln(out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(line) != "" {
+			s.history = append(s.history, line)
+		}
+		if s.execute(line) {
+			return nil
+		}
+	}
+}
+
+// execute executes the given command line and returns true if the shell must
+// exit.
+func (s *Shell) execute(line string) bool {
+	args, err := SplitArgs(line)
+	if err != nil {
+		s.printErr(err)
+		return false
+	}
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "exit":
+		return true
+	case "help":
+		if s.Usage != nil {
+			s.Usage()
+		}
+		return false
+	}
+	if args, err = ExpandArgs(args, nil, s.Commands); err != nil {
+		s.printErr(err)
+		return false
+	}
+	if err := s.Exec(args); err != nil {
+		s.printErr(err)
+	}
+	return false
+}
+
+// readLine reads a command line from the terminal in raw mode.
+func (s *Shell) readLine(r *bufio.Reader, out io.Writer) (string, error) {
+	var (
+		line string
+		hist = len(s.history)
+	)
+	redraw := func() {
+		fmt.Fprint(out, "\r\x1b[K"+s.Prompt+line)
+	}
+	redraw()
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '\r', '\n':
+			fmt.Fprintln(out)
+			return line, nil
+		case 3: // Ctrl-C
+			fmt.Fprintln(out, "^C")
+			return "", errInterrupted
+		case 4: // Ctrl-D
+			if line == "" {
+				return "", io.EOF
+			}
+		case 21: // Ctrl-U
+			line = ""
+			redraw()
+		case 127, '\b':
+			if line != "" {
+				_, size := utf8.DecodeLastRuneInString(line)
+				line = line[:len(line)-size]
+				redraw()
+			}
+		case '\t':
+			line = s.completeLine(line, out)
+			redraw()
+		case 27: // Escape sequence
+			seq, err := readEscape(r)
+			if err != nil {
+				return "", err
+			}
+			switch seq {
+			case "[A":
+				if hist > 0 {
+					hist--
+					line = s.history[hist]
+				}
+			case "[B":
+				if hist < len(s.history) {
+					hist++
+					line = ""
+					if hist < len(s.history) {
+						line = s.history[hist]
+					}
+				}
+			}
+			redraw()
+		default:
+			if b >= 32 {
+				line += string(b)
+				out.Write([]byte{b})
+			}
+		}
+	}
+}
+
+// completeLine completes the last word of line and returns the resulting
+// line. It prints the candidates if there are more than one and they do not
+// share a longer common prefix.
+func (s *Shell) completeLine(line string, out io.Writer) string {
+	cands := s.Complete(line)
+	partial := line[strings.LastIndexAny(line, " \t")+1:]
+	switch len(cands) {
+	case 0:
+		fmt.Fprint(out, "\a")
+		return line
+	case 1:
+		suffix := " "
+		if strings.HasSuffix(cands[0], "=") {
+			suffix = ""
+		}
+		return line[:len(line)-len(partial)] + cands[0] + suffix
+	}
+	if prefix := commonPrefix(cands); len(prefix) > len(partial) {
+		return line[:len(line)-len(partial)] + prefix
+	}
+	fmt.Fprint(out, "\n"+strings.Join(cands, "  ")+"\n")
+	return line
+}
+
+// candidates returns the possible values for the word following the given
+// words.
+func (s *Shell) candidates(words []string, partial string) []string {
+	if len(words) == 0 {
+		names := append([]string{}, builtins...)
+		for _, c := range s.Commands {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+	cmd := findCommand(s.Commands, words[0])
+	if cmd == nil {
+		return nil
+	}
+	if len(words) == 1 {
+		names := make([]string, len(cmd.Subcommands))
+		for i, c := range cmd.Subcommands {
+			names[i] = c.Name
+		}
+		return names
+	}
+	sub := findCommand(cmd.Subcommands, words[1])
+	if sub == nil {
+		return nil
+	}
+	if idx := strings.Index(partial, "="); idx > 0 && strings.HasPrefix(partial, "-") {
+		f := findFlag(sub.Flags, partial[:idx])
+		if f == nil {
+			return nil
+		}
+		res := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			res[i] = partial[:idx+1] + v
+		}
+		return res
+	}
+	if len(words) > 2 {
+		if f := findFlag(sub.Flags, words[len(words)-1]); f != nil {
+			return f.Values
+		}
+	}
+	var res []string
+	for _, f := range sub.Flags {
+		used := false
+		for _, w := range words[2:] {
+			if findFlag([]*Flag{f}, strings.SplitN(w, "=", 2)[0]) != nil {
+				used = true
+				break
+			}
+		}
+		if !used {
+			res = append(res, "--"+f.Name)
+		}
+	}
+	return res
+}
+
+// printErr prints err to the shell error writer.
+func (s *Shell) printErr(err error) {
+	w := s.Err
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintln(w, err.Error())
+}
+
+// findCommand returns the command with the given name, nil if there is none.
+func findCommand(cmds []*Command, name string) *Command {
+	for _, c := range cmds {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// findFlag returns the flag matching the given argument, e.g. "-name" or
+// "--name", nil if there is none.
+func findFlag(flags []*Flag, arg string) *Flag {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return nil
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	for _, f := range flags {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// readEscape reads the rest of an escape sequence, for example "[A" for the
+// up arrow key.
+func readEscape(r *bufio.Reader) (string, error) {
+	b, err := r.ReadByte()
+	if err != nil || b != '[' {
+		return string(b), err
+	}
+	seq := []byte{b}
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		seq = append(seq, b)
+		if b >= 0x40 && b <= 0x7e {
+			return string(seq), nil
+		}
+	}
+}
+
+// commonPrefix returns the longest common prefix of the given strings.
+func commonPrefix(s []string) string {
+	prefix := s[0]
+	for _, v := range s[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testCommands = []*Command{
+	{
+		Name: "storage",
+		Subcommands: []*Command{
+			{Name: "list", Flags: []*Flag{{Name: "view", Values: []string{"default", "tiny"}}, {Name: "limit"}}},
+			{Name: "show", Flags: []*Flag{{Name: "id"}}},
+		},
+	},
+	{Name: "sommelier", Subcommands: []*Command{{Name: "pick"}}},
+}
+
+func TestShellComplete(t *testing.T) {
+	cases := map[string]struct {
+		Line     string
+		Expected []string
+	}{
+		"commands":         {"", []string{"help", "exit", "storage", "sommelier"}},
+		"command-prefix":   {"s", []string{"storage", "sommelier"}},
+		"command-unique":   {"st", []string{"storage"}},
+		"subcommands":      {"storage ", []string{"list", "show"}},
+		"subcommand":       {"storage sh", []string{"show"}},
+		"unknown-command":  {"foo ", nil},
+		"flags":            {"storage list ", []string{"--view", "--limit"}},
+		"flag-prefix":      {"storage list --v", []string{"--view"}},
+		"used-flag":        {"storage list --view tiny ", []string{"--limit"}},
+		"used-flag-equal":  {"storage list -view=tiny ", []string{"--limit"}},
+		"flag-values":      {"storage list --view ", []string{"default", "tiny"}},
+		"flag-value":       {"storage list -view t", []string{"tiny"}},
+		"flag-equal-value": {"storage list --view=d", []string{"--view=default"}},
+		"free-flag-value":  {"storage list --limit ", nil},
+		"quoted":           {"storage list --view 'd", nil},
+	}
+	s := &Shell{Commands: testCommands}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			if got := s.Complete(tc.Line); !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("got %q, expected %q", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestShellRun(t *testing.T) {
+	var (
+		execs    [][]string
+		usage    int
+		errs     bytes.Buffer
+		out      bytes.Buffer
+		expected = [][]string{{"storage", "show", "--id", "a b"}, {"storage", "list"}}
+	)
+	s := &Shell{
+		Commands: testCommands,
+		Exec: func(args []string) error {
+			execs = append(execs, args)
+			if args[1] == "list" {
+				return errors.New("list failed")
+			}
+			return nil
+		},
+		Usage: func() { usage++ },
+		Err:   &errs,
+	}
+	in := strings.NewReader("storage show --id 'a b'\n\nhelp\nstorage list\nstorage 'show\nexit\nstorage show\n")
+
+	if err := s.Run(in, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(execs, expected) {
+		t.Errorf("got commands %q, expected %q", execs, expected)
+	}
+	if usage != 1 {
+		t.Errorf("got %d usage calls, expected 1", usage)
+	}
+	if e := "list failed\nunterminated ' quote\n"; errs.String() != e {
+		t.Errorf("got errors %q, expected %q", errs.String(), e)
+	}
+	if out.Len() != 0 {
+		t.Errorf("got output %q, expected none", out.String())
+	}
+}
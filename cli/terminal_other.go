@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package cli
+
+import "errors"
+
+// isTerminal always returns false: line editing is only supported on Linux
+// and macOS.
+func isTerminal(fd int) bool {
+	return false
+}
+
+// makeRaw returns an error: line editing is only supported on Linux and
+// macOS.
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw mode is not supported on this platform")
+}
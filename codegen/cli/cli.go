@@ -74,6 +74,14 @@ type (
 		Required bool
 		// Example returns a JSON serialized example value.
 		Example string
+		// Values lists the values allowed for the flag if any, e.g.
+		// the values of an enum. They are used as completion candidates.
+		Values []string
+		// Credential is the kind of credential provided by the flag if
+		// any, "token" for JWT and OAuth2 tokens or "api-key" for API
+		// keys. The generated client uses the global -token and
+		// -api-key flags as the default values of these flags.
+		Credential string
 	}
 
 	// BuildFunctionData contains the data needed to generate a constructor
@@ -149,6 +157,10 @@ type (
 		// FieldPointer if true indicates that the field in the payload is a
 		// pointer.
 		FieldPointer bool
+		// Conv is the name of the type or function used to convert the
+		// argument into the payload field value if they have different Go
+		// types (e.g. the field holds a primitive user type).
+		Conv string
 	}
 )
 
@@ -294,6 +306,18 @@ func CommandUsage(data *CommandData) *codegen.SectionTemplate {
 	}
 }
 
+// CommandsSection builds the section template that generates the function
+// returning the commands, sub-commands and flags of the CLI tool. The
+// generated client interactive shell uses the commands to complete the
+// command lines.
+func CommandsSection(data []*CommandData) *codegen.SectionTemplate {
+	return &codegen.SectionTemplate{
+		Name:   "cli-commands",
+		Source: commandsT,
+		Data:   data,
+	}
+}
+
 // PayloadBuilderSection builds the section template that can be used to
 // generate the payload builder code.
 func PayloadBuilderSection(buildFunction *BuildFunctionData) *codegen.SectionTemplate {
@@ -328,6 +352,36 @@ func NewFlagData(svcn, en, name, typeName, description string, required bool, ex
 	}
 }
 
+// FlagValues returns the flag values corresponding to the given enum values.
+func FlagValues(enum []interface{}) []string {
+	if len(enum) == 0 {
+		return nil
+	}
+	vals := make([]string, len(enum))
+	for i, v := range enum {
+		vals[i] = fmt.Sprint(v)
+	}
+	return vals
+}
+
+// FlagCredential returns the kind of credential provided by the payload field
+// with the given name of method m if any, see FlagData.Credential.
+func FlagCredential(m *service.MethodData, field string) string {
+	if field == "" {
+		return ""
+	}
+	for _, c := range m.Requirements.Credentials() {
+		if c.Scheme.CredField != field {
+			continue
+		}
+		if c.Scheme.Type == "APIKey" {
+			return "api-key"
+		}
+		return "token"
+	}
+	return ""
+}
+
 // FieldLoadCode returns the code used in the build payload function that
 // initializes one of the payload object fields. It returns the initialization
 // code and a boolean indicating whether the code requires an "err" variable.
@@ -376,7 +430,9 @@ func flagType(tname string) string {
 	switch tname {
 	case boolN, intN, int32N, int64N, uintN, uint32N, uint64N, float32N, float64N, stringN:
 		return strings.ToUpper(tname)
-	case bytesN:
+	case durationN:
+		return "INT64"
+	case bytesN, timeN, decimalN:
 		return "STRING"
 	default: // Any, Array, Map, Object, User
 		return "JSON"
@@ -426,17 +482,20 @@ func jsonExample(v interface{}) string {
 }
 
 var (
-	boolN    = codegen.GoNativeTypeName(expr.Boolean)
-	intN     = codegen.GoNativeTypeName(expr.Int)
-	int32N   = codegen.GoNativeTypeName(expr.Int32)
-	int64N   = codegen.GoNativeTypeName(expr.Int64)
-	uintN    = codegen.GoNativeTypeName(expr.UInt)
-	uint32N  = codegen.GoNativeTypeName(expr.UInt32)
-	uint64N  = codegen.GoNativeTypeName(expr.UInt64)
-	float32N = codegen.GoNativeTypeName(expr.Float32)
-	float64N = codegen.GoNativeTypeName(expr.Float64)
-	stringN  = codegen.GoNativeTypeName(expr.String)
-	bytesN   = codegen.GoNativeTypeName(expr.Bytes)
+	boolN     = codegen.GoNativeTypeName(expr.Boolean)
+	intN      = codegen.GoNativeTypeName(expr.Int)
+	int32N    = codegen.GoNativeTypeName(expr.Int32)
+	int64N    = codegen.GoNativeTypeName(expr.Int64)
+	uintN     = codegen.GoNativeTypeName(expr.UInt)
+	uint32N   = codegen.GoNativeTypeName(expr.UInt32)
+	uint64N   = codegen.GoNativeTypeName(expr.UInt64)
+	float32N  = codegen.GoNativeTypeName(expr.Float32)
+	float64N  = codegen.GoNativeTypeName(expr.Float64)
+	stringN   = codegen.GoNativeTypeName(expr.String)
+	bytesN    = codegen.GoNativeTypeName(expr.Bytes)
+	timeN     = codegen.GoNativeTypeName(expr.Time)
+	durationN = codegen.GoNativeTypeName(expr.Duration)
+	decimalN  = codegen.GoNativeTypeName(expr.Decimal)
 )
 
 // conversionCode produces the code that converts the string stored in the
@@ -494,6 +553,16 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool) {
 		parse = fmt.Sprintf("%s %s= %s", target, decl, from)
 	case bytesN:
 		parse = fmt.Sprintf("%s %s= []byte(%s)", target, decl, from)
+	case timeN:
+		parse = fmt.Sprintf("%s, err %s= time.Parse(time.RFC3339, %s)", target, decl, from)
+		checkErr = true
+	case durationN:
+		parse = fmt.Sprintf("var v int64\nv, err = strconv.ParseInt(%s, 10, 64)", from)
+		cast = fmt.Sprintf("%s %s= time.Duration(v)", target, decl)
+		checkErr = true
+	case decimalN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseDecimal(%s)", target, decl, from)
+		checkErr = true
 	default:
 		parse = fmt.Sprintf("err = json.Unmarshal([]byte(%s), &%s)", from, target)
 		checkErr = true
@@ -564,7 +633,7 @@ const parseFlagsT = `var (
 		{{- range . }}
 		{{ .VarName }}Flags = flag.NewFlagSet("{{ .Name }}", flag.ContinueOnError)
 		{{ range .Subcommands }}
-		{{ .FullName }}Flags = flag.NewFlagSet("{{ .Name }}", flag.ExitOnError)
+		{{ .FullName }}Flags = flag.NewFlagSet("{{ .Name }}", flag.CommandLine.ErrorHandling())
 		{{- $sub := . }}
 		{{- range .Flags }}
 		{{ .FullName }}Flag = {{ $sub.FullName }}Flags.String("{{ .Name }}", "{{ if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
@@ -636,6 +705,36 @@ const parseFlagsT = `var (
 	}
 `
 
+// input: []commandData
+const commandsT = `// Commands returns the commands and sub-commands supported by the CLI tool
+// along with their flags.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+	{{- range . }}
+		{
+			Name:        {{ printf "%q" .Name }},
+			Description: {{ printf "%q" .Description }},
+			Subcommands: []*goacli.Command{
+		{{- range .Subcommands }}
+				{
+					Name:        {{ printf "%q" .Name }},
+					Description: {{ printf "%q" .Description }},
+			{{- if .Flags }}
+					Flags: []*goacli.Flag{
+				{{- range .Flags }}
+						{Name: {{ printf "%q" .Name }}{{ with .Description }}, Description: {{ printf "%q" . }}{{ end }}{{ with .Values }}, Values: []string{ {{- range $i, $v := . }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end }}}{{ end }}{{ with .Credential }}, Credential: {{ printf "%q" . }}{{ end }}{{ if eq .Type "JSON" }}, File: true{{ end }}},
+				{{- end }}
+					},
+			{{- end }}
+				},
+		{{- end }}
+			},
+		},
+	{{- end }}
+	}
+}
+`
+
 // input: commandData
 const commandUsageT = `{{ printf "%sUsage displays the usage of the %s command and its subcommands." .Name .Name | comment }}
 func {{ .VarName }}Usage() {
@@ -695,7 +794,7 @@ func {{ .Name }}({{ range .FormalParams }}{{ . }} string, {{ end }}) ({{ .Result
 			{{- if .ReturnIsStruct }}
 				{{- range .Args }}
 					{{- if .FieldName }}
-	{{ if $.PayloadInit.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}
+	{{ if $.PayloadInit.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }}
 				{{- end }}
 			{{- end }}
 		{{- end }}
@@ -706,7 +805,7 @@ func {{ .Name }}({{ range .FormalParams }}{{ . }} string, {{ end }}) ({{ .Result
 	payload := &{{ .ReturnTypeName }}{
 				{{- range .Args }}
 					{{- if .FieldName }}
-		{{ .FieldName }}: {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }},
+		{{ .FieldName }}: {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }},
 					{{- end }}
 				{{- end }}
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -30,15 +31,20 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	if _, err := os.Stat(path); !os.IsNotExist(err) {
 		return nil // file already exists, skip it.
 	}
+	// envPrefix is the prefix of the environment variables that provide the
+	// default flag values, e.g. "CELLAR" for the "cellar" API.
+	envPrefix := strings.ToUpper(codegen.SnakeCase(codegen.Goify(root.API.Name, true)))
+	token, apiKey := credentialKinds(svr)
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
-		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
 		{Path: "net/url"},
 		{Path: "os"},
+		{Path: "path/filepath"},
 		{Path: "strings"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("cli", "goacli"),
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
@@ -46,7 +52,10 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "cli-main-start",
 			Source: cliMainStartT,
 			Data: map[string]interface{}{
-				"Server": svrdata,
+				"Server":    svrdata,
+				"EnvPrefix": envPrefix,
+				"Token":     token,
+				"APIKey":    apiKey,
 			},
 			FuncMap: map[string]interface{}{
 				"join": strings.Join,
@@ -78,11 +87,15 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "cli-main-usage",
 			Source: cliMainUsageT,
 			Data: map[string]interface{}{
-				"APIName": root.API.Name,
-				"Server":  svrdata,
+				"APIName":   root.API.Name,
+				"Server":    svrdata,
+				"EnvPrefix": envPrefix,
+				"Token":     token,
+				"APIKey":    apiKey,
 			},
 			FuncMap: map[string]interface{}{
 				"toUpper": strings.ToUpper,
+				"lower":   strings.ToLower,
 				"join":    strings.Join,
 			},
 		},
@@ -90,8 +103,29 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	return &codegen.File{Path: path, SectionTemplates: sections, SkipExist: true}
 }
 
+// credentialKinds returns whether the methods of the services exposed by the
+// given server accept tokens and API keys as payload credentials.
+func credentialKinds(svr *expr.ServerExpr) (token, apiKey bool) {
+	for _, svc := range svr.Services {
+		sd := service.Services.Get(svc)
+		if sd == nil {
+			continue
+		}
+		for _, m := range sd.Methods {
+			for _, c := range m.Requirements.Credentials() {
+				if c.Scheme.Type == "APIKey" {
+					apiKey = true
+				} else {
+					token = true
+				}
+			}
+		}
+	}
+	return
+}
+
 const (
-	// input: map[string]interface{}{"Server": *Data}
+	// input: map[string]interface{}{"Server": *Data, "EnvPrefix": string, "Token": bool, "APIKey": bool}
 	cliMainStartT = `func main() {
 	var (
 		hostF = flag.String("host", {{ printf "%q" .Server.DefaultHost.Name }}, "Server host (valid values: {{ (join .Server.AvailableHosts ", ") }})")
@@ -102,9 +136,54 @@ const (
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF = flag.Bool("i", false, "Start an interactive shell")
+		configF = flag.String("config", "", "Path to the configuration file")
+		profileF = flag.String("profile", "", "Name of the configuration file profile")
+	{{- if .Token }}
+		tokenF = flag.String("token", "", "Token used by the endpoints secured with JWT or OAuth2")
+	{{- end }}
+	{{- if .APIKey }}
+		apiKeyF = flag.String("api-key", "", "Key used by the endpoints secured with API keys")
+	{{- end }}
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, {{ .Server.DefaultTransport.Type }}Commands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// {{ .EnvPrefix }}_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults({{ printf "%q" .EnvPrefix }}, *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	{{- if .Token }}
+		defaults.SetCredential(goacli.CredentialToken, *tokenF)
+	{{- end }}
+	{{- if .APIKey }}
+		defaults.SetCredential(goacli.CredentialAPIKey, *apiKeyF)
+	{{- end }}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 `
 
 	// input: map[string]interface{}{"Server": *Data}
@@ -164,22 +243,63 @@ const (
 `
 
 	// input: map[string]interface{}{"Server": *Data}
-	cliMainEndpointInitT = `var(
-		endpoint goa.Endpoint
-		payload interface{}
-		err error
-	)
-	{
+	cliMainEndpointInitT = `parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 	{{- range $t := .Server.Transports }}
 		case "{{ $t.Type }}", "{{ $t.Type }}s":
-			endpoint, payload, err = do{{ toUpper $t.Name }}(scheme, host, timeout, debug)
+			return do{{ toUpper $t.Name }}(scheme, host, timeout, debug)
 	{{- end }}
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: {{ join .Server.Schemes "|" }})\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: {{ join .Server.Schemes "|" }})", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := {{ .Server.DefaultTransport.Type }}Commands()
+		shell := &goacli.Shell{
+			Prompt:   {{ printf "%q" (printf "%s> " .Server.Dir) }},
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		return
 	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := {{ .Server.DefaultTransport.Type }}Commands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -197,37 +317,67 @@ const (
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 `
 
-	// input: map[string]interface{}{"APIName": string, "Server": *Data}
+	// input: map[string]interface{}{"APIName": string, "Server": *Data, "EnvPrefix": string, "Token": bool, "APIKey": bool}
 	cliMainUsageT = `
 func usage() {
   fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the {{ .APIName }} API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v]{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME]{{ if .Token }}[-token TOKEN]{{ end }}{{ if .APIKey }}[-api-key KEY]{{ end }}{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
 
     -host HOST:  server host ({{ .Server.DefaultHost.Name }}). valid values: {{ (join .Server.AvailableHosts ", ") }}
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file (${{ .EnvPrefix }}_CONFIG or {{ lower .EnvPrefix }}/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile (${{ .EnvPrefix }}_PROFILE or default)
+	{{- if .Token }}
+    -token TOKEN:  token used by all the endpoints secured with JWT or OAuth2
+	{{- end }}
+	{{- if .APIKey }}
+    -api-key KEY:  key used by all the endpoints secured with API keys
+	{{- end }}
 	{{- range .Server.Variables }}
     -{{ .Name }}:    {{ .Description }} ({{ .DefaultValue }})
 	{{- end }}
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. {{ .EnvPrefix }}_URL for -url. Endpoint flags default to
+the value of {{ .EnvPrefix }}_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to {{ .EnvPrefix }}_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageCommands()), os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageCommands()), os.Args[0], os.Args[0], indent({{ .Server.DefaultTransport.Type }}UsageExamples()))
 }
 
 func indent(s string) string {
@@ -20,6 +20,7 @@ func TestExampleCLIFiles(t *testing.T) {
 		{"single-server-single-host-with-variables", testdata.SingleServerSingleHostWithVariablesDSL, testdata.SingleServerSingleHostWithVariablesCLIMainCode},
 		{"single-server-multiple-hosts", testdata.SingleServerMultipleHostsDSL, testdata.SingleServerMultipleHostsCLIMainCode},
 		{"single-server-multiple-hosts-with-variables", testdata.SingleServerMultipleHostsWithVariablesDSL, testdata.SingleServerMultipleHostsWithVariablesCLIMainCode},
+		{"secured-server", testdata.SecuredServerDSL, testdata.SecuredServerCLIMainCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
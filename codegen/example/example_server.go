@@ -38,10 +38,9 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "log"},
 		{Path: "net/url"},
 		{Path: "os"},
-		{Path: "os/signal"},
 		{Path: "strings"},
-		{Path: "sync"},
 		{Path: "time"},
+		codegen.GoaImport("lifecycle"),
 		codegen.GoaImport("middleware"),
 	}
 
@@ -217,25 +216,15 @@ func main() {
 `
 
 	mainInterruptsT = `
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 `
 
 	// input: map[string]interface{"Server": *Data, "Services": []*service.Data}
 	mainServerHndlrT = `
-	{{ comment "Start the servers and send errors (if any) to the error channel." }}
+	{{ comment "Configure the servers and add them to the group." }}
 	switch *hostF {
 {{- range $h := .Server.Hosts }}
 	case {{ printf "%q" $h.Name }}:
@@ -278,7 +267,7 @@ func main() {
 			} else if u.Port() == "" {
 				u.Host += ":{{ $u.Port }}"
 			}
-			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $t := $.Server.Transports }}{{ if eq $t.Type $u.Transport.Type }}{{ range $s := $t.Services }}{{ range $.Services }}{{ if eq $s .Name }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}&wg, errc, logger, *dbgF)
+			handle{{ toUpper $u.Transport.Name }}Server(grp, u, {{ range $t := $.Server.Transports }}{{ if eq $t.Type $u.Transport.Type }}{{ range $s := $t.Services }}{{ range $.Services }}{{ if eq $s .Name }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}logger, *dbgF)
 		}
 	{{- end }}
 	{{ end }}
@@ -289,14 +278,9 @@ func main() {
 `
 
 	mainEndT = `
-	{{ comment "Wait for signal." }}
-	logger.Printf("exiting (%v)", <-errc)
-
-	{{ comment "Send cancellation signal to the goroutines." }}
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	{{ comment "Run the servers until the process receives a signal or one of the servers fails." }}
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 )
@@ -280,3 +280,36 @@ var ConflictWithAPINameAndServiceNamesIncludingMultipartDSL = func() {
 		})
 	})
 }
+
+var SecuredServerDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var APIKeyAuth = APIKeySecurity("api_key")
+	API("SecuredServer", func() {
+		Server("Secured", func() {
+			Services("SecuredService")
+			Host("dev", func() {
+				URI("http://example:8090")
+			})
+		})
+	})
+	Service("SecuredService", func() {
+		Method("Token", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/token")
+			})
+		})
+		Method("Key", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/key")
+			})
+		})
+	})
+}
@@ -6,12 +6,45 @@ const (
 		hostF = flag.String("host", "localhost", "Server host (valid values: localhost)")
 		addrF = flag.String("url", "", "URL to service host")
 
-		verboseF = flag.Bool("verbose", false, "Print request and response details")
-		vF       = flag.Bool("v", false, "Print request and response details")
-		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// TEST_API_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("TEST_API", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	var (
 		addr    string
 		timeout int
@@ -45,22 +78,63 @@ const (
 		scheme = u.Scheme
 		host = u.Host
 	}
-	var (
-		endpoint goa.Endpoint
-		payload  interface{}
-		err      error
-	)
-	{
+	parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 		case "http", "https":
-			endpoint, payload, err = doHTTP(scheme, host, timeout, debug)
+			return doHTTP(scheme, host, timeout, debug)
 		case "grpc", "grpcs":
-			endpoint, payload, err = doGRPC(scheme, host, timeout, debug)
+			return doGRPC(scheme, host, timeout, debug)
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: grpc|http)\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: grpc|http)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "test_api> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
 	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -76,9 +150,9 @@ const (
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -86,21 +160,45 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the test api API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME] SERVICE ENDPOINT [flags]
 
     -host HOST:  server host (localhost). valid values: localhost
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($TEST_API_CONFIG or test_api/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($TEST_API_PROFILE or default)
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. TEST_API_URL for -url. Endpoint flags default to
+the value of TEST_API_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to TEST_API_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -116,12 +214,45 @@ func indent(s string) string {
 		hostF = flag.String("host", "dev", "Server host (valid values: dev)")
 		addrF = flag.String("url", "", "URL to service host")
 
-		verboseF = flag.Bool("verbose", false, "Print request and response details")
-		vF       = flag.Bool("v", false, "Print request and response details")
-		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// SINGLE_SERVER_SINGLE_HOST_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("SINGLE_SERVER_SINGLE_HOST", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	var (
 		addr    string
 		timeout int
@@ -155,22 +286,63 @@ func indent(s string) string {
 		scheme = u.Scheme
 		host = u.Host
 	}
-	var (
-		endpoint goa.Endpoint
-		payload  interface{}
-		err      error
-	)
-	{
+	parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 		case "http", "https":
-			endpoint, payload, err = doHTTP(scheme, host, timeout, debug)
+			return doHTTP(scheme, host, timeout, debug)
 		case "grpc", "grpcs":
-			endpoint, payload, err = doGRPC(scheme, host, timeout, debug)
+			return doGRPC(scheme, host, timeout, debug)
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: grpc|http|https)\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: grpc|http|https)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "single_host> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		return
 	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -186,9 +358,9 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -196,21 +368,45 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerSingleHost API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME] SERVICE ENDPOINT [flags]
 
     -host HOST:  server host (dev). valid values: dev
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($SINGLE_SERVER_SINGLE_HOST_CONFIG or single_server_single_host/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($SINGLE_SERVER_SINGLE_HOST_PROFILE or default)
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. SINGLE_SERVER_SINGLE_HOST_URL for -url. Endpoint flags default to
+the value of SINGLE_SERVER_SINGLE_HOST_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to SINGLE_SERVER_SINGLE_HOST_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -226,21 +422,54 @@ func indent(s string) string {
 		hostF = flag.String("host", "dev", "Server host (valid values: dev)")
 		addrF = flag.String("url", "", "URL to service host")
 
-		int_F     = flag.String("int", "1", "")
-		uint_F    = flag.String("uint", "1", "")
-		float32_F = flag.String("float32", "1.1", "")
-		int32_F   = flag.String("int32", "1", "")
-		int64_F   = flag.String("int64", "1", "")
-		uint32_F  = flag.String("uint32", "1", "")
-		uint64_F  = flag.String("uint64", "1", "")
-		float64_F = flag.String("float64", "1", "")
-		bool_F    = flag.String("bool", "true", "")
-		verboseF  = flag.Bool("verbose", false, "Print request and response details")
-		vF        = flag.Bool("v", false, "Print request and response details")
-		timeoutF  = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		int_F        = flag.String("int", "1", "")
+		uint_F       = flag.String("uint", "1", "")
+		float32_F    = flag.String("float32", "1.1", "")
+		int32_F      = flag.String("int32", "1", "")
+		int64_F      = flag.String("int64", "1", "")
+		uint32_F     = flag.String("uint32", "1", "")
+		uint64_F     = flag.String("uint64", "1", "")
+		float64_F    = flag.String("float64", "1", "")
+		bool_F       = flag.String("bool", "true", "")
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	var (
 		addr    string
 		timeout int
@@ -283,20 +512,61 @@ func indent(s string) string {
 		scheme = u.Scheme
 		host = u.Host
 	}
-	var (
-		endpoint goa.Endpoint
-		payload  interface{}
-		err      error
-	)
-	{
+	parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 		case "http", "https":
-			endpoint, payload, err = doHTTP(scheme, host, timeout, debug)
+			return doHTTP(scheme, host, timeout, debug)
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: http|https)\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: http|https)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "single_host> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		return
 	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -312,9 +582,9 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -322,12 +592,18 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerSingleHostWithVariables API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-int INT][-uint UINT][-float32 FLOAT32][-int32 INT32][-int64 INT64][-uint32 UINT32][-uint64 UINT64][-float64 FLOAT64][-bool BOOL] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME][-int INT][-uint UINT][-float32 FLOAT32][-int32 INT32][-int64 INT64][-uint32 UINT32][-uint64 UINT64][-float64 FLOAT64][-bool BOOL] SERVICE ENDPOINT [flags]
 
     -host HOST:  server host (dev). valid values: dev
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_CONFIG or single_server_single_host_with_variables/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_PROFILE or default)
     -int:     (1)
     -uint:     (1)
     -float32:     (1.1)
@@ -340,12 +616,30 @@ Usage:
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_URL for -url. Endpoint flags default to
+the value of SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to SINGLE_SERVER_SINGLE_HOST_WITH_VARIABLES_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -361,12 +655,45 @@ func indent(s string) string {
 		hostF = flag.String("host", "dev", "Server host (valid values: dev, stage)")
 		addrF = flag.String("url", "", "URL to service host")
 
-		verboseF = flag.Bool("verbose", false, "Print request and response details")
-		vF       = flag.Bool("v", false, "Print request and response details")
-		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// SINGLE_SERVER_MULTIPLE_HOSTS_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("SINGLE_SERVER_MULTIPLE_HOSTS", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	var (
 		addr    string
 		timeout int
@@ -402,20 +729,61 @@ func indent(s string) string {
 		scheme = u.Scheme
 		host = u.Host
 	}
-	var (
-		endpoint goa.Endpoint
-		payload  interface{}
-		err      error
-	)
-	{
+	parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 		case "http", "https":
-			endpoint, payload, err = doHTTP(scheme, host, timeout, debug)
+			return doHTTP(scheme, host, timeout, debug)
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: http|https)\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: http|https)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "multiple_hosts> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		return
 	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -431,9 +799,9 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -441,21 +809,45 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerMultipleHosts API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME] SERVICE ENDPOINT [flags]
 
     -host HOST:  server host (dev). valid values: dev, stage
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($SINGLE_SERVER_MULTIPLE_HOSTS_CONFIG or single_server_multiple_hosts/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($SINGLE_SERVER_MULTIPLE_HOSTS_PROFILE or default)
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. SINGLE_SERVER_MULTIPLE_HOSTS_URL for -url. Endpoint flags default to
+the value of SINGLE_SERVER_MULTIPLE_HOSTS_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to SINGLE_SERVER_MULTIPLE_HOSTS_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
@@ -471,15 +863,48 @@ func indent(s string) string {
 		hostF = flag.String("host", "dev", "Server host (valid values: dev, stage)")
 		addrF = flag.String("url", "", "URL to service host")
 
-		versionF = flag.String("version", "v1", "Version")
-		domainF  = flag.String("domain", "test", "Domain")
-		portF    = flag.String("port", "8080", "Port")
-		verboseF = flag.Bool("verbose", false, "Print request and response details")
-		vF       = flag.Bool("v", false, "Print request and response details")
-		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		versionF     = flag.String("version", "v1", "Version")
+		domainF      = flag.String("domain", "test", "Domain")
+		portF        = flag.String("port", "8080", "Port")
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
 	)
 	flag.Usage = usage
 	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	var (
 		addr    string
 		timeout int
@@ -531,20 +956,61 @@ func indent(s string) string {
 		scheme = u.Scheme
 		host = u.Host
 	}
-	var (
-		endpoint goa.Endpoint
-		payload  interface{}
-		err      error
-	)
-	{
+	parse := func() (goa.Endpoint, interface{}, error) {
 		switch scheme {
 		case "http", "https":
-			endpoint, payload, err = doHTTP(scheme, host, timeout, debug)
+			return doHTTP(scheme, host, timeout, debug)
 		default:
-			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: http|https)\n", scheme)
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: http|https)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "multiple_hosts_with_variables> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
+		return
 	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
 	if err != nil {
 		if err == flag.ErrHelp {
 			os.Exit(0)
@@ -560,9 +1026,9 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	if data != nil {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -570,24 +1036,260 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SingleServerMultipleHostsWithVariables API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-version VERSION][-domain DOMAIN][-port PORT] SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME][-version VERSION][-domain DOMAIN][-port PORT] SERVICE ENDPOINT [flags]
 
     -host HOST:  server host (dev). valid values: dev, stage
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
     -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_CONFIG or single_server_multiple_hosts_with_variables/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_PROFILE or default)
     -version:    Version (v1)
     -domain:    Domain (test)
     -port:    Port (8080)
 
 Commands:
 %s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_URL for -url. Endpoint flags default to
+the value of SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to SINGLE_SERVER_MULTIPLE_HOSTS_WITH_VARIABLES_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
+Additional help:
+    %s SERVICE [ENDPOINT] --help
+
+Shell completion:
+    %s completion (bash|zsh|fish)
+
+Example:
+%s
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
+}
+
+func indent(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "    " + strings.Replace(s, "\n", "\n    ", -1)
+}
+`
+
+	SecuredServerCLIMainCode = `func main() {
+	var (
+		hostF = flag.String("host", "dev", "Server host (valid values: dev)")
+		addrF = flag.String("url", "", "URL to service host")
+
+		verboseF     = flag.Bool("verbose", false, "Print request and response details")
+		vF           = flag.Bool("v", false, "Print request and response details")
+		timeoutF     = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		formatF      = flag.String("format", goacli.FormatJSON, "Output format (valid values: json, yaml, table)")
+		interactiveF = flag.Bool("interactive", false, "Start an interactive shell")
+		iF           = flag.Bool("i", false, "Start an interactive shell")
+		configF      = flag.String("config", "", "Path to the configuration file")
+		profileF     = flag.String("profile", "", "Name of the configuration file profile")
+		tokenF       = flag.String("token", "", "Token used by the endpoints secured with JWT or OAuth2")
+		apiKeyF      = flag.String("api-key", "", "Key used by the endpoints secured with API keys")
+	)
+	flag.Usage = usage
+	flag.Parse()
+	if flag.Arg(0) == "completion" {
+		prog := filepath.Base(os.Args[0])
+		if err := goacli.WriteCompletion(os.Stdout, flag.Arg(1), prog, flag.CommandLine, httpCommands()); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags not given on the command line default to the values of the
+	// SECURED_SERVER_* environment variables and of the configuration file
+	// profile.
+	var defaults *goacli.Defaults
+	{
+		var err error
+		defaults, err = goacli.LoadDefaults("SECURED_SERVER", *configF, *profileF)
+		if err == nil {
+			err = defaults.SetFlags(flag.CommandLine)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		defaults.SetCredential(goacli.CredentialToken, *tokenF)
+		defaults.SetCredential(goacli.CredentialAPIKey, *apiKeyF)
+	}
+	if err := goacli.ValidateFormat(*formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	var (
+		addr    string
+		timeout int
+		debug   bool
+	)
+	{
+		addr = *addrF
+		if addr == "" {
+			switch *hostF {
+			case "dev":
+				addr = "http://example:8090"
+			default:
+				fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev)\n", *hostF)
+				os.Exit(1)
+			}
+		}
+		timeout = *timeoutF
+		debug = *verboseF || *vF
+	}
+
+	var (
+		scheme string
+		host   string
+	)
+	{
+		u, err := url.Parse(addr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid URL %#v: %s\n", addr, err)
+			os.Exit(1)
+		}
+		scheme = u.Scheme
+		host = u.Host
+	}
+	parse := func() (goa.Endpoint, interface{}, error) {
+		switch scheme {
+		case "http", "https":
+			return doHTTP(scheme, host, timeout, debug)
+		default:
+			return nil, nil, fmt.Errorf("invalid scheme: %q (valid schemes: http)", scheme)
+		}
+	}
+
+	if *interactiveF || *iF {
+		// Parse errors must not exit the shell.
+		flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+		commands := httpCommands()
+		shell := &goacli.Shell{
+			Prompt:   "secured> ",
+			Commands: commands,
+			Usage:    usage,
+			Exec: func(args []string) error {
+				os.Args = append(os.Args[:1], defaults.Args(args, commands)...)
+				endpoint, payload, err := parse()
+				if err != nil {
+					if err == flag.ErrHelp {
+						return nil
+					}
+					return err
+				}
+				data, err := endpoint(context.Background(), payload)
+				if err != nil {
+					return err
+				}
+				return goacli.Print(os.Stdout, data, *formatF)
+			},
+		}
+		if err := shell.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	{
+		// Read the values of the endpoint flags that accept files from
+		// the "@file" files and append the default values of the
+		// endpoint flags to the command line arguments that follow the
+		// global flags.
+		commands := httpCommands()
+		args, err := goacli.ExpandArgs(flag.Args(), os.Stdin, commands)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		n := len(os.Args) - flag.NArg()
+		os.Args = append(os.Args[:n:n], defaults.Args(args, commands)...)
+	}
+	endpoint, payload, err := parse()
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+		fmt.Fprintln(os.Stderr, "run '"+os.Args[0]+" --help' for detailed usage.")
+		os.Exit(1)
+	}
+
+	data, err := endpoint(context.Background(), payload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	if err := goacli.Print(os.Stdout, data, *formatF); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the SecuredServer API.
+
+Usage:
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v][-format FORMAT][-interactive|-i][-config FILE][-profile NAME][-token TOKEN][-api-key KEY] SERVICE ENDPOINT [flags]
+
+    -host HOST:  server host (dev). valid values: dev
+    -url URL:    specify service URL overriding host URL (http://localhost:8080)
+    -timeout:    maximum number of seconds to wait for response (30)
+    -verbose|-v: print request and response details (false)
+    -format:     output format, one of json, yaml or table (json)
+    -interactive|-i: start an interactive shell that completes the commands
+                 and flags when the tab key is pressed (false)
+    -config FILE:  configuration file ($SECURED_SERVER_CONFIG or secured_server/config.yaml in
+                 the user configuration directory)
+    -profile NAME: configuration file profile ($SECURED_SERVER_PROFILE or default)
+    -token TOKEN:  token used by all the endpoints secured with JWT or OAuth2
+    -api-key KEY:  key used by all the endpoints secured with API keys
+
+Commands:
+%s
+The values of the endpoint flags that accept JSON, e.g. -body, of the form
+@FILE are read from FILE, @- reads the value from stdin.
+
+Flags that are not given default to the value of the environment variable
+named after the flag, e.g. SECURED_SERVER_URL for -url. Endpoint flags default to
+the value of SECURED_SERVER_SERVICE_ENDPOINT_FLAG, the endpoint flags that provide
+credentials, e.g. -token, also default to SECURED_SERVER_FLAG. Flags default to the
+values of the configuration file profile otherwise:
+
+    default:
+      url: http://localhost:8080
+      SERVICE:
+        ENDPOINT:
+          FLAG: VALUE
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
+Shell completion:
+    %s completion (bash|zsh|fish)
+
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], indent(httpUsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], indent(httpUsageCommands()), os.Args[0], os.Args[0], indent(httpUsageExamples()))
 }
 
 func indent(s string) string {
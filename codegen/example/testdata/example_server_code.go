@@ -39,22 +39,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
+
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "localhost":
 		{
@@ -76,7 +66,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -98,21 +88,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: localhost)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -154,22 +140,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "localhost":
 		{
@@ -191,7 +167,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -213,21 +189,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: localhost)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -269,22 +241,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -306,7 +268,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -328,7 +290,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -350,21 +312,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -414,22 +372,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -460,7 +408,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -491,21 +439,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -529,22 +473,12 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingservicewithfileserver] ", log.Ltime)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "svc":
 		{
@@ -566,21 +500,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: svc)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -622,22 +552,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -659,7 +579,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -681,21 +601,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -741,22 +657,12 @@ const (
 		anotherServiceEndpoints = anotherservice.NewEndpoints(anotherServiceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -778,7 +684,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, anotherServiceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -800,21 +706,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, anotherServiceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -855,22 +757,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -892,7 +784,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	case "stage":
@@ -915,21 +807,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev|stage)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -973,22 +861,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "dev":
 		{
@@ -1024,7 +902,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	case "stage":
@@ -1049,21 +927,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":443"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev|stage)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 	NamesWithSpacesServerMainCode = `func main() {
@@ -1104,22 +978,12 @@ const (
 		serviceWithSpacesEndpoints = servicewithspaces.NewEndpoints(serviceWithSpacesSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "svc":
 		{
@@ -1141,7 +1005,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceWithSpacesEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -1163,21 +1027,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceWithSpacesEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceWithSpacesEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: svc)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -1218,22 +1078,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "localhost":
 		{
@@ -1255,21 +1105,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: localhost)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -1310,22 +1156,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "localhost":
 
@@ -1348,21 +1184,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: localhost)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 
@@ -1408,22 +1240,12 @@ const (
 		anotherServiceEndpoints = anotherservice.NewEndpoints(anotherServiceSvc)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Create the group that runs the servers. The group shuts the servers
+	// down gracefully when the process receives a SIGINT or SIGTERM signal.
+	// Change the shutdown timeout as required by your service.
+	grp := &lifecycle.Group{ShutdownTimeout: 30 * time.Second}
 
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Start the servers and send errors (if any) to the error channel.
+	// Configure the servers and add them to the group.
 	switch *hostF {
 	case "localhost":
 		{
@@ -1445,7 +1267,7 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":80"
 			}
-			handleHTTPServer(ctx, u, serviceEndpoints, anotherServiceEndpoints, &wg, errc, logger, *dbgF)
+			handleHTTPServer(grp, u, serviceEndpoints, anotherServiceEndpoints, logger, *dbgF)
 		}
 
 		{
@@ -1467,21 +1289,17 @@ const (
 			} else if u.Port() == "" {
 				u.Host += ":8080"
 			}
-			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+			handleGRPCServer(grp, u, serviceEndpoints, logger, *dbgF)
 		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: localhost)\n", *hostF)
 	}
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
-
-	// Send cancellation signal to the goroutines.
-	cancel()
-
-	wg.Wait()
-	logger.Println("exited")
+	// Run the servers until the process receives a signal or one of the servers
+	// fails.
+	err := grp.Run(context.Background())
+	logger.Printf("exited (%v)", err)
 }
 `
 )
@@ -79,8 +79,9 @@ func transformAttribute(source, target *expr.AttributeExpr, sourceVar, targetVar
 		if newVar {
 			assign = ":="
 		}
-		if _, ok := target.Type.(expr.UserType); ok {
-			// Primitive user type, these are used for error results
+		if needsCast(source, target) {
+			// Primitive user type, these are used for error results and
+			// enums
 			cast := ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg)
 			return fmt.Sprintf("%s %s %s(%s)\n", targetVar, assign, cast, sourceVar), nil
 		}
@@ -104,25 +105,58 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			}
 			var (
 				deref string
+				value string
 
 				srcPtr   = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
 				tgtPtr   = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
 				srcField = sourceVar + "." + GoifyAtt(srcc, srcMatt.ElemName(n), true)
 				tgtField = GoifyAtt(tgtc, tgtMatt.ElemName(n), true)
+				cast     = needsCast(srcc, tgtc)
 			)
+			if srcc.IsNullable() != tgtc.IsNullable() {
+				// only one of the attributes is nullable, convert from or to
+				// the goa Nullable type.
+				if srcc.IsNullable() {
+					value = srcField + ".Value"
+					if tgtPtr {
+						value = "&" + value
+					}
+					postInitCode += fmt.Sprintf("if %s.Valid() {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, value)
+					return
+				}
+				ctor := strings.Replace(GoNullableTypeName(tgtc.Type), "goa.", "goa.New", 1)
+				if srcPtr {
+					postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s(*%s)\n}\n", srcField, targetVar, tgtField, ctor, srcField)
+					return
+				}
+				initCode += fmt.Sprintf("\n%s: %s(%s),", tgtField, ctor, srcField)
+				return
+			}
 			{
 				switch {
 				case srcPtr && !tgtPtr:
-					if !srcMatt.IsRequired(n) {
-						postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, "*"+srcField)
-						return
-					}
 					deref = "*"
 				case !srcPtr && tgtPtr:
 					deref = "&"
 				}
+				value = deref + srcField
+				if cast {
+					tref := ta.TargetCtx.Scope.Ref(tgtc, ta.TargetCtx.Pkg)
+					switch {
+					case srcPtr && tgtPtr:
+						value = fmt.Sprintf("(*%s)(%s)", tref, srcField)
+					case tgtPtr:
+						value = fmt.Sprintf("(*%s)(&%s)", tref, srcField)
+					default:
+						value = fmt.Sprintf("%s(%s)", tref, value)
+					}
+				}
+				if srcPtr && !tgtPtr && !srcMatt.IsRequired(n) {
+					postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, value)
+					return
+				}
 			}
-			initCode += fmt.Sprintf("\n%s: %s%s,", tgtField, deref, srcField)
+			initCode += fmt.Sprintf("\n%s: %s,", tgtField, value)
 		})
 		if initCode != "" {
 			initCode += "\n"
@@ -159,7 +193,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				code, err = transformArrayElem(expr.AsArray(srcc.Type), expr.AsArray(tgtc.Type), srcVar, tgtVar, false, ta)
 			case expr.IsMap(srcc.Type):
 				code, err = transformMapElem(expr.AsMap(srcc.Type), expr.AsMap(tgtc.Type), srcVar, tgtVar, false, ta)
-			case ok:
+			case ok && !expr.IsPrimitive(srcc.Type):
 				code = fmt.Sprintf("%s = %s(%s)\n", tgtVar, transformHelperName(srcc, tgtc, ta), srcVar)
 			case expr.IsObject(srcc.Type):
 				code, err = transformAttribute(srcc, tgtc, srcVar, tgtVar, false, ta)
@@ -193,7 +227,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			if (ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr) || !expr.IsPrimitive(srcc.Type)) && !srcMatt.IsRequired(n) {
 				code += fmt.Sprintf("if %s == nil {\n\t", srcVar)
 				if ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && expr.IsPrimitive(tgtc.Type) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtc.Type), tdef, tgtVar)
+					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", ta.TargetCtx.Scope.Ref(tgtc, ta.TargetCtx.Pkg), tdef, tgtVar)
 				} else {
 					code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
 				}
@@ -237,7 +271,7 @@ func transformArrayElem(source, target *expr.Array, sourceVar, targetVar string,
 	if err := IsCompatible(st, tt, sourceVar+"[0]", targetVar+"[0]"); err != nil {
 		return "", err
 	}
-	if _, ok := st.(expr.UserType); ok {
+	if _, ok := st.(expr.UserType); ok && !expr.IsPrimitive(st) {
 		data := map[string]interface{}{
 			"ElemTypeRef":    ta.TargetCtx.Scope.Ref(target.ElemType, ta.TargetCtx.Pkg),
 			"SourceElem":     source.ElemType,
@@ -296,7 +330,7 @@ func transformMapElem(source, target *expr.Map, sourceVar, targetVar string, new
 	if err := IsCompatible(source.ElemType.Type, target.ElemType.Type, sourceVar+"[*]", targetVar+"[*]"); err != nil {
 		return "", err
 	}
-	if _, ok := target.ElemType.Type.(expr.UserType); ok {
+	if _, ok := target.ElemType.Type.(expr.UserType); ok && !expr.IsPrimitive(target.ElemType.Type) {
 		data := map[string]interface{}{
 			"KeyTypeRef":     ta.TargetCtx.Scope.Ref(target.KeyType, ta.TargetCtx.Pkg),
 			"ElemTypeRef":    ta.TargetCtx.Scope.Ref(target.ElemType, ta.TargetCtx.Pkg),
@@ -371,7 +405,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	if _, ok := seen[name]; ok {
 		return
 	}
-	if _, ok := source.Type.(expr.UserType); ok {
+	if _, ok := source.Type.(expr.UserType); ok && !expr.IsPrimitive(source.Type) {
 		var h *TransformFunctionData
 		if h, err = generateHelper(source, target, req, ta, seen); h != nil {
 			helpers = append(helpers, h)
@@ -427,6 +461,15 @@ func generateHelper(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	return tfd, nil
 }
 
+// needsCast returns true if the Go types of the given primitive attributes
+// differ, i.e. if at least one of them is a primitive user type and thus
+// defines a named Go type.
+func needsCast(source, target *expr.AttributeExpr) bool {
+	_, src := source.Type.(expr.UserType)
+	_, tgt := target.Type.(expr.UserType)
+	return src || tgt
+}
+
 // walkMatches iterates through the attributes of source and looks for
 // attributes with identical names in target. walkMatches calls the walker
 // function for each pair of matched attributes. Both source and target must be
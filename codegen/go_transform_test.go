@@ -41,6 +41,11 @@ func TestGoTransform(t *testing.T) {
 		resultType = root.UserType("ResultType")
 		rtCol      = root.UserType("ResultTypeCollection")
 
+		enumTypes  = root.UserType("EnumTypes")
+		enumValues = root.UserType("EnumValues")
+
+		nullable = root.UserType("Nullable")
+
 		// attribute contexts used in test cases
 		defaultCtx    = NewAttributeContext(false, false, true, "", scope)
 		defaultCtxPkg = NewAttributeContext(false, false, true, "mypkg", scope)
@@ -97,6 +102,10 @@ func TestGoTransform(t *testing.T) {
 			{"composite-to-custom-field-pkg", composite, customField, defaultCtx, defaultCtxPkg, srcTgtUseDefaultCompositeToCustomFieldPkgCode},
 			{"result-type-to-result-type", resultType, resultType, defaultCtx, defaultCtx, srcTgtUseDefaultResultTypeToResultTypeCode},
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, defaultCtx, defaultCtx, srcTgtUseDefaultRTColToRTColCode},
+			{"enum-types-to-enum-values", enumTypes, enumValues, defaultCtx, defaultCtx, srcTgtUseDefaultEnumTypesToEnumValuesCode},
+			{"enum-values-to-enum-types", enumValues, enumTypes, defaultCtx, defaultCtxPkg, srcTgtUseDefaultEnumValuesToEnumTypesPkgCode},
+			{"simple-to-nullable", simple, nullable, defaultCtx, defaultCtx, srcTgtUseDefaultSimpleToNullableCode},
+			{"nullable-to-simple", nullable, simple, defaultCtx, defaultCtx, srcTgtUseDefaultNullableToSimpleCode},
 		},
 
 		// source type uses pointers for all fields, target type uses default
@@ -122,6 +131,8 @@ func TestGoTransform(t *testing.T) {
 
 			// others
 			{"custom-field-to-composite", customField, composite, pointerCtx, defaultCtx, srcAllPtrsTgtUseDefaultCustomFieldToCompositeCode},
+			{"enum-values-to-enum-types", enumValues, enumTypes, pointerCtx, defaultCtx, srcAllPtrsTgtUseDefaultEnumValuesToEnumTypesCode},
+			{"simple-to-nullable", simple, nullable, pointerCtx, defaultCtx, srcAllPtrsTgtUseDefaultSimpleToNullableCode},
 		},
 
 		// source type uses default, target type uses pointers for all fields
@@ -655,6 +666,75 @@ const (
 		}
 	}
 }
+`
+
+	srcTgtUseDefaultEnumTypesToEnumValuesCode = `func transform() {
+	target := &EnumValues{
+		Color:         (*string)(source.Color),
+		RequiredColor: string(source.RequiredColor),
+	}
+	if source.Colors != nil {
+		target.Colors = make([]string, len(source.Colors))
+		for i, val := range source.Colors {
+			target.Colors[i] = string(val)
+		}
+	}
+	if source.ByColor != nil {
+		target.ByColor = make(map[string]int, len(source.ByColor))
+		for key, val := range source.ByColor {
+			tk := string(key)
+			tv := val
+			target.ByColor[tk] = tv
+		}
+	}
+}
+`
+
+	srcTgtUseDefaultEnumValuesToEnumTypesPkgCode = `func transform() {
+	target := &mypkg.EnumTypes{
+		Color:         (*mypkg.Color)(source.Color),
+		RequiredColor: mypkg.Color(source.RequiredColor),
+	}
+	if source.Colors != nil {
+		target.Colors = make([]mypkg.Color, len(source.Colors))
+		for i, val := range source.Colors {
+			target.Colors[i] = mypkg.Color(val)
+		}
+	}
+	if source.ByColor != nil {
+		target.ByColor = make(map[mypkg.Color]int, len(source.ByColor))
+		for key, val := range source.ByColor {
+			tk := mypkg.Color(key)
+			tv := val
+			target.ByColor[tk] = tv
+		}
+	}
+}
+`
+
+	srcTgtUseDefaultSimpleToNullableCode = `func transform() {
+	target := &Nullable{
+		RequiredString: goa.NewNullableString(source.RequiredString),
+		DefaultBool:    goa.NewNullableBool(source.DefaultBool),
+	}
+	if source.Integer != nil {
+		target.Integer = goa.NewNullableInt(*source.Integer)
+	}
+}
+`
+
+	srcTgtUseDefaultNullableToSimpleCode = `func transform() {
+	target := &Simple{}
+	if source.RequiredString.Valid() {
+		target.RequiredString = source.RequiredString.Value
+	}
+	if source.DefaultBool.Valid() {
+		target.DefaultBool = source.DefaultBool.Value
+	}
+	if source.Integer.Valid() {
+		target.Integer = &source.Integer.Value
+	}
+}
 `
 
 	srcAllPtrsTgtUseDefaultSimpleToSimpleCode = `func transform() {
@@ -855,6 +935,42 @@ const (
 		target.Array[i] = val
 	}
 }
+`
+
+	srcAllPtrsTgtUseDefaultEnumValuesToEnumTypesCode = `func transform() {
+	target := &EnumTypes{
+		Color:         (*Color)(source.Color),
+		RequiredColor: Color(*source.RequiredColor),
+	}
+	if source.Colors != nil {
+		target.Colors = make([]Color, len(source.Colors))
+		for i, val := range source.Colors {
+			target.Colors[i] = Color(val)
+		}
+	}
+	if source.ByColor != nil {
+		target.ByColor = make(map[Color]int, len(source.ByColor))
+		for key, val := range source.ByColor {
+			tk := Color(key)
+			tv := val
+			target.ByColor[tk] = tv
+		}
+	}
+}
+`
+
+	srcAllPtrsTgtUseDefaultSimpleToNullableCode = `func transform() {
+	target := &Nullable{}
+	if source.RequiredString != nil {
+		target.RequiredString = goa.NewNullableString(*source.RequiredString)
+	}
+	if source.DefaultBool != nil {
+		target.DefaultBool = goa.NewNullableBool(*source.DefaultBool)
+	}
+	if source.Integer != nil {
+		target.Integer = goa.NewNullableInt(*source.Integer)
+	}
+}
 `
 
 	srcUseDefaultTgtAllPtrsSimpleToSimpleCode = `func transform() {
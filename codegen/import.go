@@ -84,7 +84,24 @@ func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec)
 	return typeName, importS
 }
 
-// GetMetaTypeImports parses the attribute for all user defined imports
+// GetValidatorImports returns the imports of the packages that define the
+// user defined validation functions of the attribute if any.
+func GetValidatorImports(att *expr.AttributeExpr) []*ImportSpec {
+	if att == nil || att.Validation == nil {
+		return nil
+	}
+	var imports []*ImportSpec
+	for _, v := range att.Validation.Validators {
+		if v.ImportPath != "" {
+			imports = append(imports, &ImportSpec{Name: v.ImportName, Path: v.ImportPath})
+		}
+	}
+	return imports
+}
+
+// GetMetaTypeImports parses the attribute for all user defined imports and
+// the imports required by the Go types of the time, duration and decimal
+// primitives.
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
 	return safelyGetMetaTypeImports(att, nil)
 }
@@ -112,31 +129,26 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 			}
 		}
 	case *expr.Array:
-		_, im := GetMetaType(t.ElemType)
-		if im != nil {
+		for _, im := range attributeImports(t.ElemType) {
 			uniqueImports[*im] = struct{}{}
 		}
 	case *expr.Map:
-		_, im := GetMetaType(t.ElemType)
-		if im != nil {
+		for _, im := range attributeImports(t.ElemType) {
 			uniqueImports[*im] = struct{}{}
 		}
-		_, im = GetMetaType(t.KeyType)
-		if im != nil {
+		for _, im := range attributeImports(t.KeyType) {
 			uniqueImports[*im] = struct{}{}
 		}
 	case *expr.Object:
 		for _, key := range *t {
 			if key != nil {
-				_, im := GetMetaType(key.Attribute)
-				if im != nil {
+				for _, im := range attributeImports(key.Attribute) {
 					uniqueImports[*im] = struct{}{}
 				}
 			}
 		}
 	}
-	_, im := GetMetaType(att)
-	if im != nil {
+	for _, im := range attributeImports(att) {
 		uniqueImports[*im] = struct{}{}
 	}
 	for imp := range uniqueImports {
@@ -147,6 +159,24 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 	return imports
 }
 
+// attributeImports returns the imports required by the struct:field:type
+// metadata, the user defined validation functions and the Go type of the
+// primitive type of the attribute if any.
+func attributeImports(att *expr.AttributeExpr) []*ImportSpec {
+	var imports []*ImportSpec
+	if _, im := GetMetaType(att); im != nil {
+		imports = append(imports, im)
+	}
+	imports = append(imports, GetValidatorImports(att)...)
+	switch att.Type.Kind() {
+	case expr.TimeKind, expr.DurationKind:
+		imports = append(imports, SimpleImport("time"))
+	case expr.DecimalKind:
+		imports = append(imports, GoaImport(""))
+	}
+	return imports
+}
+
 // AddServiceMetaTypeImports adds meta type imports for each method of the service expr
 func AddServiceMetaTypeImports(header *SectionTemplate, svc *expr.ServiceExpr) {
 	for _, m := range svc.Methods {
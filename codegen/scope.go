@@ -101,6 +101,9 @@ func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) str
 		if t, _ := GetMetaType(att); t != "" {
 			return t
 		}
+		if att.IsNullable() {
+			return GoNullableTypeName(actual)
+		}
 		return GoNativeTypeName(actual)
 	case *expr.Array:
 		d := s.GoTypeDef(actual.ElemType, ptr, useDefault)
@@ -136,7 +139,7 @@ func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) str
 				tdef = s.GoTypeDef(at, ptr, useDefault)
 				if expr.IsObject(at.Type) ||
 					att.IsPrimitivePointer(name, useDefault) ||
-					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind) {
+					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind && !at.IsNullable()) {
 					tdef = "*" + tdef
 				}
 				if at.Description != "" {
@@ -192,6 +195,9 @@ func (s *NameScope) GoFullTypeName(att *expr.AttributeExpr, pkg string) string {
 		if t, _ := GetMetaType(att); t != "" {
 			return t
 		}
+		if att.IsNullable() {
+			return GoNullableTypeName(actual)
+		}
 		return GoNativeTypeName(actual)
 	case *expr.Array:
 		return "[]" + s.GoFullTypeRef(actual.ElemType, pkg)
@@ -3,7 +3,7 @@ package service
 // data: Data
 const dummyAuthFuncsT = `{{ range .Schemes }}
 {{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme." .Type $.Name .SchemeName | comment }}
-func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MTLS" }}cert *x509.Certificate{{ else }}token string{{ end }}, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
 	//
 	// TBD: add authorization logic.
 	//
@@ -21,4 +21,25 @@ func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Typ
 	return ctx, fmt.Errorf("not implemented")
 }
 {{- end }}
+{{- if .Authorized }}
+
+{{ printf "Authorize implements the authorization logic for the methods of service %q that define authorization requirements." $.Name | comment }}
+func (s *{{ $.VarName }}srvc) Authorize(ctx context.Context, attrs map[string]interface{}, policy *security.AuthorizationPolicy) (context.Context, error) {
+	//
+	// TBD: add authorization logic.
+	//
+	// The roles and scopes of the caller are typically stored in the
+	// context by the authentication functions above and may be checked
+	// with policy.Validate. attrs contains the values of the payload
+	// attributes listed in the policy requirements, e.g. to check that
+	// the caller owns the corresponding resource.
+	//
+	// In case of authorization failure this function should return
+	// one of the generated error structs, e.g.:
+	//
+	//    return ctx, myservice.MakeForbiddenError("access denied")
+	//
+	return ctx, fmt.Errorf("not implemented")
+}
+{{- end }}
 `
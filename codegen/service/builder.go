@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// BuilderData contains the data needed to render the constructor and
+	// the setter methods of a user type.
+	BuilderData struct {
+		// Name is the name of the type as defined in the design.
+		Name string
+		// VarName is the Go type name.
+		VarName string
+		// Args lists the constructor arguments, one per required
+		// attribute.
+		Args []*BuilderFieldData
+		// Defaults lists the fields initialized with their default value
+		// by the constructor.
+		Defaults []*BuilderFieldData
+		// Setters lists the fields that have a setter method, one per
+		// attribute that is not required.
+		Setters []*BuilderFieldData
+	}
+
+	// BuilderFieldData contains the data needed to render a constructor
+	// argument, a field initialization or a setter method.
+	BuilderFieldData struct {
+		// Name is the name of the attribute as defined in the design.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// VarName is the name of the constructor argument or setter
+		// parameter.
+		VarName string
+		// TypeRef is the reference to the field value type.
+		TypeRef string
+		// Pointer is true if the struct field is a pointer to the value.
+		Pointer bool
+		// DefaultValue is the Go literal of the field default value if
+		// any.
+		DefaultValue string
+	}
+)
+
+// generateBuilder returns true if the constructor and setter methods must be
+// generated for the given user type, that is if the type is an object that
+// defines the "type:generate:builder" meta.
+func generateBuilder(ut expr.UserType) bool {
+	if _, ok := ut.Attribute().Meta["type:generate:builder"]; !ok {
+		return false
+	}
+	return expr.AsObject(ut) != nil
+}
+
+// buildBuilderData returns the data needed to render the constructor and the
+// setter methods of the given object user type.
+func buildBuilderData(ut expr.UserType, scope *codegen.NameScope) *BuilderData {
+	var (
+		att  = &expr.AttributeExpr{Type: ut}
+		obj  = expr.AsObject(ut)
+		ctx  = typeContext("", scope)
+		data = &BuilderData{Name: ut.Name(), VarName: scope.GoTypeName(att)}
+	)
+	for _, nat := range *obj {
+		var (
+			fatt = nat.Attribute
+			vn   = codegen.Goify(nat.Name, false)
+		)
+		if vn == "v" {
+			vn = "val"
+		}
+		f := &BuilderFieldData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(fatt, nat.Name, true),
+			VarName:   vn,
+			TypeRef:   scope.GoTypeRef(fatt),
+			Pointer:   expr.IsPrimitive(fatt.Type) && !fatt.IsNullable() && ctx.IsPrimitivePointer(nat.Name, ut.Attribute()),
+		}
+		if ut.Attribute().IsRequired(nat.Name) {
+			data.Args = append(data.Args, f)
+			continue
+		}
+		if def := fatt.DefaultValue; def != nil {
+			f.DefaultValue = defaultLiteral(fatt, def, scope)
+			if f.DefaultValue == "" {
+				panic(fmt.Sprintf("cannot render default value %#v of attribute %q of type %q", def, nat.Name, ut.Name())) // bug
+			}
+			data.Defaults = append(data.Defaults, f)
+		}
+		data.Setters = append(data.Setters, f)
+	}
+	return data
+}
+
+// defaultLiteral returns the Go literal for the default value def of the given
+// attribute. It returns an empty string if the value cannot be expressed as a
+// literal, the design validation rejects such default values (time, duration
+// and decimal values as well as objects) so this should never happen.
+func defaultLiteral(att *expr.AttributeExpr, def interface{}, scope *codegen.NameScope) string {
+	switch actual := att.Type.(type) {
+	case *expr.Array:
+		v := reflect.ValueOf(def)
+		if v.Kind() != reflect.Slice {
+			return ""
+		}
+		elems := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if elems[i] = defaultLiteral(actual.ElemType, v.Index(i).Interface(), scope); elems[i] == "" {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%s{%s}", scope.GoTypeRef(att), strings.Join(elems, ", "))
+	case *expr.Map:
+		v := reflect.ValueOf(def)
+		if v.Kind() != reflect.Map {
+			return ""
+		}
+		elems := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := defaultLiteral(actual.KeyType, iter.Key().Interface(), scope)
+			val := defaultLiteral(actual.ElemType, iter.Value().Interface(), scope)
+			if key == "" || val == "" {
+				return ""
+			}
+			elems = append(elems, key+": "+val)
+		}
+		sort.Strings(elems)
+		return fmt.Sprintf("%s{%s}", scope.GoTypeRef(att), strings.Join(elems, ", "))
+	case expr.UserType:
+		if expr.IsPrimitive(actual) {
+			return defaultLiteral(actual.Attribute(), def, scope)
+		}
+		return ""
+	}
+	switch att.Type.Kind() {
+	case expr.BooleanKind, expr.IntKind, expr.Int32Kind, expr.Int64Kind,
+		expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind,
+		expr.Float64Kind, expr.StringKind, expr.AnyKind:
+		return fmt.Sprintf("%#v", def)
+	case expr.BytesKind:
+		switch v := def.(type) {
+		case string:
+			return fmt.Sprintf("[]byte(%q)", v)
+		case []byte:
+			return fmt.Sprintf("[]byte(%q)", string(v))
+		}
+	}
+	return ""
+}
+
+// input: BuilderData
+const builderT = `{{ printf "New%s creates a new %s from the values of its required attributes and initializes its other attributes with their default value if any." .VarName .Name | comment }}
+func New{{ .VarName }}({{ range $i, $a := .Args }}{{ if $i }}, {{ end }}{{ $a.VarName }} {{ $a.TypeRef }}{{ end }}) *{{ .VarName }} {
+	v := &{{ .VarName }}{
+	{{- range .Args }}
+		{{ .FieldName }}: {{ if .Pointer }}&{{ end }}{{ .VarName }},
+	{{- end }}
+	{{- range .Defaults }}
+		{{- if not .Pointer }}
+		{{ .FieldName }}: {{ .DefaultValue }},
+		{{- end }}
+	{{- end }}
+	}
+	{{- range .Defaults }}
+		{{- if .Pointer }}
+	{
+		var {{ .VarName }} {{ .TypeRef }} = {{ .DefaultValue }}
+		v.{{ .FieldName }} = &{{ .VarName }}
+	}
+		{{- end }}
+	{{- end }}
+	return v
+}
+{{- range .Setters }}
+
+{{ printf "With%s sets the %s attribute and returns v." .FieldName .Name | comment }}
+func (v *{{ $.VarName }}) With{{ .FieldName }}({{ .VarName }} {{ .TypeRef }}) *{{ $.VarName }} {
+	v.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}{{ .VarName }}
+	return v
+}
+{{- end }}
+`
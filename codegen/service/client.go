@@ -43,12 +43,40 @@ func ClientFile(service *expr.ServiceExpr) *codegen.File {
 				Source: serviceClientMethodT,
 				Data:   m,
 			})
+			if m.Pagination != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-pages",
+					Source: serviceClientPagesT,
+					Data:   m,
+				})
+			}
 		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
+// ClientCredentialsSection returns the section template that generates the
+// function used by the transport clients to set the credentials of the given
+// method payload that are not set. payloadRef is the reference to the payload
+// type in the transport client package. ClientCredentialsSection returns nil
+// if the method payload does not define credentials.
+func ClientCredentialsSection(m *MethodData, payloadRef string) *codegen.SectionTemplate {
+	creds := m.Requirements.Credentials()
+	if len(creds) == 0 {
+		return nil
+	}
+	return &codegen.SectionTemplate{
+		Name:   "client-credentials",
+		Source: clientCredentialsT,
+		Data: map[string]interface{}{
+			"Method":      m,
+			"PayloadRef":  payloadRef,
+			"Credentials": creds,
+		},
+	}
+}
+
 // input: endpointsData
 const serviceClientT = `// {{ .ClientVarName }} is the {{ printf "%q" .Name }} service client.
 type {{ .ClientVarName }} struct {
@@ -98,3 +126,99 @@ func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .Payloa
 	{{- end }}
 }
 `
+
+// input: endpointMethodData
+const serviceClientPagesT = `
+{{ printf "%s returns an iterator over the pages of results of the %q endpoint of the %q service starting with the page identified by p." .Pagination.PagesName .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.PagesName }}(p {{ .PayloadRef }}) *{{ .Pagination.IteratorName }} {
+	if p == nil {
+		p = &{{ .Payload }}{}
+	}
+	return &{{ .Pagination.IteratorName }}{client: c, payload: p}
+}
+
+{{ printf "%s iterates over the pages of results of the %q endpoint of the %q service." .Pagination.IteratorName .Name .ServiceName | comment }}
+type {{ .Pagination.IteratorName }} struct {
+	client  *{{ .ClientVarName }}
+	payload {{ .PayloadRef }}
+	page    {{ .ResultRef }}
+	err     error
+	done    bool
+}
+
+{{ printf "Next retrieves the next page of results. It returns false once all the pages have been retrieved or if the request failed, see Err." | comment }}
+func (it *{{ .Pagination.IteratorName }}) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	res, err := it.client.{{ .VarName }}(ctx, it.payload)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = res
+	if res.{{ .Pagination.NextField }} == nil{{ if .Pagination.Cursor }} || *res.{{ .Pagination.NextField }} == ""{{ end }} {
+		it.done = true
+		return true
+	}
+	p := *it.payload
+	p.{{ .Pagination.TokenField }} = res.{{ .Pagination.NextField }}
+	it.payload = &p
+	return true
+}
+
+// Page returns the page of results retrieved by the last call to Next.
+func (it *{{ .Pagination.IteratorName }}) Page() {{ .ResultRef }} {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *{{ .Pagination.IteratorName }}) Err() error {
+	return it.err
+}
+`
+
+// input: map[string]interface{}{"Method": *MethodData, "PayloadRef": string, "Credentials": []*CredentialData}
+const clientCredentialsT = `{{ printf "set%sCredentials returns a copy of the %q payload v where the credentials that are not set are obtained from src." .Method.VarName .Method.Name | comment }}
+func set{{ .Method.VarName }}Credentials(ctx context.Context, src security.CredentialSource, v interface{}) (interface{}, error) {
+	p, ok := v.({{ .PayloadRef }})
+	if !ok || p == nil {
+		return v, nil
+	}
+	res := *p
+{{- range .Credentials }}
+	if res.{{ .Scheme.CredField }} == {{ if .Scheme.CredPointer }}nil{{ else }}""{{ end }} {
+		cred, err := src.Credential(ctx, &security.{{ .Scheme.Type }}Scheme{
+			Name: {{ printf "%q" .Scheme.SchemeName }},
+			Scopes: []string{ {{- range .Scheme.Scopes }}{{ printf "%q" . }}, {{ end }} },
+			RequiredScopes: []string{ {{- range .RequiredScopes }}{{ printf "%q" . }}, {{ end }} },
+			{{- if .Scheme.Flows }}
+			Flows: []*security.OAuthFlow{
+				{{- range .Scheme.Flows }}
+				&security.OAuthFlow{
+					Type: "{{ .Type }}",
+					{{- if .AuthorizationURL }}
+					AuthorizationURL: {{ printf "%q" .AuthorizationURL }},
+					{{- end }}
+					{{- if .TokenURL }}
+					TokenURL: {{ printf "%q" .TokenURL }},
+					{{- end }}
+					{{- if .RefreshURL }}
+					RefreshURL: {{ printf "%q" .RefreshURL }},
+					{{- end }}
+				},
+				{{- end }}
+			},
+			{{- end }}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.{{ .Scheme.CredField }} = {{ if .Scheme.CredPointer }}&cred{{ else }}cred{{ end }}
+		}
+	}
+{{- end }}
+	return &res, nil
+}
+`
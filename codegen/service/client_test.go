@@ -29,6 +29,7 @@ func TestClient(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodClient},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL, testdata.BidirectionalStreamingMethodClient},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodClient},
+		{"paginated", testdata.PaginatedMethodDSL, testdata.PaginatedMethodClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -58,3 +59,48 @@ func TestClient(t *testing.T) {
 		})
 	}
 }
+
+// TestClientPages builds the client generated for a paginated method and
+// checks that the iterator stops on the last page.
+func TestClientPages(t *testing.T) {
+	codegen.RunDSL(t, testdata.PaginatedMethodDSL)
+	svc := expr.Root.Services[0]
+	runGeneratedTest(t, clientPagesTest, ClientFile(svc), File("example.com/generated/gen", svc))
+}
+
+// clientPagesTest iterates over pages whose last next cursor is empty.
+const clientPagesTest = `package paginatedservice
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListPages(t *testing.T) {
+	next := map[string]string{"": "a", "a": "b", "b": ""}
+	list := func(ctx context.Context, v interface{}) (interface{}, error) {
+		var cursor string
+		if p := v.(*ListPayload); p.Cursor != nil {
+			cursor = *p.Cursor
+		}
+		if _, ok := next[cursor]; !ok {
+			t.Fatalf("unexpected cursor %q", cursor)
+		}
+		c := next[cursor]
+		return &ListResult{Items: []string{cursor}, NextCursor: &c}, nil
+	}
+	it := NewClient(list).ListPages(nil)
+	var n int
+	for it.Next(context.Background()) {
+		if n++; n > len(next) {
+			t.Fatal("iterator did not stop on the empty cursor")
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(next) {
+		t.Errorf("got %d pages, expected %d", n, len(next))
+	}
+}
+`
@@ -9,9 +9,11 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
 )
 
 // convertData contains the info needed to render convert and create functions.
@@ -337,6 +339,12 @@ func uniquify(base string, taken map[string]struct{}) string {
 	return name
 }
 
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	decimalType  = reflect.TypeOf(goa.Decimal{})
+)
+
 type dtRec struct {
 	path string
 	seen map[string]expr.DataType
@@ -372,6 +380,18 @@ func buildDesignType(dt *expr.DataType, t reflect.Type, ref expr.DataType, recs
 		rec.seen = make(map[string]expr.DataType)
 	}
 
+	switch t {
+	case timeType:
+		*dt = expr.Time
+		return nil
+	case durationType:
+		*dt = expr.Duration
+		return nil
+	case decimalType:
+		*dt = expr.Decimal
+		return nil
+	}
+
 	switch t.Kind() {
 	case reflect.Bool:
 		*dt = expr.Boolean
@@ -567,6 +587,10 @@ func attributeName(obj *expr.Object, name string) (string, string) {
 
 // isPrimitive is true if the given kind matches a goa primitive type.
 func isPrimitive(t reflect.Type) bool {
+	switch t {
+	case timeType, durationType, decimalType:
+		return true
+	}
 	switch t.Kind() {
 	case reflect.Bool:
 		fallthrough
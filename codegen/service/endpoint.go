@@ -30,6 +30,9 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// Authorized is true if at least one endpoint defines
+		// authorization requirements.
+		Authorized bool
 	}
 
 	// endpointMethodData describes a single endpoint method.
@@ -138,6 +141,7 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 		ClientInitArgs: strings.Join(names, ", "),
 		Methods:        methods,
 		Schemes:        svc.Schemes,
+		Authorized:     svc.Authorized,
 	}
 }
 
@@ -160,13 +164,13 @@ type {{ .VarName }} struct {
 // input: endpointsData
 const serviceEndpointsInitT = `{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
 func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
-{{- if .Schemes }}
+{{- if or .Schemes .Authorized }}
 	// Casting service to Auther interface
 	a := s.(Auther)
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
-		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}{{ if .Authorizations }}, a.Authorize{{ end }}),
 {{- end }}
 	}
 }
@@ -186,7 +190,7 @@ type {{ .ServerStream.EndpointStruct }} struct {
 
 // input: endpointMethodData
 const serviceEndpointMethodT = `{{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
-func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
+func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}{{ if .Authorizations }}, authorizeFn security.AuthorizeFunc{{ end }}) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 {{- if .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
@@ -285,6 +289,14 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
 
+			{{- else if eq .Type "MTLS" }}
+				sc := security.MTLSScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+				}
+				ctx, err = auth{{ .Type }}Fn(ctx, security.PeerCertificate(ctx), &sc)
+
 			{{- end }}
 			{{- if ne $sidx 0 }}
 				}
@@ -298,6 +310,44 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 			return nil, err
 		}
 {{- end }}
+{{- if .Authorizations }}
+	{{- if not .Requirements }}
+		var err error
+	{{- end }}
+		policy := security.AuthorizationPolicy{
+			Service: {{ printf "%q" .ServiceName }},
+			Method: {{ printf "%q" .Name }},
+			Requirements: []*security.AuthorizationRequirement{
+			{{- range .Authorizations }}
+				&security.AuthorizationRequirement{
+				{{- if .Roles }}
+					Roles: []string{ {{- range .Roles }}{{ printf "%q" . }}, {{ end }} },
+				{{- end }}
+				{{- if .Scopes }}
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+				{{- end }}
+				{{- if .Attributes }}
+					Attributes: []string{ {{- range .Attributes }}{{ printf "%q" .Name }}, {{ end }} },
+				{{- end }}
+				},
+			{{- end }}
+			},
+		}
+		attrs := make(map[string]interface{})
+	{{- range .AuthorizationAttributes }}
+		{{- if .Pointer }}
+		if {{ $payload }}.{{ .FieldName }} != nil {
+			attrs[{{ printf "%q" .Name }}] = *{{ $payload }}.{{ .FieldName }}
+		}
+		{{- else }}
+		attrs[{{ printf "%q" .Name }}] = {{ $payload }}.{{ .FieldName }}
+		{{- end }}
+	{{- end }}
+		ctx, err = authorizeFn(ctx, attrs, &policy)
+		if err != nil {
+			return nil, err
+		}
+{{- end }}
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
 {{- else if .ViewedResult }}
@@ -306,6 +356,11 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 			return nil, err
 		}
 		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
+	{{- if and .FieldsField .ViewedResult.Select }}
+		if err := {{ .ViewedResult.ViewsPkg }}.{{ .ViewedResult.Select.Name }}(vres, {{ $payload }}.{{ .FieldsField }}); err != nil {
+			return nil, err
+		}
+	{{- end }}
 		return vres, nil
 {{- else if .ResultRef }}
 		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
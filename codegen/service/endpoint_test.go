@@ -23,6 +23,7 @@ func TestEndpoint(t *testing.T) {
 		{"no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadEndpoint},
 		{"with-result", testdata.WithResultEndpointDSL, testdata.WithResultEndpoint},
 		{"with-result-multiple-views", testdata.WithResultMultipleViewsEndpointDSL, testdata.WithResultMultipleViewsEndpoint},
+		{"with-result-sparse-fieldsets", testdata.WithResultSparseFieldsetsEndpointDSL, testdata.WithResultSparseFieldsetsEndpoint},
 		{"streaming-result", testdata.StreamingResultEndpointDSL, testdata.StreamingResultMethodEndpoint},
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadEndpointDSL, testdata.StreamingResultNoPayloadMethodEndpoint},
 		{"streaming-result-with-views", testdata.StreamingResultWithViewsMethodDSL, testdata.StreamingResultWithViewsMethodEndpoint},
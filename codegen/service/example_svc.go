@@ -67,6 +67,7 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 	}
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "crypto/x509"},
 		{Path: "log"},
 		{Path: "fmt"},
 		{Path: path.Join(genpkg, codegen.SnakeCase(svcName)), Name: data.PkgName},
@@ -77,7 +78,7 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 		{Name: "basic-service-struct", Source: svcStructT, Data: data},
 		{Name: "basic-service-init", Source: svcInitT, Data: data},
 	}
-	if len(data.Schemes) > 0 {
+	if len(data.Schemes) > 0 || data.Authorized {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "security-authfuncs",
 			Source: dummyAuthFuncsT,
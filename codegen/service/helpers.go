@@ -0,0 +1,431 @@
+package service
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// TypeHelpersData contains the data needed to render the Equal, DeepCopy
+	// and Diff methods of a user type.
+	TypeHelpersData struct {
+		// VarName is the Go type name.
+		VarName string
+		// Ref is the reference to the type used as method receiver.
+		Ref string
+		// IsStruct is true if the type is a struct.
+		IsStruct bool
+		// Fields lists the data needed to compare, copy and diff the
+		// struct fields if the type is a struct.
+		Fields []*FieldHelpersData
+		// Equal is the expression that compares v and other if the type is
+		// not a struct.
+		Equal string
+		// Copy is the expression that copies v if the type is not a struct.
+		Copy string
+	}
+
+	// FieldHelpersData contains the data needed to compare, copy and diff a
+	// struct field.
+	FieldHelpersData struct {
+		// Name is the name of the attribute as defined in the design.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Equal is the expression that is true if the field values of v and
+		// other are equal.
+		Equal string
+		// NotEqual is the negation of Equal.
+		NotEqual string
+		// Copy is the expression that copies the field value of v.
+		Copy string
+		// Nested is true if the field value is a user type that implements
+		// the Diff method.
+		Nested bool
+	}
+
+	// HelperFunctionData contains the data needed to render the functions
+	// that compare and copy the values of an array, a map or a pointer to a
+	// primitive.
+	HelperFunctionData struct {
+		// EqualName is the name of the function that compares two values.
+		EqualName string
+		// CopyName is the name of the function that copies a value.
+		CopyName string
+		// Ref is the reference to the type of the values.
+		Ref string
+		// IsMap is true if the values are maps.
+		IsMap bool
+		// IsPointer is true if the values are pointers to primitives.
+		IsPointer bool
+		// ElemEqual is the expression that compares the pointed values if
+		// IsPointer is true.
+		ElemEqual string
+		// ElemNotEqual is the expression that is true if the elements of
+		// the arrays or maps differ.
+		ElemNotEqual string
+		// ElemCopy is the expression that copies an element.
+		ElemCopy string
+	}
+
+	// helpersBuilder computes the data needed to render the helper methods
+	// of the user types defined in a package.
+	helpersBuilder struct {
+		// scope is the package name scope.
+		scope *codegen.NameScope
+		// ctx is the attribute context of the package types.
+		ctx *codegen.AttributeContext
+		// types lists the user types with helper methods.
+		types []*TypeHelpersData
+		// funcs lists the helper functions used by the methods.
+		funcs []*HelperFunctionData
+		// seen records the names of the types already processed.
+		seen map[string]struct{}
+		// helpers indexes the helper functions by name.
+		helpers map[string]*HelperFunctionData
+	}
+)
+
+// generateHelpers returns true if the Equal, DeepCopy and Diff methods must be
+// generated for the given user type. This is the case if the type defines the
+// "type:generate:helpers" meta or if it is a collection of such a result type.
+func generateHelpers(ut expr.UserType) bool {
+	if _, ok := ut.Attribute().Meta["type:generate:helpers"]; ok {
+		return true
+	}
+	if rt, ok := ut.(*expr.ResultTypeExpr); ok {
+		if arr := expr.AsArray(rt); arr != nil {
+			if elem, ok := arr.ElemType.Type.(expr.UserType); ok {
+				return generateHelpers(elem)
+			}
+		}
+	}
+	return false
+}
+
+// newHelpersBuilder returns a builder for the helpers of the types defined in
+// the package with the given scope and attribute context.
+func newHelpersBuilder(scope *codegen.NameScope, ctx *codegen.AttributeContext) *helpersBuilder {
+	return &helpersBuilder{
+		scope:   scope,
+		ctx:     ctx,
+		seen:    make(map[string]struct{}),
+		helpers: make(map[string]*HelperFunctionData),
+	}
+}
+
+// Add records the helper methods for the given user type and for all the
+// user types it references recursively.
+func (b *helpersBuilder) Add(ut expr.UserType) {
+	if ut == expr.Empty || expr.IsPrimitive(ut) {
+		return
+	}
+	att := &expr.AttributeExpr{Type: ut}
+	b.AddNamed(ut, b.scope.GoTypeName(att), b.scope.GoTypeRef(att), b.ctx)
+}
+
+// AddNamed records the helper methods for the given user type using the given
+// Go type name and reference and for all the user types it references
+// recursively. The fields of ut are compared and copied using ctx, the fields
+// of the referenced types using the builder context.
+func (b *helpersBuilder) AddNamed(ut expr.UserType, vname, ref string, ctx *codegen.AttributeContext) {
+	if _, ok := b.seen[vname]; ok {
+		return
+	}
+	b.seen[vname] = struct{}{}
+	data := &TypeHelpersData{VarName: vname, Ref: ref}
+	b.types = append(b.types, data)
+	if obj := expr.AsObject(ut); obj != nil {
+		data.IsStruct = true
+		for _, nat := range *obj {
+			data.Fields = append(data.Fields, b.field(ut.Attribute(), nat, ctx))
+		}
+	} else {
+		data.Equal, _ = b.equal(ut.Attribute(), "v", "other", false)
+		data.Copy = b.copy(ut.Attribute(), "v", false)
+	}
+	b.walk(ut.Attribute().Type)
+}
+
+// walk records the helper methods of the user types referenced by dt.
+func (b *helpersBuilder) walk(dt expr.DataType) {
+	switch actual := dt.(type) {
+	case expr.UserType:
+		b.Add(actual)
+	case *expr.Array:
+		b.walk(actual.ElemType.Type)
+	case *expr.Map:
+		b.walk(actual.KeyType.Type)
+		b.walk(actual.ElemType.Type)
+	case *expr.Object:
+		for _, nat := range *actual {
+			b.walk(nat.Attribute.Type)
+		}
+	}
+}
+
+// field returns the data needed to compare, copy and diff the given struct
+// field.
+func (b *helpersBuilder) field(parent *expr.AttributeExpr, nat *expr.NamedAttributeExpr, ctx *codegen.AttributeContext) *FieldHelpersData {
+	var (
+		att = nat.Attribute
+		fn  = codegen.GoifyAtt(att, nat.Name, true)
+		ptr = expr.IsPrimitive(att.Type) && ctx.IsPrimitivePointer(nat.Name, parent)
+
+		src = "v." + fn
+		tgt = "other." + fn
+	)
+	eq, neq := b.equal(att, src, tgt, ptr)
+	_, nested := att.Type.(expr.UserType)
+	return &FieldHelpersData{
+		Name:      nat.Name,
+		FieldName: fn,
+		Equal:     eq,
+		NotEqual:  neq,
+		Copy:      b.copy(att, src, ptr),
+		Nested:    nested && !expr.IsPrimitive(att.Type),
+	}
+}
+
+// equal returns the expression that is true if x and y hold equal values of
+// the given attribute type and its negation. ptr indicates whether x and y are
+// pointers to primitive values.
+func (b *helpersBuilder) equal(att *expr.AttributeExpr, x, y string, ptr bool) (string, string) {
+	call := func(format string, args ...interface{}) (string, string) {
+		c := fmt.Sprintf(format, args...)
+		return c, "!" + c
+	}
+	if t, _ := codegen.GetMetaType(att); t != "" {
+		return call("reflect.DeepEqual(%s, %s)", x, y)
+	}
+	if ptr {
+		return call("%s(%s, %s)", b.helper(att, true).EqualName, x, y)
+	}
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if !expr.IsPrimitive(actual) {
+			return call("%s.Equal(%s)", x, y)
+		}
+	case *expr.Array, *expr.Map:
+		return call("%s(%s, %s)", b.helper(att, false).EqualName, x, y)
+	case *expr.Object:
+		return call("reflect.DeepEqual(%s, %s)", x, y)
+	}
+	switch att.Type.Kind() {
+	case expr.BytesKind:
+		if att.IsNullable() {
+			return call("reflect.DeepEqual(%s, %s)", x, y)
+		}
+		return call("bytes.Equal(%s, %s)", x, y)
+	case expr.AnyKind:
+		return call("reflect.DeepEqual(%s, %s)", x, y)
+	case expr.TimeKind:
+		if att.IsNullable() {
+			eq := fmt.Sprintf("%s.Set == %s.Set && %s.Null == %s.Null && %s.Value.Equal(%s.Value)", x, y, x, y, x, y)
+			return eq, "!(" + eq + ")"
+		}
+		return call("%s.Equal(%s)", x, y)
+	}
+	return fmt.Sprintf("%s == %s", x, y), fmt.Sprintf("%s != %s", x, y)
+}
+
+// copy returns the expression that copies the value x of the given attribute
+// type. ptr indicates whether x is a pointer to a primitive value.
+func (b *helpersBuilder) copy(att *expr.AttributeExpr, x string, ptr bool) string {
+	if t, _ := codegen.GetMetaType(att); t != "" {
+		return x
+	}
+	if ptr {
+		return fmt.Sprintf("%s(%s)", b.helper(att, true).CopyName, x)
+	}
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if !expr.IsPrimitive(actual) {
+			return x + ".DeepCopy()"
+		}
+	case *expr.Array, *expr.Map:
+		return fmt.Sprintf("%s(%s)", b.helper(att, false).CopyName, x)
+	}
+	if att.Type.Kind() == expr.BytesKind {
+		if att.IsNullable() {
+			return fmt.Sprintf("%s{Value: append([]byte(nil), %s.Value...), Set: %s.Set, Null: %s.Null}",
+				codegen.GoNullableTypeName(att.Type), x, x, x)
+		}
+		return fmt.Sprintf("append([]byte(nil), %s...)", x)
+	}
+	return x
+}
+
+// helper returns the functions that compare and copy values of the given
+// array or map type or pointers to values of the given primitive type if ptr
+// is true. helper records the functions if not already recorded.
+func (b *helpersBuilder) helper(att *expr.AttributeExpr, ptr bool) *HelperFunctionData {
+	name := b.helperName(att)
+	ref := b.scope.GoTypeRef(att)
+	if ptr {
+		name += "Ptr"
+		ref = "*" + ref
+	}
+	if h, ok := b.helpers[name]; ok {
+		return h
+	}
+	h := &HelperFunctionData{
+		EqualName: "equal" + name,
+		CopyName:  "copy" + name,
+		Ref:       ref,
+		IsPointer: ptr,
+	}
+	b.helpers[name] = h
+	b.funcs = append(b.funcs, h)
+	switch {
+	case ptr:
+		h.ElemEqual = "*a == *b"
+		if att.Type.Kind() == expr.TimeKind {
+			h.ElemEqual = "a.Equal(*b)"
+		}
+		h.ElemCopy = "*a"
+	case expr.IsMap(att.Type):
+		m := expr.AsMap(att.Type)
+		h.IsMap = true
+		_, h.ElemNotEqual = b.equal(m.ElemType, "va", "vb", false)
+		h.ElemCopy = b.copy(m.ElemType, "e", false)
+	default:
+		a := expr.AsArray(att.Type)
+		_, h.ElemNotEqual = b.equal(a.ElemType, "a[i]", "b[i]", false)
+		h.ElemCopy = b.copy(a.ElemType, "e", false)
+	}
+	return h
+}
+
+// helperName returns the name used to build the names of the helper functions
+// for the given attribute type.
+func (b *helpersBuilder) helperName(att *expr.AttributeExpr) string {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		return b.scope.GoTypeName(att)
+	case *expr.Array:
+		return "ArrayOf" + b.helperName(actual.ElemType)
+	case *expr.Map:
+		return "MapOf" + b.helperName(actual.KeyType) + b.helperName(actual.ElemType)
+	case *expr.Object:
+		return "Object"
+	}
+	name := codegen.Goify(att.Type.Name(), true)
+	if att.IsNullable() {
+		name = "Nullable" + name
+	}
+	return name
+}
+
+// input: TypeHelpersData
+const typeHelpersT = `{{ printf "Equal returns true if v and other hold the same values." | comment }}
+func (v {{ .Ref }}) Equal(other {{ .Ref }}) bool {
+{{- if .IsStruct }}
+	if v == nil || other == nil {
+		return v == other
+	}
+	{{- if .Fields }}
+	return {{ range $i, $f := .Fields }}{{ if $i }} &&
+		{{ end }}{{ $f.Equal }}{{ end }}
+	{{- else }}
+	return true
+	{{- end }}
+{{- else }}
+	return {{ .Equal }}
+{{- end }}
+}
+
+{{ printf "DeepCopy returns a deep copy of v." | comment }}
+func (v {{ .Ref }}) DeepCopy() {{ .Ref }} {
+{{- if .IsStruct }}
+	if v == nil {
+		return nil
+	}
+	return &{{ .VarName }}{
+	{{- range .Fields }}
+		{{ .FieldName }}: {{ .Copy }},
+	{{- end }}
+	}
+{{- else }}
+	return {{ .Copy }}
+{{- end }}
+}
+
+{{ printf "Diff returns the list of fields whose values differ between v and other." | comment }}
+func (v {{ .Ref }}) Diff(other {{ .Ref }}) []goa.FieldChange {
+{{- if .IsStruct }}
+	if v == nil || other == nil {
+		if v == other {
+			return nil
+		}
+		return []goa.FieldChange{ {Old: v, New: other} }
+	}
+	var changes []goa.FieldChange
+	{{- range .Fields }}
+		{{- if .Nested }}
+	changes = append(changes, goa.NestFieldChanges({{ printf "%q" .Name }}, v.{{ .FieldName }}.Diff(other.{{ .FieldName }}))...)
+		{{- else }}
+	if {{ .NotEqual }} {
+		changes = append(changes, goa.FieldChange{Field: {{ printf "%q" .Name }}, Old: v.{{ .FieldName }}, New: other.{{ .FieldName }}})
+	}
+		{{- end }}
+	{{- end }}
+	return changes
+{{- else }}
+	if v.Equal(other) {
+		return nil
+	}
+	return []goa.FieldChange{ {Old: v, New: other} }
+{{- end }}
+}
+`
+
+// input: HelperFunctionData
+const helperFunctionsT = `{{ printf "%s returns true if a and b hold the same values." .EqualName | comment }}
+func {{ .EqualName }}(a, b {{ .Ref }}) bool {
+{{- if .IsPointer }}
+	if a == nil || b == nil {
+		return a == b
+	}
+	return {{ .ElemEqual }}
+{{- else }}
+	if len(a) != len(b) {
+		return false
+	}
+	{{- if .IsMap }}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || {{ .ElemNotEqual }} {
+			return false
+		}
+	}
+	{{- else }}
+	for i := range a {
+		if {{ .ElemNotEqual }} {
+			return false
+		}
+	}
+	{{- end }}
+	return true
+{{- end }}
+}
+
+{{ printf "%s returns a deep copy of a." .CopyName | comment }}
+func {{ .CopyName }}(a {{ .Ref }}) {{ .Ref }} {
+	if a == nil {
+		return nil
+	}
+{{- if .IsPointer }}
+	res := {{ .ElemCopy }}
+	return &res
+{{- else }}
+	res := make({{ .Ref }}, len(a))
+	for {{ if .IsMap }}k{{ else }}i{{ end }}, e := range a {
+		res[{{ if .IsMap }}k{{ else }}i{{ end }}] = {{ .ElemCopy }}
+	}
+	return res
+{{- end }}
+}
+`
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+// TestTypeHelpersDeepCopy builds the code generated for the type helpers and
+// checks that the copies share no memory with the original values.
+func TestTypeHelpersDeepCopy(t *testing.T) {
+	codegen.RunDSL(t, testdata.TypeHelpersDSL)
+	f := File("example.com/generated/gen", expr.Root.Services[0])
+	AddServiceDataMetaTypeImports(f.SectionTemplates[0], expr.Root.Services[0])
+	runGeneratedTest(t, deepCopyTest, f)
+}
+
+// runGeneratedTest renders the given files in a temporary module that uses
+// this repository and runs the given test in the package of the first file.
+func runGeneratedTest(t *testing.T, test string, files ...*codegen.File) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping build of the generated code in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not available")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "goa-generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var pkgdir string
+	for _, f := range files {
+		path, err := f.Render(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pkgdir == "" {
+			pkgdir = filepath.Dir(path)
+		}
+	}
+	gomod := "module example.com/generated\n\ngo 1.13\n\nrequire goa.design/goa/v3 v3.0.0\n\nreplace goa.design/goa/v3 => " + root + "\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(pkgdir, "generated_test.go"), []byte(test), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = pkgdir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("generated code test failed: %s\n%s", err, out)
+	}
+}
+
+// deepCopyTest mutates the values copied with the generated DeepCopy method.
+const deepCopyTest = `package typehelpers
+
+import (
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestDeepCopy(t *testing.T) {
+	now := time.Now()
+	when := now
+	v := &HelpersPayload{
+		Item:   &Item{Name: "a", Data: []byte("data")},
+		Items:  []*Item{{Name: "b"}},
+		Labels: map[string][]string{"k": {"v"}},
+		When:   &when,
+		Raw:    goa.NewNullableBytes([]byte("raw")),
+	}
+	c := v.DeepCopy()
+	if !c.Equal(v) {
+		t.Fatalf("got copy %+v, expected %+v", c, v)
+	}
+	v.Item.Name = "x"
+	v.Item.Data[0] = 'x'
+	v.Items[0].Name = "x"
+	v.Labels["k"][0] = "x"
+	*v.When = time.Time{}
+	v.Raw.Value[0] = 'x'
+	if c.Item.Name != "a" || string(c.Item.Data) != "data" || c.Items[0].Name != "b" ||
+		c.Labels["k"][0] != "v" || !c.When.Equal(now) || string(c.Raw.Value) != "raw" {
+		t.Errorf("copy shares memory with the original value: %+v", c)
+	}
+}
+`
@@ -18,6 +18,7 @@ func TestSecureEndpointInit(t *testing.T) {
 		{"endpoints-with-requirements", testdata.EndpointsWithRequirementsDSL, testdata.EndpointInitWithRequirementsCode},
 		{"endpoints-with-service-requirements", testdata.EndpointsWithServiceRequirementsDSL, testdata.EndpointInitWithServiceRequirementsCode},
 		{"endpoints-no-security", testdata.EndpointNoSecurityDSL, testdata.EndpointInitNoSecurityCode},
+		{"endpoint-with-authorization", testdata.EndpointWithAuthorizationDSL, testdata.EndpointInitWithAuthorizationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -51,6 +52,9 @@ func TestSecureEndpoint(t *testing.T) {
 		{"with-optional-required-scopes", testdata.EndpointWithOptionalRequiredScopesDSL, testdata.EndpointWithOptionalRequiredScopesCode},
 		{"with-api-key-override", testdata.EndpointWithAPIKeyOverrideDSL, testdata.EndpointWithAPIKeyOverrideCode},
 		{"with-oauth2", testdata.EndpointWithOAuth2DSL, testdata.EndpointWithOAuth2Code},
+		{"with-authorization", testdata.EndpointWithAuthorizationDSL, testdata.EndpointWithAuthorizationCode},
+		{"with-authorization-field-name", testdata.EndpointWithAuthorizationFieldNameDSL, testdata.EndpointWithAuthorizationFieldNameCode},
+		{"with-mtls", testdata.EndpointWithMTLSDSL, testdata.EndpointWithMTLSCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
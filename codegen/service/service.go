@@ -17,7 +17,11 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		service.Name+" service",
 		svc.PkgName,
 		[]*codegen.ImportSpec{
+			{Path: "bytes"},
 			{Path: "context"},
+			{Path: "crypto/x509"},
+			{Path: "reflect"},
+			{Path: "strconv"},
 			codegen.GoaImport(""),
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
@@ -148,6 +152,31 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		})
 	}
 
+	// constructors and setter methods
+	for _, b := range svc.builders {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "builder",
+			Source: builderT,
+			Data:   b,
+		})
+	}
+
+	// equality, deep copy and diff methods
+	for _, t := range svc.typeHelpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "type-helpers",
+			Source: typeHelpersT,
+			Data:   t,
+		})
+	}
+	for _, f := range svc.helperFuncs {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "helper-functions",
+			Source: helperFunctionsT,
+			Data:   f,
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -227,12 +256,16 @@ type Service interface {
 {{- end }}
 }
 
-{{- if .Schemes }}
+{{- if or .Schemes .Authorized }}
 // Auther defines the authorization functions to be implemented by the service.
 type Auther interface {
 	{{- range .Schemes }}
 	{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
-	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MTLS" }}cert *x509.Certificate{{ else }}token string{{ end }}, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{- end }}
+	{{- if .Authorized }}
+	{{ comment "Authorize implements the authorization logic for the methods that define authorization requirements. attrs contains the values of the payload attributes listed in the policy requirements." }}
+	Authorize(ctx context.Context, attrs map[string]interface{}, policy *security.AuthorizationPolicy) (context.Context, error)
 	{{- end }}
 }
 {{- end }}
@@ -290,6 +323,29 @@ type {{ .Result }} {{ .ResultDef }}
 
 const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
+{{- if .EnumValues }}
+
+// Values of {{ .VarName }}.
+const (
+{{- range .EnumValues }}
+	{{ .Name }} {{ $.VarName }} = {{ .Value }}
+{{- end }}
+)
+
+// Valid returns true if v is one of the {{ .VarName }} values.
+func (v {{ .VarName }}) Valid() bool {
+	switch v {
+	case {{ range $i, $v := .EnumValues }}{{ if $i }}, {{ end }}{{ $v.Name }}{{ end }}:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of v.
+func (v {{ .VarName }}) String() string {
+	return {{ .EnumString }}
+}
+{{- end }}
 `
 
 const errorT = `// Error returns an error description.
@@ -3,8 +3,10 @@ package service
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -54,6 +56,9 @@ type (
 		Methods []*MethodData
 		// Schemes is the list of security schemes required by the service methods.
 		Schemes SchemesData
+		// Authorized is true if at least one of the service methods
+		// defines authorization requirements.
+		Authorized bool
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
@@ -71,6 +76,20 @@ type (
 		projectedTypes []*ProjectedTypeData
 		// viewedResultTypes lists all the viewed method result types.
 		viewedResultTypes []*ViewedResultTypeData
+		// typeHelpers lists the Equal, DeepCopy and Diff methods generated
+		// for the service types.
+		typeHelpers []*TypeHelpersData
+		// helperFuncs lists the functions used by the typeHelpers methods.
+		helperFuncs []*HelperFunctionData
+		// viewTypeHelpers lists the Equal, DeepCopy and Diff methods
+		// generated for the viewed result types and projected types.
+		viewTypeHelpers []*TypeHelpersData
+		// viewHelperFuncs lists the functions used by the viewTypeHelpers
+		// methods.
+		viewHelperFuncs []*HelperFunctionData
+		// builders lists the constructors and setter methods generated for
+		// the types that define the "type:generate:builder" meta.
+		builders []*BuilderData
 	}
 
 	// ErrorInitData describes an error returned by a service method of type
@@ -140,6 +159,12 @@ type (
 		// Schemes contains the security schemes types used by the
 		// method.
 		Schemes SchemesData
+		// Authorizations contains the authorization requirements for the
+		// method.
+		Authorizations []*AuthorizationData
+		// AuthorizationAttributes lists the payload attributes referenced
+		// by the authorization requirements without duplicates.
+		AuthorizationAttributes []*AuthorizationAttributeData
 		// ViewedResult contains the data required to generate the code handling
 		// views if any.
 		ViewedResult *ViewedResultTypeData
@@ -151,6 +176,21 @@ type (
 		ClientStream *StreamData
 		// StreamKind is the kind of the stream (payload or result or bidirectional).
 		StreamKind expr.StreamKind
+		// Pagination contains the data needed to generate the client
+		// iterator over the pages of results if the method is paginated.
+		Pagination *PaginationData
+		// FieldsField is the name of the payload field that lists the
+		// result attributes to render if the method lets clients select
+		// them.
+		FieldsField string
+		// RequestBodyLimit is the maximum size in bytes of the request
+		// body, zero if the method is streaming or does not limit the
+		// request body size.
+		RequestBodyLimit int64
+		// RequestTimeout is the code of the maximum duration of the
+		// requests, empty if the method is streaming or does not define
+		// a timeout.
+		RequestTimeout string
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -198,6 +238,59 @@ type (
 		Scopes []string
 	}
 
+	// CredentialData describes a payload credential that the generated
+	// clients obtain from a credential source when the payload does not set
+	// it.
+	CredentialData struct {
+		// Scheme is the security scheme that uses the credential.
+		Scheme *SchemeData
+		// RequiredScopes lists the scopes required by the security
+		// requirement that defines the scheme.
+		RequiredScopes []string
+	}
+
+	// AuthorizationData lists the roles, scopes and payload attributes
+	// defined by a single authorization requirement.
+	AuthorizationData struct {
+		// Roles list the required roles.
+		Roles []string
+		// Scopes list the required scopes.
+		Scopes []string
+		// Attributes list the payload attributes given to the authorizer.
+		Attributes []*AuthorizationAttributeData
+	}
+
+	// AuthorizationAttributeData describes a payload attribute given to the
+	// authorizer.
+	AuthorizationAttributeData struct {
+		// Name is the name of the attribute.
+		Name string
+		// FieldName is the name of the corresponding payload field.
+		FieldName string
+		// Pointer is true if the payload field is a pointer to a
+		// primitive value.
+		Pointer bool
+	}
+
+	// PaginationData contains the data needed to generate the iterator over
+	// the pages of results of a paginated method.
+	PaginationData struct {
+		// IteratorName is the name of the iterator struct.
+		IteratorName string
+		// PagesName is the name of the client method that returns the
+		// iterator.
+		PagesName string
+		// TokenField is the name of the payload field that identifies
+		// the page to retrieve.
+		TokenField string
+		// NextField is the name of the result field that identifies the
+		// next page.
+		NextField string
+		// Cursor is true if pages are identified by cursors in which
+		// case an empty cursor also identifies the last page.
+		Cursor bool
+	}
+
 	// UserTypeData contains the data describing a user-defined type.
 	UserTypeData struct {
 		// Name is the type name.
@@ -212,12 +305,27 @@ type (
 		Ref string
 		// Type is the underlying type.
 		Type expr.UserType
+		// EnumValues lists the constants generated for the values of the
+		// type Enum validation if the type is a primitive with an Enum
+		// validation, nil otherwise.
+		EnumValues []*EnumValueData
+		// EnumString is the Go code that converts a value of the type into
+		// a string in the String method of enum types.
+		EnumString string
+	}
+
+	// EnumValueData describes a constant generated for an enum value.
+	EnumValueData struct {
+		// Name is the name of the constant.
+		Name string
+		// Value is the Go literal of the constant value.
+		Value string
 	}
 
 	// SchemeData describes a single security scheme.
 	SchemeData struct {
-		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT"
-		// or "OAuth2".
+		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT",
+		// "OAuth2" or "MTLS".
 		Type string
 		// SchemeName is the name of the scheme.
 		SchemeName string
@@ -296,6 +404,38 @@ type (
 		ViewName string
 		// ViewsPkg is the views package name.
 		ViewsPkg string
+		// Select contains the data needed to render the function that
+		// projects the viewed result type down to the attributes selected
+		// by the client if any.
+		Select *SelectFieldsData
+	}
+
+	// SelectFieldsData contains the data needed to render the function that
+	// projects a viewed result type down to the attributes selected by the
+	// client.
+	SelectFieldsData struct {
+		// Name is the name of the function.
+		Name string
+		// VarName is the Go name of the viewed result type.
+		VarName string
+		// MapName is the name of the variable that lists the attributes
+		// rendered by each view.
+		MapName string
+		// IsCollection is true if the viewed result type is a collection.
+		IsCollection bool
+		// Fields lists the projected type fields that may be omitted, one
+		// per attribute that is not required.
+		Fields []*SelectFieldData
+	}
+
+	// SelectFieldData describes a projected type field that may be omitted.
+	SelectFieldData struct {
+		// Name is the name of the attribute as defined in the design.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Zero is the Go code that represents the zero value of the field.
+		Zero string
 	}
 
 	// ViewData contains data about a result type view.
@@ -414,6 +554,29 @@ func (r RequirementsData) Scheme(name string) *SchemeData {
 	return nil
 }
 
+// Credentials returns the credentials of the API key, JWT and OAuth2 schemes
+// of the requirements. Schemes that share the same payload field are listed
+// once.
+func (r RequirementsData) Credentials() []*CredentialData {
+	var (
+		creds []*CredentialData
+		seen  = make(map[string]bool)
+	)
+	for _, req := range r {
+		for _, s := range req.Schemes {
+			if s.CredField == "" || seen[s.CredField] {
+				continue
+			}
+			switch s.Type {
+			case "APIKey", "JWT", "OAuth2":
+				seen[s.CredField] = true
+				creds = append(creds, &CredentialData{Scheme: s, RequiredScopes: req.Scopes})
+			}
+		}
+	}
+	return creds
+}
+
 // Dup creates a copy of the scheme data.
 func (s *SchemeData) Dup() *SchemeData {
 	return &SchemeData{
@@ -438,6 +601,27 @@ func (s *SchemeData) Dup() *SchemeData {
 	}
 }
 
+// HasType returns true if schemes contain a scheme of the given type.
+func (s SchemesData) HasType(typ string) bool {
+	for _, se := range s {
+		if se.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedMTLS returns true if at least one method of the given services uses the
+// mutual TLS security scheme.
+func NeedMTLS(svcs []*Data) bool {
+	for _, svc := range svcs {
+		if svc.Schemes.HasType("MTLS") {
+			return true
+		}
+	}
+	return false
+}
+
 // Append appends a scheme data to schemes only if it doesn't exist.
 func (s SchemesData) Append(d *SchemeData) SchemesData {
 	found := false
@@ -561,8 +745,9 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 	}
 
 	var (
-		methods []*MethodData
-		schemes SchemesData
+		methods    []*MethodData
+		schemes    SchemesData
+		authorized bool
 	)
 	{
 		methods = make([]*MethodData, len(service.Methods))
@@ -579,11 +764,87 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 					seenViewed[vrt.Name] = vrt
 					m.ViewedResult = vrt
 				}
+				if e.FieldSelection != nil && m.ViewedResult.Select == nil {
+					projected := seenProj[rt.ID()]
+					var keep []string
+					if e.Pagination != nil {
+						keep = append(keep, e.Pagination.NextAttribute())
+					}
+					m.ViewedResult.Select = buildSelectFieldsData(m.ViewedResult, projected.Type, keep, viewScope)
+				}
 			}
 			methods[i] = m
 			for _, s := range m.Schemes {
 				schemes = schemes.Append(s)
 			}
+			if len(m.Authorizations) > 0 {
+				authorized = true
+			}
+		}
+	}
+
+	var (
+		helpers     *helpersBuilder
+		viewHelpers *helpersBuilder
+	)
+	{
+		helpers = newHelpersBuilder(scope, typeContext("", scope))
+		add := func(att *expr.AttributeExpr) {
+			if ut, ok := att.Type.(expr.UserType); ok && generateHelpers(ut) {
+				helpers.Add(ut)
+			}
+		}
+		for _, m := range service.Methods {
+			add(m.Payload)
+			add(m.StreamingPayload)
+			add(m.Result)
+		}
+		for _, t := range types {
+			add(&expr.AttributeExpr{Type: t.Type})
+		}
+		for _, t := range errTypes {
+			add(&expr.AttributeExpr{Type: t.Type})
+		}
+
+		// Viewed result types and projected types are generated in the views
+		// package.
+		viewHelpers = newHelpersBuilder(viewScope, projectedTypeContext("", viewScope))
+		for _, t := range viewedRTs {
+			if ut, ok := expr.AsObject(t.Type).Attribute("projected").Type.(expr.UserType); ok && generateHelpers(ut) {
+				viewHelpers.AddNamed(t.Type, t.VarName, "*"+t.VarName, typeContext("", viewScope))
+			}
+		}
+		for _, t := range projTypes {
+			if generateHelpers(t.Type) {
+				viewHelpers.Add(t.Type)
+			}
+		}
+	}
+
+	var builders []*BuilderData
+	{
+		seenBuilders := make(map[string]struct{})
+		add := func(att *expr.AttributeExpr) {
+			ut, ok := att.Type.(expr.UserType)
+			if !ok || !generateBuilder(ut) {
+				return
+			}
+			if _, ok := seenBuilders[ut.ID()]; ok {
+				return
+			}
+			seenBuilders[ut.ID()] = struct{}{}
+			builders = append(builders, buildBuilderData(ut, scope))
+		}
+		for _, m := range service.Methods {
+			add(m.Payload)
+			add(m.StreamingPayload)
+			add(m.Result)
+		}
+		for _, t := range types {
+			add(&expr.AttributeExpr{Type: t.Type})
+		}
+		for _, t := range errTypes {
+			add(&expr.AttributeExpr{Type: t.Type})
 		}
 	}
 
@@ -606,6 +867,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		ViewsPkg:          viewspkg,
 		Methods:           methods,
 		Schemes:           schemes,
+		Authorized:        authorized,
 		Scope:             scope,
 		ViewScope:         viewScope,
 		errorTypes:        errTypes,
@@ -613,6 +875,11 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		userTypes:         types,
 		projectedTypes:    projTypes,
 		viewedResultTypes: viewedRTs,
+		typeHelpers:       helpers.types,
+		helperFuncs:       helpers.funcs,
+		viewTypeHelpers:   viewHelpers.types,
+		viewHelperFuncs:   viewHelpers.funcs,
+		builders:          builders,
 	}
 	d[service.Name] = data
 
@@ -644,13 +911,16 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 		if _, ok := seen[dt.ID()]; ok {
 			return nil
 		}
+		vname := scope.GoTypeName(at)
 		data = append(data, &UserTypeData{
 			Name:        dt.Name(),
-			VarName:     scope.GoTypeName(at),
+			VarName:     vname,
 			Description: dt.Attribute().Description,
 			Def:         scope.GoTypeDef(dt.Attribute(), false, true),
 			Ref:         scope.GoTypeRef(at),
 			Type:        dt,
+			EnumValues:  enumValues(dt, vname),
+			EnumString:  enumString(dt),
 		})
 		seen[dt.ID()] = struct{}{}
 		data = append(data, collect(dt.Attribute())...)
@@ -667,6 +937,69 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 	return
 }
 
+// enumValues returns the constants generated for the Enum validation values of
+// the given primitive user type. It returns nil if the type is not a primitive
+// or does not define an Enum validation.
+func enumValues(ut expr.UserType, vname string) []*EnumValueData {
+	if enumString(ut) == "" {
+		return nil
+	}
+	val := ut.Attribute().Validation
+	if val == nil || len(val.Values) == 0 {
+		return nil
+	}
+	var (
+		vals = make([]*EnumValueData, len(val.Values))
+		seen = make(map[string]struct{})
+	)
+	for i, v := range val.Values {
+		suffix := codegen.Goify(fmt.Sprintf("%v", v), true)
+		if f, ok := v.(float64); ok {
+			suffix = codegen.Goify(strings.Replace(strconv.FormatFloat(f, 'f', -1, 64), ".", "_", 1), true)
+		}
+		if strings.HasPrefix(fmt.Sprintf("%v", v), "-") {
+			suffix = "Neg" + suffix
+		}
+		if suffix == "" {
+			suffix = "Empty"
+		}
+		name := vname + suffix
+		if _, ok := seen[name]; ok {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		seen[name] = struct{}{}
+		vals[i] = &EnumValueData{Name: name, Value: fmt.Sprintf("%#v", v)}
+	}
+	return vals
+}
+
+// enumString returns the Go code that converts the value "v" of the given
+// primitive user type into a string. It returns the empty string if the type
+// does not support enum constants.
+func enumString(ut expr.UserType) string {
+	if !expr.IsPrimitive(ut) {
+		return ""
+	}
+	switch ut.Attribute().Type.Kind() {
+	case expr.StringKind:
+		return "string(v)"
+	case expr.BooleanKind:
+		return "strconv.FormatBool(bool(v))"
+	case expr.IntKind:
+		return "strconv.Itoa(int(v))"
+	case expr.Int32Kind, expr.Int64Kind:
+		return "strconv.FormatInt(int64(v), 10)"
+	case expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return "strconv.FormatUint(uint64(v), 10)"
+	case expr.Float32Kind:
+		return "strconv.FormatFloat(float64(v), 'g', -1, 32)"
+	case expr.Float64Kind:
+		return "strconv.FormatFloat(float64(v), 'g', -1, 64)"
+	default:
+		return ""
+	}
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *expr.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Meta["goa:error:temporary"]
@@ -708,6 +1041,8 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		errors       []*ErrorInitData
 		reqs         RequirementsData
 		schemes      SchemesData
+		authzs       []*AuthorizationData
+		authzAtts    []*AuthorizationAttributeData
 		svrStream    *StreamData
 		cliStream    *StreamData
 	)
@@ -816,37 +1151,124 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		}
 		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
 	}
+	seenAtts := make(map[string]struct{})
+	for _, a := range m.Authorizations {
+		atts := make([]*AuthorizationAttributeData, len(a.Attributes))
+		for i, n := range a.Attributes {
+			atts[i] = &AuthorizationAttributeData{
+				Name:      n,
+				FieldName: codegen.GoifyAtt(expr.AsObject(m.Payload.Type).Attribute(n), n, true),
+				Pointer:   m.Payload.IsPrimitivePointer(n, true),
+			}
+			if _, ok := seenAtts[n]; !ok {
+				seenAtts[n] = struct{}{}
+				authzAtts = append(authzAtts, atts[i])
+			}
+		}
+		authzs = append(authzs, &AuthorizationData{
+			Roles:      a.Roles,
+			Scopes:     a.Scopes,
+			Attributes: atts,
+		})
+	}
 
+	var pagination *PaginationData
+	if p := m.Pagination; p != nil {
+		pagination = &PaginationData{
+			IteratorName: scope.Unique(vname + "Iterator"),
+			PagesName:    vname + "Pages",
+			TokenField:   codegen.GoifyAtt(m.Payload.Find(p.TokenAttribute()), p.TokenAttribute(), true),
+			NextField:    codegen.GoifyAtt(m.Result.Find(p.NextAttribute()), p.NextAttribute(), true),
+			Cursor:       p.Kind == expr.CursorPaginationKind,
+		}
+	}
+	var fieldsField string
+	if m.FieldSelection != nil {
+		fieldsField = codegen.GoifyAtt(m.Payload.Find(expr.FieldSelectionAttribute), expr.FieldSelectionAttribute, true)
+	}
+	var (
+		bodyLimit int64
+		timeout   string
+	)
+	if !m.IsStreaming() {
+		bodyLimit = m.RequestBodyLimit
+		if m.RequestTimeout > 0 {
+			timeout = durationCode(m.RequestTimeout)
+		}
+	}
 	return &MethodData{
-		Name:                 m.Name,
-		VarName:              vname,
-		Description:          desc,
-		Payload:              payloadName,
-		PayloadDef:           payloadDef,
-		PayloadRef:           payloadRef,
-		PayloadDesc:          payloadDesc,
-		PayloadEx:            payloadEx,
-		StreamingPayload:     spayloadName,
-		StreamingPayloadDef:  spayloadDef,
-		StreamingPayloadRef:  spayloadRef,
-		StreamingPayloadDesc: spayloadDesc,
-		StreamingPayloadEx:   spayloadEx,
-		Result:               rname,
-		ResultDef:            resultDef,
-		ResultRef:            resultRef,
-		ResultDesc:           resultDesc,
-		ResultEx:             resultEx,
-		Errors:               errors,
-		Requirements:         reqs,
-		Schemes:              schemes,
-		ServerStream:         svrStream,
-		ClientStream:         cliStream,
-		StreamKind:           m.Stream,
+		Name:                    m.Name,
+		VarName:                 vname,
+		Description:             desc,
+		Payload:                 payloadName,
+		PayloadDef:              payloadDef,
+		PayloadRef:              payloadRef,
+		PayloadDesc:             payloadDesc,
+		PayloadEx:               payloadEx,
+		StreamingPayload:        spayloadName,
+		StreamingPayloadDef:     spayloadDef,
+		StreamingPayloadRef:     spayloadRef,
+		StreamingPayloadDesc:    spayloadDesc,
+		StreamingPayloadEx:      spayloadEx,
+		Result:                  rname,
+		ResultDef:               resultDef,
+		ResultRef:               resultRef,
+		ResultDesc:              resultDesc,
+		ResultEx:                resultEx,
+		Errors:                  errors,
+		Requirements:            reqs,
+		Schemes:                 schemes,
+		Authorizations:          authzs,
+		AuthorizationAttributes: authzAtts,
+		ServerStream:            svrStream,
+		ClientStream:            cliStream,
+		StreamKind:              m.Stream,
+		Pagination:              pagination,
+		FieldsField:             fieldsField,
+		RequestBodyLimit:        bodyLimit,
+		RequestTimeout:          timeout,
+	}
+}
+
+// durationCode returns the Go code of the given duration expressed in the
+// largest unit that divides it, e.g. "30 * time.Second".
+func durationCode(d time.Duration) string {
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+		{"time.Microsecond", time.Microsecond},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
 	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
 }
 
 // buildSchemeData builds the scheme data for the given scheme and method expr.
 func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
+	if s.Kind == expr.MTLSKind {
+		// The client certificate is provided by the transport and does
+		// not need a payload attribute.
+		var scopes []string
+		if len(s.Scopes) > 0 {
+			scopes = make([]string, len(s.Scopes))
+			for i, s := range s.Scopes {
+				scopes[i] = s.Name
+			}
+		}
+		return &SchemeData{
+			Type:       s.Kind.String(),
+			SchemeName: s.SchemeName,
+			Scopes:     scopes,
+		}
+	}
 	if !expr.IsObject(m.Payload.Type) {
 		return nil
 	}
@@ -956,6 +1378,11 @@ func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string,
 	}
 	switch pt := projected.Type.(type) {
 	case expr.UserType:
+		if expr.IsPrimitive(pt) {
+			// Projected types hold the underlying primitive values.
+			expr.UnwrapPrimitive(projected)
+			return
+		}
 		dt := att.Type.(expr.UserType)
 		if pd, ok := seen[dt.ID()]; ok {
 			// a projected type is already created for this user type. We change the
@@ -1181,6 +1608,49 @@ func buildViewedResultType(att, projected *expr.AttributeExpr, viewspkg string,
 	}
 }
 
+// buildSelectFieldsData returns the data needed to render the function that
+// projects the given viewed result type down to the attributes selected by the
+// client. projected is the type of the viewed result type projection. The
+// required attributes and the attributes listed in keep are always rendered.
+func buildSelectFieldsData(vrt *ViewedResultTypeData, projected expr.UserType, keep []string, viewScope *codegen.NameScope) *SelectFieldsData {
+	att := projected.Attribute()
+	if arr := expr.AsArray(projected); arr != nil {
+		att = arr.ElemType
+	}
+	var fields []*SelectFieldData
+	for _, nat := range *expr.AsObject(att.Type) {
+		if att.IsRequired(nat.Name) {
+			continue
+		}
+		kept := false
+		for _, k := range keep {
+			if k == nat.Name {
+				kept = true
+				break
+			}
+		}
+		if kept {
+			continue
+		}
+		zero := "nil"
+		if expr.IsPrimitive(nat.Attribute.Type) && nat.Attribute.IsNullable() {
+			zero = viewScope.GoTypeRef(nat.Attribute) + "{}"
+		}
+		fields = append(fields, &SelectFieldData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Zero:      zero,
+		})
+	}
+	return &SelectFieldsData{
+		Name:         "Select" + vrt.VarName + "Fields",
+		VarName:      vrt.VarName,
+		MapName:      vrt.Views[0].TypeVarName + "Map",
+		IsCollection: vrt.IsCollection,
+		Fields:       fields,
+	}
+}
+
 // wrapProjected builds a viewed result type by wrapping the given projected
 // in a result type with "projected" and "view" attributes.
 func wrapProjected(projected expr.UserType) expr.UserType {
@@ -32,6 +32,10 @@ func TestService(t *testing.T) {
 		{"custom-errors", testdata.CustomErrorsDSL, testdata.CustomErrors},
 		{"force-generate-type", testdata.ForceGenerateTypeDSL, testdata.ForceGenerateType},
 		{"force-generate-type-explicit", testdata.ForceGenerateTypeExplicitDSL, testdata.ForceGenerateTypeExplicit},
+		{"enum-types", testdata.EnumTypesDSL, testdata.EnumTypes},
+		{"type-helpers", testdata.TypeHelpersDSL, testdata.TypeHelpers},
+		{"builder", testdata.BuilderDSL, testdata.Builder},
+		{"struct-tags", testdata.StructTagsDSL, testdata.StructTags},
 		{"streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethod},
 		{"streaming-result-with-views", testdata.StreamingResultWithViewsMethodDSL, testdata.StreamingResultWithViewsMethod},
 		{"streaming-result-with-explicit-view", testdata.StreamingResultWithExplicitViewMethodDSL, testdata.StreamingResultWithExplicitViewMethod},
@@ -283,3 +283,79 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const PaginatedMethodClient = `// Client is the "PaginatedService" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "PaginatedService" service client given the
+// endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// List calls the "List" endpoint of the "PaginatedService" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListPages returns an iterator over the pages of results of the "List"
+// endpoint of the "PaginatedService" service starting with the page identified
+// by p.
+func (c *Client) ListPages(p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, payload: p}
+}
+
+// ListIterator iterates over the pages of results of the "List" endpoint of
+// the "PaginatedService" service.
+type ListIterator struct {
+	client  *Client
+	payload *ListPayload
+	page    *ListResult
+	err     error
+	done    bool
+}
+
+// Next retrieves the next page of results. It returns false once all the pages
+// have been retrieved or if the request failed, see Err.
+func (it *ListIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	res, err := it.client.List(ctx, it.payload)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = res
+	if res.NextCursor == nil || *res.NextCursor == "" {
+		it.done = true
+		return true
+	}
+	p := *it.payload
+	p.Cursor = res.NextCursor
+	it.payload = &p
+	return true
+}
+
+// Page returns the page of results retrieved by the last call to Next.
+func (it *ListIterator) Page() *ListResult {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+`
@@ -187,6 +187,43 @@ func NewAEndpoint(s Service) goa.Endpoint {
 }
 `
 
+const WithResultSparseFieldsetsEndpoint = `// Endpoints wraps the "WithResultSparseFieldsets" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "WithResultSparseFieldsets" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "WithResultSparseFieldsets"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "WithResultSparseFieldsets".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		res, view, err := s.A(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedViewtype(res, view)
+		if err := withresultsparsefieldsetsviews.SelectViewtypeFields(vres, p.Fields); err != nil {
+			return nil, err
+		}
+		return vres, nil
+	}
+}
+`
+
 const StreamingResultMethodEndpoint = `// Endpoints wraps the "StreamingResultEndpoint" service endpoints.
 type Endpoints struct {
 	StreamingResultMethod goa.Endpoint
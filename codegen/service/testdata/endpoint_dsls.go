@@ -87,6 +87,29 @@ var WithResultMultipleViewsEndpointDSL = func() {
 	})
 }
 
+var WithResultSparseFieldsetsEndpointDSL = func() {
+	var ViewType = ResultType("application/vnd.withresult.sparse.fieldsets", func() {
+		TypeName("Viewtype")
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", String)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("WithResultSparseFieldsets", func() {
+		Method("A", func() {
+			Result(ViewType)
+			SparseFieldsets()
+		})
+	})
+}
+
 var StreamingResultEndpointDSL = func() {
 	var AType = Type("AType", func() {
 		Attribute("a", String)
@@ -22,6 +22,11 @@ var APIKeyAuth = APIKeySecurity("api_key", func() {
 	Scope("api:admin", "Admin access")
 })
 
+var MTLSAuth = MTLSSecurity("mtls", func() {
+	Scope("api:read", "Read-only access")
+	Scope("api:write", "Read and write access")
+})
+
 var OAuth2AuthorizationCode = OAuth2Security("authCode", func() {
 	AuthorizationCodeFlow("/authorization", "/token", "/refresh")
 	Scope("api:write", "Write acess")
@@ -117,6 +122,64 @@ var EndpointWithRequiredScopesDSL = func() {
 	})
 }
 
+var EndpointWithAuthorizationDSL = func() {
+	Service("EndpointWithAuthorization", func() {
+		Method("SecureWithAuthorization", func() {
+			Security(JWTAuth)
+			Authorize(func() {
+				Role("admin")
+			})
+			Authorize(func() {
+				Scope("api:write")
+				Resource("account_id", "project_id")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String)
+				Attribute("project_id", Int)
+				Required("account_id")
+			})
+			HTTP(func() {
+				PUT("/{account_id}")
+			})
+		})
+	})
+}
+
+var EndpointWithAuthorizationFieldNameDSL = func() {
+	Service("EndpointWithAuthorizationFieldName", func() {
+		Method("SecureWithAuthorizationFieldName", func() {
+			Security(JWTAuth)
+			Authorize(func() {
+				Resource("account_id")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String, func() {
+					Meta("struct:field:name", "Account")
+				})
+				Required("account_id")
+			})
+		})
+	})
+}
+
+var EndpointWithMTLSDSL = func() {
+	Service("EndpointWithMTLS", func() {
+		Method("SecureWithMTLS", func() {
+			Security(MTLSAuth, func() {
+				Scope("api:write")
+			})
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var EndpointWithOptionalRequiredScopesDSL = func() {
 	Service("EndpointWithOptionalRequiredScopes", func() {
 		Method("SecureWithOptionalRequiredScopes", func() {
@@ -152,3 +152,120 @@ func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func
 	}
 }
 `
+
+var EndpointInitWithAuthorizationCode = `// NewEndpoints wraps the methods of the "EndpointWithAuthorization" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		SecureWithAuthorization: NewSecureWithAuthorizationEndpoint(s, a.JWTAuth, a.Authorize),
+	}
+}
+`
+
+var EndpointWithAuthorizationCode = `// NewSecureWithAuthorizationEndpoint returns an endpoint function that calls
+// the method "SecureWithAuthorization" of service "EndpointWithAuthorization".
+func NewSecureWithAuthorizationEndpoint(s Service, authJWTFn security.AuthJWTFunc, authorizeFn security.AuthorizeFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SecureWithAuthorizationPayload)
+		var err error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		if err != nil {
+			return nil, err
+		}
+		policy := security.AuthorizationPolicy{
+			Service: "EndpointWithAuthorization",
+			Method:  "SecureWithAuthorization",
+			Requirements: []*security.AuthorizationRequirement{
+				&security.AuthorizationRequirement{
+					Roles: []string{"admin"},
+				},
+				&security.AuthorizationRequirement{
+					Scopes:     []string{"api:write"},
+					Attributes: []string{"account_id", "project_id"},
+				},
+			},
+		}
+		attrs := make(map[string]interface{})
+		attrs["account_id"] = p.AccountID
+		if p.ProjectID != nil {
+			attrs["project_id"] = *p.ProjectID
+		}
+		ctx, err = authorizeFn(ctx, attrs, &policy)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithAuthorization(ctx, p)
+	}
+}
+`
+
+var EndpointWithAuthorizationFieldNameCode = `// NewSecureWithAuthorizationFieldNameEndpoint returns an endpoint function
+// that calls the method "SecureWithAuthorizationFieldName" of service
+// "EndpointWithAuthorizationFieldName".
+func NewSecureWithAuthorizationFieldNameEndpoint(s Service, authJWTFn security.AuthJWTFunc, authorizeFn security.AuthorizeFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SecureWithAuthorizationFieldNamePayload)
+		var err error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		if err != nil {
+			return nil, err
+		}
+		policy := security.AuthorizationPolicy{
+			Service: "EndpointWithAuthorizationFieldName",
+			Method:  "SecureWithAuthorizationFieldName",
+			Requirements: []*security.AuthorizationRequirement{
+				&security.AuthorizationRequirement{
+					Attributes: []string{"account_id"},
+				},
+			},
+		}
+		attrs := make(map[string]interface{})
+		attrs["account_id"] = p.Account
+		ctx, err = authorizeFn(ctx, attrs, &policy)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithAuthorizationFieldName(ctx, p)
+	}
+}
+`
+
+var EndpointWithMTLSCode = `// NewSecureWithMTLSEndpoint returns an endpoint function that calls the method
+// "SecureWithMTLS" of service "EndpointWithMTLS".
+func NewSecureWithMTLSEndpoint(s Service, authMTLSFn security.AuthMTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SecureWithMTLSPayload)
+		var err error
+		sc := security.MTLSScheme{
+			Name:           "mtls",
+			Scopes:         []string{"api:read", "api:write"},
+			RequiredScopes: []string{"api:write"},
+		}
+		ctx, err = authMTLSFn(ctx, security.PeerCertificate(ctx), &sc)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithMTLS(ctx, p)
+	}
+}
+`
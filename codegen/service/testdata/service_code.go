@@ -1111,6 +1111,443 @@ type ForcedType struct {
 }
 `
 
+const EnumTypes = `
+// Service is the EnumTypes service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *EnumPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "EnumTypes"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// EnumPayload is the payload type of the EnumTypes service A method.
+type EnumPayload struct {
+	Color Color
+	Level *Level
+	Tint  *Tint
+}
+
+type Color string
+
+// Values of Color.
+const (
+	ColorRed       Color = "red"
+	ColorLightBlue Color = "light-blue"
+)
+
+// Valid returns true if v is one of the Color values.
+func (v Color) Valid() bool {
+	switch v {
+	case ColorRed, ColorLightBlue:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of v.
+func (v Color) String() string {
+	return string(v)
+}
+
+type Level int
+
+// Values of Level.
+const (
+	Level1    Level = 1
+	LevelNeg2 Level = -2
+)
+
+// Valid returns true if v is one of the Level values.
+func (v Level) Valid() bool {
+	switch v {
+	case Level1, LevelNeg2:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of v.
+func (v Level) String() string {
+	return strconv.Itoa(int(v))
+}
+
+type Tint string
+
+// Values of Tint.
+const (
+	TintDark  Tint = "dark"
+	TintEmpty Tint = ""
+)
+
+// Valid returns true if v is one of the Tint values.
+func (v Tint) Valid() bool {
+	switch v {
+	case TintDark, TintEmpty:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of v.
+func (v Tint) String() string {
+	return string(v)
+}
+`
+
+const Builder = `
+// Service is the Builder service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *BuilderPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Builder"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// BuilderPayload is the payload type of the Builder service A method.
+type BuilderPayload struct {
+	ID    string
+	Child *BuilderChild
+	Count int
+	Tags  []string
+	Note  *string
+	V     *float64
+	Blob  []byte
+	Extra interface{}
+}
+
+type BuilderChild struct {
+	Name *string
+}
+
+// NewBuilderPayload creates a new BuilderPayload from the values of its
+// required attributes and initializes its other attributes with their default
+// value if any.
+func NewBuilderPayload(id string, child *BuilderChild) *BuilderPayload {
+	v := &BuilderPayload{
+		ID:    id,
+		Child: child,
+		Count: 10,
+		Tags:  []string{"a", "b"},
+		Blob:  []byte("abc"),
+		Extra: map[string]interface{}{"a": 1},
+	}
+	return v
+}
+
+// WithCount sets the count attribute and returns v.
+func (v *BuilderPayload) WithCount(count int) *BuilderPayload {
+	v.Count = count
+	return v
+}
+
+// WithTags sets the tags attribute and returns v.
+func (v *BuilderPayload) WithTags(tags []string) *BuilderPayload {
+	v.Tags = tags
+	return v
+}
+
+// WithNote sets the note attribute and returns v.
+func (v *BuilderPayload) WithNote(note string) *BuilderPayload {
+	v.Note = &note
+	return v
+}
+
+// WithV sets the v attribute and returns v.
+func (v *BuilderPayload) WithV(val float64) *BuilderPayload {
+	v.V = &val
+	return v
+}
+
+// WithBlob sets the blob attribute and returns v.
+func (v *BuilderPayload) WithBlob(blob []byte) *BuilderPayload {
+	v.Blob = blob
+	return v
+}
+
+// WithExtra sets the extra attribute and returns v.
+func (v *BuilderPayload) WithExtra(extra interface{}) *BuilderPayload {
+	v.Extra = extra
+	return v
+}
+`
+
+const StructTags = `
+// Service is the StructTags service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *TaggedPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "StructTags"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// TaggedPayload is the payload type of the StructTags service A method.
+type TaggedPayload struct {
+	UserID    string  ` + "`" + `db:"id" yaml:"user_id"` + "`" + `
+	FirstName *string ` + "`" + `db:"first_name" yaml:"first_name"` + "`" + `
+}
+`
+
+const TypeHelpers = `
+// Service is the TypeHelpers service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *HelpersPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "TypeHelpers"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// HelpersPayload is the payload type of the TypeHelpers service A method.
+type HelpersPayload struct {
+	Item   *Item
+	Items  []*Item
+	Labels map[string][]string
+	When   *time.Time
+	Raw    goa.NullableBytes
+}
+
+type Item struct {
+	Name  string
+	Count *int
+	Data  []byte
+}
+
+// Equal returns true if v and other hold the same values.
+func (v *HelpersPayload) Equal(other *HelpersPayload) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	return v.Item.Equal(other.Item) &&
+		equalArrayOfItem(v.Items, other.Items) &&
+		equalMapOfStringArrayOfString(v.Labels, other.Labels) &&
+		equalTimePtr(v.When, other.When) &&
+		reflect.DeepEqual(v.Raw, other.Raw)
+}
+
+// DeepCopy returns a deep copy of v.
+func (v *HelpersPayload) DeepCopy() *HelpersPayload {
+	if v == nil {
+		return nil
+	}
+	return &HelpersPayload{
+		Item:   v.Item.DeepCopy(),
+		Items:  copyArrayOfItem(v.Items),
+		Labels: copyMapOfStringArrayOfString(v.Labels),
+		When:   copyTimePtr(v.When),
+		Raw:    goa.NullableBytes{Value: append([]byte(nil), v.Raw.Value...), Set: v.Raw.Set, Null: v.Raw.Null},
+	}
+}
+
+// Diff returns the list of fields whose values differ between v and other.
+func (v *HelpersPayload) Diff(other *HelpersPayload) []goa.FieldChange {
+	if v == nil || other == nil {
+		if v == other {
+			return nil
+		}
+		return []goa.FieldChange{{Old: v, New: other}}
+	}
+	var changes []goa.FieldChange
+	changes = append(changes, goa.NestFieldChanges("item", v.Item.Diff(other.Item))...)
+	if !equalArrayOfItem(v.Items, other.Items) {
+		changes = append(changes, goa.FieldChange{Field: "items", Old: v.Items, New: other.Items})
+	}
+	if !equalMapOfStringArrayOfString(v.Labels, other.Labels) {
+		changes = append(changes, goa.FieldChange{Field: "labels", Old: v.Labels, New: other.Labels})
+	}
+	if !equalTimePtr(v.When, other.When) {
+		changes = append(changes, goa.FieldChange{Field: "when", Old: v.When, New: other.When})
+	}
+	if !reflect.DeepEqual(v.Raw, other.Raw) {
+		changes = append(changes, goa.FieldChange{Field: "raw", Old: v.Raw, New: other.Raw})
+	}
+	return changes
+}
+
+// Equal returns true if v and other hold the same values.
+func (v *Item) Equal(other *Item) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	return v.Name == other.Name &&
+		equalIntPtr(v.Count, other.Count) &&
+		bytes.Equal(v.Data, other.Data)
+}
+
+// DeepCopy returns a deep copy of v.
+func (v *Item) DeepCopy() *Item {
+	if v == nil {
+		return nil
+	}
+	return &Item{
+		Name:  v.Name,
+		Count: copyIntPtr(v.Count),
+		Data:  append([]byte(nil), v.Data...),
+	}
+}
+
+// Diff returns the list of fields whose values differ between v and other.
+func (v *Item) Diff(other *Item) []goa.FieldChange {
+	if v == nil || other == nil {
+		if v == other {
+			return nil
+		}
+		return []goa.FieldChange{{Old: v, New: other}}
+	}
+	var changes []goa.FieldChange
+	if v.Name != other.Name {
+		changes = append(changes, goa.FieldChange{Field: "name", Old: v.Name, New: other.Name})
+	}
+	if !equalIntPtr(v.Count, other.Count) {
+		changes = append(changes, goa.FieldChange{Field: "count", Old: v.Count, New: other.Count})
+	}
+	if !bytes.Equal(v.Data, other.Data) {
+		changes = append(changes, goa.FieldChange{Field: "data", Old: v.Data, New: other.Data})
+	}
+	return changes
+}
+
+// equalArrayOfItem returns true if a and b hold the same values.
+func equalArrayOfItem(a, b []*Item) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyArrayOfItem returns a deep copy of a.
+func copyArrayOfItem(a []*Item) []*Item {
+	if a == nil {
+		return nil
+	}
+	res := make([]*Item, len(a))
+	for i, e := range a {
+		res[i] = e.DeepCopy()
+	}
+	return res
+}
+
+// equalMapOfStringArrayOfString returns true if a and b hold the same values.
+func equalMapOfStringArrayOfString(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !equalArrayOfString(va, vb) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyMapOfStringArrayOfString returns a deep copy of a.
+func copyMapOfStringArrayOfString(a map[string][]string) map[string][]string {
+	if a == nil {
+		return nil
+	}
+	res := make(map[string][]string, len(a))
+	for k, e := range a {
+		res[k] = copyArrayOfString(e)
+	}
+	return res
+}
+
+// equalArrayOfString returns true if a and b hold the same values.
+func equalArrayOfString(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyArrayOfString returns a deep copy of a.
+func copyArrayOfString(a []string) []string {
+	if a == nil {
+		return nil
+	}
+	res := make([]string, len(a))
+	for i, e := range a {
+		res[i] = e
+	}
+	return res
+}
+
+// equalTimePtr returns true if a and b hold the same values.
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// copyTimePtr returns a deep copy of a.
+func copyTimePtr(a *time.Time) *time.Time {
+	if a == nil {
+		return nil
+	}
+	res := *a
+	return &res
+}
+
+// equalIntPtr returns true if a and b hold the same values.
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// copyIntPtr returns a deep copy of a.
+func copyIntPtr(a *int) *int {
+	if a == nil {
+		return nil
+	}
+	res := *a
+	return &res
+}
+`
+
 const StreamingResultMethod = `
 // Service is the StreamingResultService service interface.
 type Service interface {
@@ -315,6 +315,114 @@ var ForceGenerateTypeExplicitDSL = func() {
 	})
 }
 
+var EnumTypesDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "light-blue")
+	})
+	var Level = Type("Level", Int, func() {
+		Enum(1, -2)
+	})
+	var EnumPayload = Type("EnumPayload", func() {
+		Attribute("color", Color)
+		Attribute("level", Level)
+		Attribute("tint", String, func() {
+			Enum("dark", "")
+			Meta("type:enum", "Tint")
+		})
+		Required("color")
+	})
+	Service("EnumTypes", func() {
+		Method("A", func() {
+			Payload(EnumPayload)
+		})
+	})
+}
+
+var TypeHelpersDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Attribute("count", Int)
+		Attribute("data", Bytes)
+		Required("name")
+	})
+	var HelpersPayload = Type("HelpersPayload", func() {
+		Attribute("item", Item)
+		Attribute("items", ArrayOf(Item))
+		Attribute("labels", MapOf(String, ArrayOf(String)))
+		Attribute("when", Time)
+		Attribute("raw", Bytes, func() {
+			Nullable()
+		})
+		Meta("type:generate:helpers")
+	})
+	Service("TypeHelpers", func() {
+		Method("A", func() {
+			Payload(HelpersPayload)
+		})
+	})
+}
+
+var BuilderDSL = func() {
+	var BuilderChild = Type("BuilderChild", func() {
+		Attribute("name", String)
+	})
+	var BuilderPayload = Type("BuilderPayload", func() {
+		Attribute("id", String)
+		Attribute("child", BuilderChild)
+		Attribute("count", Int, func() {
+			Default(10)
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			Default([]string{"a", "b"})
+		})
+		Attribute("note", String)
+		Attribute("v", Float64)
+		Attribute("blob", Bytes, func() {
+			Default("abc")
+		})
+		Attribute("extra", Any, func() {
+			Default(map[string]interface{}{"a": 1})
+		})
+		Required("id", "child")
+		Meta("type:generate:builder")
+	})
+	Service("Builder", func() {
+		Method("A", func() {
+			Payload(BuilderPayload)
+		})
+	})
+}
+
+var StructTagsDSL = func() {
+	var TaggedPayload = Type("TaggedPayload", func() {
+		StructTagNaming("snake_case", "db", "yaml")
+		Attribute("userID", String, func() {
+			StructTag("db", "id")
+		})
+		Attribute("firstName", String)
+		Required("userID")
+	})
+	Service("StructTags", func() {
+		Method("A", func() {
+			Payload(TaggedPayload)
+		})
+	})
+}
+
+var PaginatedMethodDSL = func() {
+	Service("PaginatedService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(CursorPagination)
+		})
+	})
+}
+
 var StreamingResultMethodDSL = func() {
 	Service("StreamingResultService", func() {
 		Method("StreamingResultMethod", func() {
@@ -547,3 +547,134 @@ func ValidateRTViewTiny(result *RTView) (err error) {
 	return
 }
 `
+const ResultCollectionSparseFieldsetsCode = `// ResultTypeCollection is the viewed result type that is projected based on a
+// view.
+type ResultTypeCollection struct {
+	// Type to project
+	Projected ResultTypeCollectionView
+	// View to render
+	View string
+}
+
+// ResultTypeCollectionView is a type that runs validations on a projected type.
+type ResultTypeCollectionView []*ResultTypeView
+
+// ResultTypeView is a type that runs validations on a projected type.
+type ResultTypeView struct {
+	A *string
+	B *string
+	C []string
+}
+
+var (
+	// ResultTypeCollectionMap is a map of attribute names in result type
+	// ResultTypeCollection indexed by view name.
+	ResultTypeCollectionMap = map[string][]string{
+		"default": []string{
+			"a",
+			"b",
+			"c",
+		},
+		"tiny": []string{
+			"a",
+		},
+	}
+	// ResultTypeMap is a map of attribute names in result type ResultType indexed
+	// by view name.
+	ResultTypeMap = map[string][]string{
+		"default": []string{
+			"a",
+			"b",
+			"c",
+		},
+		"tiny": []string{
+			"a",
+		},
+	}
+)
+
+// ValidateResultTypeCollection runs the validations defined on the viewed
+// result type ResultTypeCollection.
+func ValidateResultTypeCollection(result ResultTypeCollection) (err error) {
+	switch result.View {
+	case "default", "":
+		err = ValidateResultTypeCollectionView(result.Projected)
+	case "tiny":
+		err = ValidateResultTypeCollectionViewTiny(result.Projected)
+	default:
+		err = goa.InvalidEnumValueError("view", result.View, []interface{}{"default", "tiny"})
+	}
+	return
+}
+
+// ValidateResultTypeCollectionView runs the validations defined on
+// ResultTypeCollectionView using the "default" view.
+func ValidateResultTypeCollectionView(result ResultTypeCollectionView) (err error) {
+	for _, item := range result {
+		if err2 := ValidateResultTypeView(item); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateResultTypeCollectionViewTiny runs the validations defined on
+// ResultTypeCollectionView using the "tiny" view.
+func ValidateResultTypeCollectionViewTiny(result ResultTypeCollectionView) (err error) {
+	for _, item := range result {
+		if err2 := ValidateResultTypeViewTiny(item); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateResultTypeView runs the validations defined on ResultTypeView using
+// the "default" view.
+func ValidateResultTypeView(result *ResultTypeView) (err error) {
+	if result.A == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("a", "result"))
+	}
+	return
+}
+
+// ValidateResultTypeViewTiny runs the validations defined on ResultTypeView
+// using the "tiny" view.
+func ValidateResultTypeViewTiny(result *ResultTypeView) (err error) {
+	if result.A == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("a", "result"))
+	}
+	return
+}
+
+// SelectResultTypeCollectionFields projects the viewed result type
+// ResultTypeCollection down to the given fields. The attributes rendered by
+// the view that are not listed in fields are omitted, required attributes are
+// always rendered. SelectResultTypeCollectionFields returns an error if fields
+// lists an attribute that is not rendered by the view.
+func SelectResultTypeCollectionFields(result ResultTypeCollection, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	view := result.View
+	if view == "" {
+		view = "default"
+	}
+	selected, err := goa.SelectFields("fields", fields, ResultTypeCollectionMap[view])
+	if err != nil {
+		return err
+	}
+	for _, p := range result.Projected {
+		if p == nil {
+			continue
+		}
+		if _, ok := selected["b"]; !ok {
+			p.B = nil
+		}
+		if _, ok := selected["c"]; !ok {
+			p.C = nil
+		}
+	}
+	return nil
+}
+`
@@ -193,3 +193,29 @@ var ResultWithCustomFieldsDSL = func() {
 		})
 	})
 }
+
+var ResultCollectionSparseFieldsetsDSL = func() {
+	var RT = ResultType("application/vnd.result", func() {
+		TypeName("ResultType")
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", String)
+			Attribute("c", ArrayOf(String))
+			Required("a")
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+			Attribute("c")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("ResultCollectionSparseFieldsets", func() {
+		Method("A", func() {
+			Result(CollectionOf(RT))
+			SparseFieldsets()
+		})
+	})
+}
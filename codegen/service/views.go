@@ -28,6 +28,8 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 	{
 		header := codegen.Header(service.Name+" views", "views",
 			[]*codegen.ImportSpec{
+				{Path: "bytes"},
+				{Path: "reflect"},
 				codegen.GoaImport(""),
 				{Path: "unicode/utf8"},
 			})
@@ -99,6 +101,34 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				})
 			}
 		}
+
+		// field selection
+		for _, t := range svc.viewedResultTypes {
+			if t.Select == nil {
+				continue
+			}
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "select-fields",
+				Source: selectFieldsT,
+				Data:   t.Select,
+			})
+		}
+
+		// equality, deep copy and diff methods
+		for _, t := range svc.viewTypeHelpers {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "type-helpers",
+				Source: typeHelpersT,
+				Data:   t,
+			})
+		}
+		for _, f := range svc.viewHelperFuncs {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "helper-functions",
+				Source: helperFunctionsT,
+				Data:   f,
+			})
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -128,3 +158,37 @@ const viewedMapT = `var (
 {{- end }}
 )
 `
+
+// input: SelectFieldsData
+const selectFieldsT = `{{ printf "%s projects the viewed result type %s down to the given fields. The attributes rendered by the view that are not listed in fields are omitted, required attributes are always rendered. %s returns an error if fields lists an attribute that is not rendered by the view." .Name .VarName .Name | comment }}
+func {{ .Name }}(result {{ if not .IsCollection }}*{{ end }}{{ .VarName }}, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	view := result.View
+	if view == "" {
+		view = "default"
+	}
+	{{ if .Fields }}selected{{ else }}_{{ end }}, err := goa.SelectFields("fields", fields, {{ .MapName }}[view])
+	if err != nil {
+		return err
+	}
+{{- if .Fields }}
+	{{- if .IsCollection }}
+	for _, p := range result.Projected {
+		if p == nil {
+			continue
+		}
+	{{- else }}
+	if p := result.Projected; p != nil {
+	{{- end }}
+	{{- range .Fields }}
+		if _, ok := selected[{{ printf "%q" .Name }}]; !ok {
+			p.{{ .FieldName }} = {{ .Zero }}
+		}
+	{{- end }}
+	}
+{{- end }}
+	return nil
+}
+`
@@ -23,6 +23,7 @@ func TestViews(t *testing.T) {
 		{"result-with-result-type", testdata.ResultWithResultTypeDSL, testdata.ResultWithResultTypeCode},
 		{"result-with-recursive-result-type", testdata.ResultWithRecursiveResultTypeDSL, testdata.ResultWithRecursiveResultTypeCode},
 		{"result-type-with-custom-fields", testdata.ResultWithCustomFieldsDSL, testdata.ResultWithCustomFieldsCode},
+		{"result-collection-sparse-fieldsets", testdata.ResultCollectionSparseFieldsetsDSL, testdata.ResultCollectionSparseFieldsetsCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -212,5 +212,47 @@ var TestTypesDSL = func() {
 			})
 			Required("required_int", "required_string", "required_bytes", "required_any", "required_array", "required_map")
 		})
+
+		_ = Type("TimeTypes", func() {
+			Attribute("time", Time)
+			Attribute("duration", Duration)
+			Attribute("decimal", Decimal)
+			Attribute("required_time", Time)
+			Attribute("times", ArrayOf(Time))
+			Required("required_time")
+		})
+
+		Color = Type("Color", String, func() {
+			Enum("red", "blue")
+		})
+
+		_ = Type("EnumTypes", func() {
+			Attribute("color", Color)
+			Attribute("required_color", Color)
+			Attribute("colors", ArrayOf(Color))
+			Attribute("by_color", MapOf(Color, Int))
+			Required("required_color")
+		})
+
+		_ = Type("EnumValues", func() {
+			Attribute("color", String)
+			Attribute("required_color", String)
+			Attribute("colors", ArrayOf(String))
+			Attribute("by_color", MapOf(String, Int))
+			Required("required_color")
+		})
+
+		_ = Type("Nullable", func() {
+			Attribute("required_string", String, func() {
+				Nullable()
+			})
+			Attribute("default_bool", Boolean, func() {
+				Nullable()
+			})
+			Attribute("integer", Int, func() {
+				Nullable()
+			})
+			Required("required_string")
+		})
 	)
 }
@@ -358,5 +358,161 @@ const (
 		}
 	}
 }
+`
+
+	NumberRequiredValidationCode = `func Validate() (err error) {
+	if target.RequiredPrice <= 0 {
+		err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_price", target.RequiredPrice, 0, true))
+	}
+	err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.required_price", float64(target.RequiredPrice), 0.01))
+	if target.DefaultRatio != nil {
+		if *target.DefaultRatio >= 1 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.default_ratio", *target.DefaultRatio, 1, false))
+		}
+	}
+	if target.Quantity != nil {
+		if *target.Quantity >= 100 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.quantity", *target.Quantity, 100, false))
+		}
+	}
+	if target.Quantity != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.quantity", float64(*target.Quantity), 5))
+	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.tags", target.Tags))
+	if len(target.Labels) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 1, true))
+	}
+	if len(target.Labels) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 10, false))
+	}
+}
+`
+
+	NumberPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredPrice == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_price", "target"))
+	}
+	if target.RequiredPrice != nil {
+		if *target.RequiredPrice <= 0 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_price", *target.RequiredPrice, 0, true))
+		}
+	}
+	if target.RequiredPrice != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.required_price", float64(*target.RequiredPrice), 0.01))
+	}
+	if target.DefaultRatio != nil {
+		if *target.DefaultRatio >= 1 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.default_ratio", *target.DefaultRatio, 1, false))
+		}
+	}
+	if target.Quantity != nil {
+		if *target.Quantity >= 100 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.quantity", *target.Quantity, 100, false))
+		}
+	}
+	if target.Quantity != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.quantity", float64(*target.Quantity), 5))
+	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.tags", target.Tags))
+	if len(target.Labels) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 1, true))
+	}
+	if len(target.Labels) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 10, false))
+	}
+}
+`
+
+	NumberUseDefaultValidationCode = `func Validate() (err error) {
+	if target.RequiredPrice <= 0 {
+		err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_price", target.RequiredPrice, 0, true))
+	}
+	err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.required_price", float64(target.RequiredPrice), 0.01))
+	if target.DefaultRatio >= 1 {
+		err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.default_ratio", target.DefaultRatio, 1, false))
+	}
+	if target.Quantity != nil {
+		if *target.Quantity >= 100 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.quantity", *target.Quantity, 100, false))
+		}
+	}
+	if target.Quantity != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.quantity", float64(*target.Quantity), 5))
+	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.tags", target.Tags))
+	if len(target.Labels) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 1, true))
+	}
+	if len(target.Labels) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.labels", target.Labels, len(target.Labels), 10, false))
+	}
+}
+`
+
+	CustomRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, validators.ValidateCustom("target", target))
+	err = goa.MergeErrors(err, goa.ValidatePattern("target.iban", target.Iban, "^[A-Z]{2}"))
+	err = goa.MergeErrors(err, validators.ValidateIBAN("target.iban", target.Iban))
+	if target.Ratio != nil {
+		err = goa.MergeErrors(err, v.ValidateRatio("target.ratio", *target.Ratio))
+	}
+	for _, e := range target.Codes {
+		err = goa.MergeErrors(err, validators.ValidateCode("target.codes[*]", e))
+	}
+}
+`
+
+	CustomPointerValidationCode = `func Validate() (err error) {
+	if target.Iban == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("iban", "target"))
+	}
+	err = goa.MergeErrors(err, validators.ValidateCustom("target", target))
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.iban", *target.Iban, "^[A-Z]{2}"))
+	}
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, validators.ValidateIBAN("target.iban", *target.Iban))
+	}
+	if target.Ratio != nil {
+		err = goa.MergeErrors(err, v.ValidateRatio("target.ratio", *target.Ratio))
+	}
+	for _, e := range target.Codes {
+		err = goa.MergeErrors(err, validators.ValidateCode("target.codes[*]", e))
+	}
+}
+`
+
+	NullableRequiredValidationCode = `func Validate() (err error) {
+	if !target.Name.Set {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "target"))
+	}
+	if target.Name.Valid() {
+		if utf8.RuneCountInString(target.Name.Value) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.name", target.Name.Value, utf8.RuneCountInString(target.Name.Value), 1, true))
+		}
+	}
+	if target.Age.Valid() {
+		if target.Age.Value < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", target.Age.Value, 0, true))
+		}
+	}
+}
+`
+
+	NullablePointerValidationCode = `func Validate() (err error) {
+	if !target.Name.Set {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "target"))
+	}
+	if target.Name.Valid() {
+		if utf8.RuneCountInString(target.Name.Value) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.name", target.Name.Value, utf8.RuneCountInString(target.Name.Value), 1, true))
+		}
+	}
+	if target.Age.Valid() {
+		if target.Age.Value < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", target.Age.Value, 0, true))
+		}
+	}
+}
 `
 )
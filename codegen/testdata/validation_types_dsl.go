@@ -95,5 +95,55 @@ var ValidationTypesDSL = func() {
 			})
 			Required("required_map")
 		})
+
+		_ = Type("Number", func() {
+			Attribute("required_price", Float64, func() {
+				ExclusiveMinimum(0)
+				MultipleOf(0.01)
+			})
+			Attribute("default_ratio", Float32, func() {
+				ExclusiveMaximum(1)
+				Default(0.5)
+			})
+			Attribute("quantity", Int, func() {
+				ExclusiveMaximum(100)
+				MultipleOf(5)
+			})
+			Attribute("tags", ArrayOf(String), func() {
+				UniqueItems()
+			})
+			Attribute("labels", MapOf(String, String), func() {
+				MinProperties(1)
+				MaxProperties(10)
+			})
+			Required("required_price")
+		})
+
+		_ = Type("Custom", func() {
+			Attribute("iban", String, func() {
+				Pattern("^[A-Z]{2}")
+				Validator("validators.ValidateIBAN", "example.com/validators")
+			})
+			Attribute("ratio", Float64, func() {
+				Validator("v.ValidateRatio", "example.com/validators", "v")
+			})
+			Attribute("codes", ArrayOf(String, func() {
+				Validator("validators.ValidateCode", "example.com/validators")
+			}))
+			Required("iban")
+			Validator("validators.ValidateCustom", "example.com/validators")
+		})
+
+		_ = Type("Nullable", func() {
+			Attribute("name", String, func() {
+				Nullable()
+				MinLength(1)
+			})
+			Attribute("age", Int, func() {
+				Nullable()
+				Minimum(0)
+			})
+			Required("name")
+		})
 	)
 }
@@ -111,13 +111,23 @@ func IsCompatible(a, b expr.DataType, actx, bctx string) error {
 			return fmt.Errorf("%s is a hash but %s type is %s", actx, bctx, b.Name())
 		}
 	default:
-		if a.Kind() != b.Kind() {
+		if primitiveKind(a) != primitiveKind(b) {
 			return fmt.Errorf("%s is a %s but %s type is %s", actx, a.Name(), bctx, b.Name())
 		}
 	}
 	return nil
 }
 
+// primitiveKind returns the kind of the given type. It returns the kind of the
+// underlying primitive type for primitive user types so that they are
+// compatible with the primitive type they are defined with.
+func primitiveKind(dt expr.DataType) expr.Kind {
+	if ut, ok := dt.(expr.UserType); ok && expr.IsPrimitive(ut) {
+		return primitiveKind(ut.Attribute().Type)
+	}
+	return dt.Kind()
+}
+
 // AppendHelpers takes care of only appending helper functions from newH that
 // are not already in oldH.
 func AppendHelpers(oldH, newH []*TransformFunctionData) []*TransformFunctionData {
@@ -181,7 +191,7 @@ func mapDepth(dt expr.DataType, depth int, seen ...map[string]struct{}) int {
 // IsPrimitivePointer returns true if the attribute with the given name is a
 // primitive pointer in the given parent attribute.
 func (a *AttributeContext) IsPrimitivePointer(name string, att *expr.AttributeExpr) bool {
-	if at := att.Find(name); at != nil && (at.Type == expr.Any || at.Type == expr.Bytes) {
+	if at := att.Find(name); at != nil && (at.Type == expr.Any || at.Type == expr.Bytes || at.IsNullable()) {
 		return false
 	}
 	if a.Pointer {
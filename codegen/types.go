@@ -36,31 +36,126 @@ func GoNativeTypeName(t expr.DataType) string {
 		return "[]byte"
 	case expr.AnyKind:
 		return "interface{}"
+	case expr.TimeKind:
+		return "time.Time"
+	case expr.DurationKind:
+		return "time.Duration"
+	case expr.DecimalKind:
+		return "goa.Decimal"
 	default:
 		panic(fmt.Sprintf("cannot compute native Go type for %T", t)) // bug
 	}
 }
 
-// AttributeTags computes the struct field tags from its metadata if any.
-func AttributeTags(parent, att *expr.AttributeExpr) string {
-	var elems []string
-	keys := make([]string, len(att.Meta))
-	i := 0
-	for k := range att.Meta {
-		keys[i] = k
-		i++
+// GoNullableTypeName returns the goa Nullable type corresponding to the given
+// primitive type. GoNullableTypeName panics if t is not a primitive type.
+func GoNullableTypeName(t expr.DataType) string {
+	switch t.Kind() {
+	case expr.BooleanKind:
+		return "goa.NullableBool"
+	case expr.IntKind:
+		return "goa.NullableInt"
+	case expr.Int32Kind:
+		return "goa.NullableInt32"
+	case expr.Int64Kind:
+		return "goa.NullableInt64"
+	case expr.UIntKind:
+		return "goa.NullableUInt"
+	case expr.UInt32Kind:
+		return "goa.NullableUInt32"
+	case expr.UInt64Kind:
+		return "goa.NullableUInt64"
+	case expr.Float32Kind:
+		return "goa.NullableFloat32"
+	case expr.Float64Kind:
+		return "goa.NullableFloat64"
+	case expr.StringKind:
+		return "goa.NullableString"
+	case expr.BytesKind:
+		return "goa.NullableBytes"
+	case expr.AnyKind:
+		return "goa.NullableAny"
+	case expr.TimeKind:
+		return "goa.NullableTime"
+	case expr.DurationKind:
+		return "goa.NullableDuration"
+	case expr.DecimalKind:
+		return "goa.NullableDecimal"
+	default:
+		panic(fmt.Sprintf("cannot compute nullable Go type for %T", t)) // bug
 	}
-	sort.Strings(keys)
-	for _, key := range keys {
-		val := att.Meta[key]
+}
+
+// AttributeTags computes the struct field tags from its metadata if any. The
+// tags set explicitly on the attribute via the "struct:tag:xxx" meta take
+// precedence over the tags computed from the naming strategies set on the
+// parent attribute via the "struct:naming:xxx" meta. parent may be nil in which
+// case only the explicit tags are returned.
+func AttributeTags(parent, att *expr.AttributeExpr) string {
+	tags := make(map[string]string)
+	for key, val := range att.Meta {
 		if strings.HasPrefix(key, "struct:tag:") {
-			name := key[11:]
-			value := strings.Join(val, ",")
-			elems = append(elems, fmt.Sprintf("%s:\"%s\"", name, value))
+			tags[key[11:]] = strings.Join(val, ",")
+		}
+	}
+	if parent != nil {
+		if name := fieldName(parent, att); name != "" {
+			for key, val := range parent.Meta {
+				if !strings.HasPrefix(key, "struct:naming:") || len(val) == 0 {
+					continue
+				}
+				if _, ok := tags[key[14:]]; !ok {
+					tags[key[14:]] = TagName(name, val[0])
+				}
+			}
 		}
 	}
-	if len(elems) > 0 {
-		return " `" + strings.Join(elems, " ") + "`"
+	if len(tags) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	elems := make([]string, len(keys))
+	for i, k := range keys {
+		elems[i] = fmt.Sprintf("%s:\"%s\"", k, tags[k])
+	}
+	return " `" + strings.Join(elems, " ") + "`"
+}
+
+// TagName returns the value of a struct tag computed from the given attribute
+// name using the given naming strategy. The supported strategies are
+// "snake_case", "kebab-case", "camelCase", "PascalCase" and "lowercase", any
+// other value leaves the name unchanged.
+func TagName(name, strategy string) string {
+	switch strategy {
+	case "snake_case":
+		return SnakeCase(name)
+	case "kebab-case":
+		return KebabCase(name)
+	case "camelCase":
+		return CamelCase(name, false, false)
+	case "PascalCase":
+		return CamelCase(name, true, false)
+	case "lowercase":
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// fieldName returns the name of the child attribute att of the object parent
+// or the empty string if parent is not an object or does not contain att.
+func fieldName(parent, att *expr.AttributeExpr) string {
+	obj := expr.AsObject(parent.Type)
+	if obj == nil {
+		return ""
+	}
+	for _, nat := range *obj {
+		if nat.Attribute == att {
+			return nat.Name
+		}
 	}
 	return ""
 }
@@ -148,3 +148,52 @@ func TestGoify(t *testing.T) {
 		}
 	}
 }
+
+func TestAttributeTags(t *testing.T) {
+	var (
+		plain    = &expr.AttributeExpr{Type: expr.String}
+		explicit = &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:db": {"id"}, "struct:tag:bson": {"_id", "omitempty"}}}
+		naming   = expr.MetaExpr{"struct:naming:db": {"snake_case"}, "struct:naming:yaml": {"kebab-case"}}
+		obj      = &expr.Object{{Name: "userID", Attribute: plain}, {Name: "ownerName", Attribute: explicit}}
+		parent   = &expr.AttributeExpr{Type: obj, Meta: naming}
+	)
+	cases := map[string]struct {
+		parent   *expr.AttributeExpr
+		att      *expr.AttributeExpr
+		expected string
+	}{
+		"no tag":          {nil, plain, ""},
+		"explicit":        {nil, explicit, " `bson:\"_id,omitempty\" db:\"id\"`"},
+		"naming":          {parent, plain, " `db:\"user_id\" yaml:\"user-id\"`"},
+		"naming override": {parent, explicit, " `bson:\"_id,omitempty\" db:\"id\" yaml:\"owner-name\"`"},
+		"not a child":     {parent, &expr.AttributeExpr{Type: expr.String}, ""},
+	}
+	for k, tc := range cases {
+		actual := AttributeTags(tc.parent, tc.att)
+		if actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestTagName(t *testing.T) {
+	cases := map[string]struct {
+		name     string
+		strategy string
+		expected string
+	}{
+		"snake_case":        {"userID", "snake_case", "user_id"},
+		"kebab-case":        {"userID", "kebab-case", "user-id"},
+		"camelCase":         {"user_id", "camelCase", "userId"},
+		"camelCase keyword": {"type", "camelCase", "type"},
+		"PascalCase":        {"user_id", "PascalCase", "UserId"},
+		"lowercase":         {"UserID", "lowercase", "userid"},
+		"unknown":           {"user_id", "unknown", "user_id"},
+	}
+	for k, tc := range cases {
+		actual := TagName(tc.name, tc.strategy)
+		if actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
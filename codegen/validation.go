@@ -15,11 +15,14 @@ var (
 	formatValT   *template.Template
 	patternValT  *template.Template
 	minMaxValT   *template.Template
+	multipleValT *template.Template
 	lengthValT   *template.Template
+	uniqueValT   *template.Template
 	requiredValT *template.Template
 	arrayValT    *template.Template
 	mapValT      *template.Template
 	userValT     *template.Template
+	customValT   *template.Template
 )
 
 func init() {
@@ -34,11 +37,14 @@ func init() {
 	formatValT = template.Must(template.New("format").Funcs(fm).Parse(formatValTmpl))
 	patternValT = template.Must(template.New("pattern").Funcs(fm).Parse(patternValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
+	multipleValT = template.Must(template.New("multiple").Funcs(fm).Parse(multipleValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
+	uniqueValT = template.Must(template.New("unique").Funcs(fm).Parse(uniqueValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
+	customValT = template.Must(template.New("custom").Funcs(fm).Parse(customValTmpl))
 }
 
 // ValidationCode produces Go code that runs the validations defined in the
@@ -71,17 +77,19 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 		isPointer       = attCtx.Pointer || !attCtx.IgnoreRequired && (!req && (att.DefaultValue == nil || !attCtx.UseDefault))
 		tval            = target
 	)
-	if isPointer && expr.IsPrimitive(att.Type) && !isNativePointer {
+	deref := isPointer && expr.IsPrimitive(att.Type) && !isNativePointer
+	if deref {
 		tval = "*" + tval
 	}
 	data := map[string]interface{}{
 		"attribute": att,
 		"attCtx":    attCtx,
 		"isPointer": isPointer,
+		"deref":     deref,
 		"context":   context,
 		"target":    target,
 		"targetVal": tval,
-		"string":    kind == expr.StringKind,
+		"string":    kind == expr.StringKind || kind == expr.DecimalKind,
 		"array":     expr.IsArray(att.Type),
 		"map":       expr.IsMap(att.Type),
 		"zeroVal":   att.ZeroValue,
@@ -128,6 +136,31 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if min := validation.ExclusiveMinimum; min != nil {
+		data["min"] = *min
+		data["isMin"] = true
+		data["exclusive"] = true
+		delete(data, "max")
+		if val := runTemplate(minMaxValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if max := validation.ExclusiveMaximum; max != nil {
+		data["max"] = *max
+		data["isMin"] = false
+		data["exclusive"] = true
+		delete(data, "min")
+		if val := runTemplate(minMaxValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	delete(data, "exclusive")
+	if multiple := validation.MultipleOf; multiple != nil {
+		data["multiple"] = *multiple
+		if val := runTemplate(multipleValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if minLength := validation.MinLength; minLength != nil {
 		data["minLength"] = minLength
 		data["isMinLength"] = true
@@ -144,6 +177,11 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if validation.UniqueItems {
+		if val := runTemplate(uniqueValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if req := validation.Required; len(req) > 0 {
 		obj := expr.AsObject(att.Type)
 		for _, r := range req {
@@ -151,17 +189,23 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			if reqAtt == nil {
 				continue
 			}
+			nullable := reqAtt.IsNullable()
 			if !attCtx.Pointer && expr.IsPrimitive(reqAtt.Type) &&
 				reqAtt.Type.Kind() != expr.BytesKind &&
-				reqAtt.Type.Kind() != expr.AnyKind {
+				reqAtt.Type.Kind() != expr.AnyKind && !nullable {
 
 				continue
 			}
+			data["nullable"] = nullable
 			data["req"] = r
 			data["reqAtt"] = reqAtt
 			res = append(res, runTemplate(requiredValT, data))
 		}
 	}
+	for _, v := range validation.Validators {
+		data["function"] = v.Function
+		res = append(res, runTemplate(customValT, data))
+	}
 	return strings.Join(res, "\n")
 }
 
@@ -313,7 +357,7 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 					}
 					obj := expr.AsObject(a.Type)
 					for _, name := range a.Validation.Required {
-						if att := obj.Attribute(name); att != nil && !expr.IsPrimitive(att.Type) {
+						if att := obj.Attribute(name); att != nil && (!expr.IsPrimitive(att.Type) || att.IsNullable()) {
 							hasValidations = true
 							return done
 						}
@@ -334,6 +378,24 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 			}
 			validation = buf.String()
 		}
+	} else if nat.Attribute.IsNullable() {
+		// The value of nullable attributes is held by the Value field of
+		// the goa Nullable type, validate it only if it is set and not null.
+		ctx := attCtx.Dup()
+		ctx.Pkg = attCtx.Pkg
+		ctx.Pointer = false
+		tgt := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
+		validation = recurseValidationCode(
+			nat.Attribute,
+			ctx,
+			true,
+			tgt+".Value",
+			fmt.Sprintf("%s.%s", context, nat.Name),
+			seen,
+		).String()
+		if validation != "" {
+			validation = fmt.Sprintf("if %s.Valid() {\n%s\n}", tgt, validation)
+		}
 	} else {
 		validation = recurseValidationCode(
 			nat.Attribute,
@@ -403,6 +465,25 @@ func constant(formatName string) string {
 		return "goa.FormatJSON"
 	case "rfc1123":
 		return "goa.FormatRFC1123"
+	case "time":
+		return "goa.FormatTime"
+	case "duration":
+		return "goa.FormatDuration"
+	case "uri-reference":
+		return "goa.FormatURIReference"
+	case "iri":
+		return "goa.FormatIRI"
+	case "json-pointer":
+		return "goa.FormatJSONPointer"
+	case "idn-email":
+		return "goa.FormatIDNEmail"
+	case "semver":
+		return "goa.FormatSemver"
+	case "decimal":
+		return "goa.FormatDecimal"
+	}
+	if expr.IsCustomFormat(expr.ValidationFormat(formatName)) {
+		return fmt.Sprintf("goa.Format(%q)", formatName)
 	}
 	panic("unknown format") // bug
 }
@@ -458,13 +539,25 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }}{{ if .exclusive }}={{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
+        err = goa.MergeErrors(err, goa.Invalid{{ if .exclusive }}Exclusive{{ end }}RangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
 }`
 
+	multipleValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ .zeroVal }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, goa.ValidateMultipleOf({{ printf "%q" .context }}, float64({{ .targetVal }}), {{ .multiple }}))
+{{- if or (isset .zeroVal) .isPointer }}
+}
+{{- end }}`
+
+	uniqueValTmpl = `err = goa.MergeErrors(err, goa.ValidateUniqueItems({{ printf "%q" .context }}, {{ .target }}))`
+
 	lengthValTmpl = `{{ $target := or (and (or (or .array .map) .nonzero) .target) .targetVal -}}
 {{ if and (isset .zeroVal) .string -}}
 if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
@@ -477,7 +570,17 @@ if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target
 }
 {{- end }}`
 
-	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
+	customValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
+{{ else if .deref -}}
+if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, {{ .function }}({{ printf "%q" .context }}, {{ .targetVal }}))
+{{- if or (isset .zeroVal) .deref }}
+}
+{{- end }}`
+
+	requiredValTmpl = `if {{ if .nullable }}!{{ end }}{{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }}{{ if .nullable }}.Set{{ else }} == nil{{ end }} {
         err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
 }`
 )
@@ -19,6 +19,9 @@ func TestRecursiveValidationCode(t *testing.T) {
 		arrayUT  = root.UserType("ArrayUserType")
 		arrayT   = root.UserType("Array")
 		mapT     = root.UserType("Map")
+		numberT  = root.UserType("Number")
+		customT  = root.UserType("Custom")
+		nullT    = root.UserType("Nullable")
 	)
 	cases := []struct {
 		Name       string
@@ -47,6 +50,13 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"map-required", mapT, true, false, false, testdata.MapRequiredValidationCode},
 		{"map-pointer", mapT, false, true, false, testdata.MapPointerValidationCode},
 		{"map-use-default", mapT, false, false, true, testdata.MapUseDefaultValidationCode},
+		{"number-required", numberT, true, false, false, testdata.NumberRequiredValidationCode},
+		{"number-pointer", numberT, false, true, false, testdata.NumberPointerValidationCode},
+		{"number-use-default", numberT, false, false, true, testdata.NumberUseDefaultValidationCode},
+		{"custom-required", customT, true, false, false, testdata.CustomRequiredValidationCode},
+		{"custom-pointer", customT, false, true, false, testdata.CustomPointerValidationCode},
+		{"nullable-required", nullT, true, false, false, testdata.NullableRequiredValidationCode},
+		{"nullable-pointer", nullT, false, true, false, testdata.NullablePointerValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -165,6 +165,9 @@ func Attribute(name string, args ...interface{}) {
 			// DSL did not contain an "Attribute" declaration
 			attr.Type = expr.String
 		}
+		if tname, ok := attr.Meta["type:enum"]; ok {
+			enumType(attr, tname)
+		}
 	}
 
 	parent.Type.(*expr.Object).Set(name, attr)
@@ -215,6 +218,130 @@ func Default(def interface{}) {
 	a.SetDefault(def)
 }
 
+// Nullable makes it possible to distinguish an attribute explicitly set to
+// null from an attribute that is not set. The generated Go types use one of the
+// goa Nullable types (e.g. goa.NullableString) for nullable attributes instead
+// of a pointer. This is useful to implement JSON merge patch endpoints where a
+// null value means that the corresponding field must be cleared.
+//
+// Nullable must appear in an Attribute DSL. Nullable attributes must be of a
+// primitive type, cannot have a default value and must be mapped to HTTP
+// request or response bodies (not to params or headers). Nullable attributes
+// that are not set are omitted from the JSON encoded bodies.
+//
+// Nullable takes no argument.
+//
+// Example:
+//
+//    var UpdateUser = Type("UpdateUser", func() {
+//        Attribute("name", String)
+//        Attribute("nickname", String, func() {
+//            Nullable()
+//        })
+//    })
+//
+func Nullable() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["nullable"] = []string{"true"}
+}
+
+// StructTag sets a tag on the Go struct field generated for the attribute. The
+// tag overrides the tag of the same name computed from the naming strategy set
+// with StructTagNaming on the parent type if any. StructTag is equivalent to
+// setting the "struct:tag:xxx" meta where xxx is the tag name. Only the form,
+// json and xml tags apply to the HTTP body types, see the "struct:tag:xxx"
+// meta.
+//
+// StructTag must appear in an Attribute DSL.
+//
+// StructTag takes two arguments: the name and the value of the tag.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        Attribute("id", String, func() {
+//            StructTag("db", "user_id")
+//            StructTag("bson", "_id,omitempty")
+//        })
+//    })
+//
+func StructTag(name, value string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("struct tag name cannot be empty")
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["struct:tag:"+name] = []string{value}
+}
+
+// StructTagNaming sets the naming strategy used to compute the value of the
+// given tags for all the Go struct fields generated for the attributes of the
+// type. The value of each tag is the name of the attribute converted using the
+// strategy. The supported strategies are "snake_case", "kebab-case",
+// "camelCase", "PascalCase" and "lowercase". The tags apply to the service
+// types, the viewed result types and the projected types, they do not apply to
+// the transport types. StructTagNaming is equivalent to setting the
+// "struct:naming:xxx" meta for each tag name xxx.
+//
+// StructTagNaming must appear in a Type, ResultType or Attribute DSL that
+// defines an object.
+//
+// StructTagNaming takes the naming strategy as first argument followed by the
+// names of the tags.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        StructTagNaming("snake_case", "db", "yaml", "mapstructure")
+//        Attribute("userID", String) // UserID *string `db:"user_id" mapstructure:"user_id" yaml:"user_id"`
+//        Attribute("firstName", String, func() {
+//            StructTag("db", "name") // overrides the db tag
+//        })
+//    })
+//
+func StructTagNaming(strategy string, tags ...string) {
+	var att *expr.AttributeExpr
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		att = e
+	case expr.CompositeExpr:
+		att = e.Attribute()
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	switch strategy {
+	case "snake_case", "kebab-case", "camelCase", "PascalCase", "lowercase":
+	default:
+		eval.ReportError("invalid struct tag naming strategy %q, must be one of snake_case, kebab-case, camelCase, PascalCase or lowercase", strategy)
+		return
+	}
+	if len(tags) == 0 {
+		eval.ReportError("StructTagNaming requires at least one tag name")
+		return
+	}
+	if att.Meta == nil {
+		att.Meta = expr.MetaExpr{}
+	}
+	for _, tag := range tags {
+		att.Meta["struct:naming:"+tag] = []string{strategy}
+	}
+}
+
 // Example provides an example value for a type, a parameter, a header or any
 // attribute. Example supports two syntaxes: one syntax accepts two arguments
 // where the first argument is a summary describing the example and the second a
@@ -361,3 +488,31 @@ func parseAttributeArgs(baseAttr *expr.AttributeExpr, args ...interface{}) (expr
 
 	return dataType, description, fn
 }
+
+// enumType replaces the type of the given attribute with a primitive user type
+// named after the value of the "type:enum" meta. The validations of the
+// attribute move to the user type.
+func enumType(attr *expr.AttributeExpr, tname []string) {
+	if len(tname) != 1 || tname[0] == "" {
+		eval.ReportError("type:enum meta requires the name of the type")
+		return
+	}
+	if !expr.IsPrimitive(attr.Type) {
+		eval.ReportError("type:enum meta must be used on primitive attributes")
+		return
+	}
+	if attr.Validation == nil || len(attr.Validation.Values) == 0 {
+		eval.ReportError("type:enum meta must be used on attributes with an Enum validation")
+		return
+	}
+	attr.Type = &expr.UserTypeExpr{
+		TypeName: tname[0],
+		AttributeExpr: &expr.AttributeExpr{
+			Type:        attr.Type,
+			Description: attr.Description,
+			Validation:  attr.Validation,
+		},
+	}
+	attr.Validation = nil
+	delete(attr.Meta, "type:enum")
+}
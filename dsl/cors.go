@@ -0,0 +1,190 @@
+package dsl
+
+import (
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Origin defines the CORS policy that applies to the requests made from a
+// given origin. The generated HTTP servers mount handlers that reply to the
+// CORS preflight requests and add the CORS headers to the responses of the
+// actual requests.
+//
+// Origin may appear in an API, Service or Method expression. The policies
+// defined in a method override the policies defined in the service which
+// override the policies defined in the API.
+//
+// Origin takes one or two arguments: the origin and an optional DSL defining
+// the policy. The origin is either "*" (any origin), an exact origin such as
+// "https://goa.design", an origin containing "*" wildcards such as
+// "https://*.goa.design" or a regular expression delimited by slashes such as
+// "/^https://[a-z]+\.goa\.design$/". The policy DSL may use AllowMethods,
+// AllowHeaders, ExposeHeaders, MaxAge and AllowCredentials.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Origin("https://*.goa.design", func() {
+//            AllowHeaders("Authorization", "Content-Type")
+//            ExposeHeaders("X-Request-Id")
+//            MaxAge(600)
+//            AllowCredentials()
+//        })
+//    })
+//
+//    var _ = Service("public", func() {
+//        Origin("*") // Any origin, default policy.
+//    })
+//
+func Origin(origin string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Origin")
+		return
+	}
+	if origin == "" {
+		eval.ReportError("origin cannot be empty")
+		return
+	}
+	cors := &expr.CORSExpr{Origin: origin, Parent: eval.Current()}
+	if cors.IsRegexp() {
+		if _, err := regexp.Compile(origin[1 : len(origin)-1]); err != nil {
+			eval.ReportError("invalid origin regular expression %q: %s", origin, err)
+			return
+		}
+	}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], cors) {
+			return
+		}
+	}
+	if cors.Credentials && origin == "*" {
+		eval.ReportError("AllowCredentials cannot be used with the \"*\" origin")
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.CORS = append(e.CORS, cors)
+	case *expr.ServiceExpr:
+		e.CORS = append(e.CORS, cors)
+	case *expr.MethodExpr:
+		e.CORS = append(e.CORS, cors)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// AllowMethods sets the HTTP methods allowed in the requests made from the
+// origin. The methods default to the method of the requested endpoint route.
+//
+// AllowMethods must appear in an Origin expression.
+//
+// AllowMethods takes one or more HTTP methods as argument.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowMethods("GET", "POST")
+//    })
+//
+func AllowMethods(methods ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, m := range methods {
+		cors.Methods = append(cors.Methods, strings.ToUpper(m))
+	}
+}
+
+// AllowHeaders sets the request headers allowed in the requests made from
+// the origin. "*" allows any header.
+//
+// AllowHeaders must appear in an Origin expression.
+//
+// AllowHeaders takes one or more header names as argument.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowHeaders("Authorization", "Content-Type")
+//    })
+//
+func AllowHeaders(headers ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Headers = append(cors.Headers, headers...)
+}
+
+// ExposeHeaders sets the response headers that the browsers expose to the
+// scripts running on the origin.
+//
+// ExposeHeaders must appear in an Origin expression.
+//
+// ExposeHeaders takes one or more header names as argument.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        ExposeHeaders("X-Request-Id", "X-Time")
+//    })
+//
+func ExposeHeaders(headers ...string) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.ExposedHeaders = append(cors.ExposedHeaders, headers...)
+}
+
+// MaxAge sets the number of seconds the browsers may cache the results of the
+// preflight requests made from the origin.
+//
+// MaxAge must appear in an Origin expression.
+//
+// MaxAge takes one argument: the number of seconds.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        MaxAge(600)
+//    })
+//
+func MaxAge(seconds uint) {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.MaxAge = seconds
+}
+
+// AllowCredentials allows the browsers to send credentials such as cookies or
+// authorization headers with the requests made from the origin.
+// AllowCredentials cannot be used with the "*" origin.
+//
+// AllowCredentials must appear in an Origin expression.
+//
+// AllowCredentials takes no argument.
+//
+// Example:
+//
+//    Origin("https://goa.design", func() {
+//        AllowCredentials()
+//    })
+//
+func AllowCredentials() {
+	cors, ok := eval.Current().(*expr.CORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Credentials = true
+}
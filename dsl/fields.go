@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// SparseFieldsets lets clients select the result attributes rendered by the
+// method.
+//
+// SparseFieldsets extends the method payload with the optional "fields"
+// attribute that lists the names of the result attributes to render. The
+// result is first projected using the view chosen by the server, the
+// attributes of the view that are not listed are then omitted. Required
+// attributes and the attribute that identifies the next page of paginated
+// methods are always rendered. All the attributes of the view are rendered if
+// the list is empty. The request fails with a validation error if the list
+// contains an attribute that is not rendered by the view. The values of the
+// attribute are restricted to the attributes of the view set with the method
+// Result expression if any, of any of the result type views otherwise. The
+// method result must be a result type. User types that are also used by other
+// methods are never extended, they must define the "fields" attribute
+// explicitly.
+//
+// The HTTP transport maps the "fields" attribute to a query string parameter
+// unless it is explicitly mapped, the values may be given as repeated
+// parameters or as a comma separated list. The gRPC transport maps the attribute to a
+// google.protobuf.FieldMask message field.
+//
+// SparseFieldsets must appear in a Method expression.
+//
+// SparseFieldsets takes no argument.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Result(Account)
+//        SparseFieldsets()
+//        HTTP(func() {
+//            GET("/accounts/{id}") // e.g. GET /accounts/1?fields=name,href
+//        })
+//    })
+//
+func SparseFieldsets() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.FieldSelection = &expr.FieldSelectionExpr{Method: m}
+}
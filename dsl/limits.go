@@ -0,0 +1,97 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// RequestBodyLimit sets the maximum size in bytes of the request bodies
+// accepted by the methods. The HTTP servers reply with a 413 Request Entity
+// Too Large status code if a request body exceeds the limit, the gRPC servers
+// reply with a ResourceExhausted status code if the size of the request
+// message exceeds the limit. The limit does not apply to streaming methods.
+// gRPC messages are checked once decoded, the grpc.MaxRecvMsgSize server
+// option rejects messages before they are decoded but applies to all the
+// methods of the server. The generated example gRPC server sets it to the
+// largest limit.
+//
+// RequestBodyLimit may appear in an API, Service or Method expression. The
+// value set in a method overrides the value set in the service which
+// overrides the value set in the API.
+//
+// RequestBodyLimit takes one argument: the maximum size in bytes.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        RequestBodyLimit(1 << 20) // 1MB default
+//    })
+//
+//    var _ = Service("files", func() {
+//        Method("upload", func() {
+//            RequestBodyLimit(32 << 20) // 32MB for this method only
+//            Payload(Bytes)
+//        })
+//    })
+//
+func RequestBodyLimit(n int64) {
+	if n <= 0 {
+		eval.ReportError("request body limit must be greater than 0, got %d", n)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.RequestBodyLimit = n
+	case *expr.ServiceExpr:
+		e.RequestBodyLimit = n
+	case *expr.MethodExpr:
+		e.RequestBodyLimit = n
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// RequestTimeout sets the maximum duration of the requests handled by the
+// methods. The generated servers set a deadline on the request context before
+// invoking the method endpoint. The request fails with a "deadline_exceeded"
+// error if the endpoint returns an error after the deadline expired. The HTTP
+// servers reply with a 504 Gateway Timeout status code, the gRPC servers
+// reply with a DeadlineExceeded status code. The timeout does not apply to
+// streaming methods.
+//
+// RequestTimeout may appear in an API, Service or Method expression. The
+// value set in a method overrides the value set in the service which
+// overrides the value set in the API.
+//
+// RequestTimeout takes one argument: the maximum duration.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        RequestTimeout(30 * time.Second)
+//    })
+//
+//    var _ = Service("reports", func() {
+//        Method("generate", func() {
+//            RequestTimeout(5 * time.Minute)
+//        })
+//    })
+//
+func RequestTimeout(d time.Duration) {
+	if d <= 0 {
+		eval.ReportError("request timeout must be greater than 0, got %s", d)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.RequestTimeout = d
+	case *expr.ServiceExpr:
+		e.RequestTimeout = d
+	case *expr.MethodExpr:
+		e.RequestTimeout = d
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -30,6 +30,67 @@ import (
 //        Meta("type:generate:force", service1, service2)
 //    })
 //
+// - "type:generate:helpers" generates the Equal, DeepCopy and Diff methods for
+// the type it is defined on as well as for all the user types it references
+// and for the collections of the type if it is a result type. Equal returns
+// true if two values hold the same data, DeepCopy returns a copy that shares
+// no memory with the original value (values of type Any are copied shallowly)
+// and Diff returns the list of fields whose values differ as a slice of
+// goa.FieldChange. The methods are also generated for the corresponding
+// viewed result and projected types in the views package.
+//
+//    var Account = Type("Account", func() {
+//        Attribute("name", String)
+//        Attribute("owner", User)
+//        Meta("type:generate:helpers")
+//    })
+//
+// - "type:generate:builder" generates a New<Type> constructor and With<Field>
+// setter methods for the object type it is defined on. The constructor
+// accepts the values of the required attributes in order and initializes the
+// attributes that define a default value with that value. Each attribute that
+// is not required gets a setter method that returns the receiver so that calls
+// can be chained. The meta cannot be used on method result types as the
+// service package already defines constructors for these. Attributes of
+// object types (or arrays and maps of objects) cannot define a default value.
+//
+//    var CreateAccount = Type("CreateAccount", func() {
+//        Attribute("name", String)
+//        Attribute("plan", String, func() {
+//            Default("free")
+//        })
+//        Attribute("referrer", String)
+//        Required("name")
+//        Meta("type:generate:builder")
+//    })
+//
+// makes it possible to write:
+//
+//    p := NewCreateAccount("acme").WithReferrer("bob")
+//
+// - "type:enum" generates a Go named type for the attribute it is defined on.
+// The attribute must be a primitive with an Enum validation. The value is the
+// name of the generated type. The service package defines the type together
+// with a constant for each enum value and the Valid and String methods. This
+// is equivalent to defining a primitive user type with an Enum validation.
+//
+//    var Shape = Type("Shape", func() {
+//        Attribute("color", String, func() {
+//            Enum("red", "green", "blue")
+//            Meta("type:enum", "Color")
+//        })
+//    })
+//
+// generates:
+//
+//    type Color string
+//
+//    const (
+//        ColorRed   Color = "red"
+//        ColorGreen Color = "green"
+//        ColorBlue  Color = "blue"
+//    )
+//
 // - "struct:error:name" identifies the attribute of a result type used to
 // select the returned error when multiple errors are defined on the same
 // method. The value of the field corresponding to the attribute with the
@@ -83,7 +144,9 @@ import (
 //
 // - "struct:tag:xxx" sets a generated Go struct field tag and overrides tags
 // that goa would otherwise set. If the metadata value is a slice then the
-// strings are joined with the space character as separator. Applicable to
+// strings are joined with the space character as separator. The HTTP body
+// types only use the form, json and xml tags, setting any of these replaces
+// the tags goa would otherwise set for the body field. Applicable to
 // attributes only.
 //
 //    var MyType = Type("MyType", func() {
@@ -93,6 +156,15 @@ import (
 //        })
 //    })
 //
+// - "struct:naming:xxx" sets the naming strategy used to compute the value of
+// the xxx struct tag of the fields generated for the attributes of the type.
+// See StructTagNaming. Applicable to types and object attributes.
+//
+//    var MyType = Type("MyType", func() {
+//        Meta("struct:naming:db", "snake_case")
+//        Attribute("userID", String) // UserID *string `db:"user_id"`
+//    })
+//
 // - "swagger:generate" specifies whether Swagger specification should be
 // generated. Defaults to true. Applicable to services, methods and file
 // servers.
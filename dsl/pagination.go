@@ -0,0 +1,68 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// CursorPagination identifies pages with opaque cursors returned by the
+	// server.
+	CursorPagination = expr.CursorPaginationKind
+	// PagePagination identifies pages with their number starting at 1.
+	PagePagination = expr.PagePaginationKind
+)
+
+// Paginated indicates that the method returns its results one page at a time.
+//
+// With CursorPagination the method payload is extended with the optional
+// "cursor" (String) and "limit" (Int) attributes and the result with the
+// optional "next_cursor" (String) attribute. With PagePagination the payload is
+// extended with the optional "page" (Int) and "limit" (Int) attributes and the
+// result with the optional "next_page" (Int) attribute. Attributes that are
+// already defined by the payload or result are left untouched, they must have
+// the types listed above and may not be required or have a default value. The
+// payload and result must be objects (the payload may be omitted). User types
+// that are also used by other methods are never extended, they must define
+// the pagination attributes explicitly.
+//
+// The HTTP transport maps the payload pagination attributes to query string
+// parameters unless they are explicitly mapped and sets the "Link" response
+// header with the URL of the next page. The gRPC transport maps the attributes
+// to message fields. The generated service client exposes a method named after
+// the method with the "Pages" suffix that returns an iterator over all the
+// pages. The iterator stops once the result does not set the next page or sets
+// an empty next cursor.
+//
+// Paginated must appear in a Method expression.
+//
+// Paginated takes one argument: the kind of pagination.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("filter", String)
+//        })
+//        Result(func() {
+//            Attribute("items", ArrayOf(Item))
+//        })
+//        Paginated(CursorPagination)
+//        HTTP(func() {
+//            GET("/items")
+//            Param("filter")
+//        })
+//    })
+//
+func Paginated(kind expr.PaginationKind) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if kind != CursorPagination && kind != PagePagination {
+		eval.ReportError("invalid pagination kind %d, use CursorPagination or PagePagination", kind)
+		return
+	}
+	m.Pagination = &expr.PaginationExpr{Method: m, Kind: kind}
+}
@@ -179,13 +179,62 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// MTLSSecurity defines a mutual TLS security scheme where clients authenticate
+// using certificates. The generated HTTP and gRPC servers retrieve the verified
+// client certificate from the TLS connection and pass it to the scheme
+// authorization function. This scheme supports defining scopes that endpoint
+// may require to authorize the request.
+//
+// The servers must be configured to request and verify client certificates
+// (e.g. using tls.RequireAndVerifyClientCert) for the certificate to be
+// available. The generated OpenAPI 2.0 specification does not list mutual TLS
+// schemes as it cannot describe them.
+//
+// MTLSSecurity is a top level DSL.
+//
+// MTLSSecurity takes a name as first argument and an optional DSL as second
+// argument.
+//
+// Example:
+//
+//    var MTLS = MTLSSecurity("mtls", func() {
+//        Description("Client certificate issued by the internal CA")
+//        Scope("system:read", "Read anything in there")
+//    })
+//
+func MTLSSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.MTLSKind,
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
 // Security defines authentication requirements to access a service or a service
 // method.
 //
 // The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
-// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
-// OAuth2Security or JWTSecurity scheme then required scopes may be listed by
-// name in the Security DSL. All the listed schemes must be validated by the
+// APIKeySecurity, JWTSecurity or MTLSSecurity security scheme. If the schemes
+// include a OAuth2Security, JWTSecurity or MTLSSecurity scheme then required
+// scopes may be listed by name in the Security DSL. All the listed schemes must be validated by the
 // client for the request to be authorized. Security may appear multiple times
 // in the same scope in which case the client may validate any one of the
 // requirements for the request to be authorized.
@@ -306,6 +355,106 @@ func NoSecurity() {
 	}
 }
 
+// Authorize defines an authorization requirement for a service or a service
+// method. Authorization requirements are checked by the generated endpoint
+// code once the request has been authenticated and before the service method
+// is invoked. The requirement may list roles and scopes that the caller must
+// have as well as payload attributes whose values are given to the
+// authorizer, e.g. to check that the caller owns the resource identified by
+// the attribute. Authorize may appear multiple times in the same scope in
+// which case the request is authorized if it satisfies any one of the
+// requirements.
+//
+// The generated code calls the Authorize method of the service Auther
+// interface with the values of the attributes listed in the requirements.
+//
+// Authorize must appear in a Service or Method expression.
+//
+// Authorize accepts a DSL function as argument.
+//
+// Example:
+//
+//    Method("update", func() {
+//        Security(JWT)
+//
+//        // Authorize callers with the "admin" role.
+//        Authorize(func() {
+//            Role("admin")
+//        })
+//
+//        // Or callers with the "api:write" scope that own the account.
+//        Authorize(func() {
+//            Scope("api:write")
+//            Resource("account_id")
+//        })
+//
+//        Payload(func() {
+//            Token("token", String)
+//            Attribute("account_id", String)
+//        })
+//    })
+//
+func Authorize(fn func()) {
+	authz := &expr.AuthorizationExpr{}
+	if !eval.Execute(fn, authz) {
+		return
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		actual.Authorizations = append(actual.Authorizations, authz)
+	case *expr.ServiceExpr:
+		actual.Authorizations = append(actual.Authorizations, authz)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Role lists roles that the caller must have to satisfy the authorization
+// requirement.
+//
+// Role must appear in Authorize.
+//
+// Role accepts one or more role names as argument.
+//
+// Example:
+//
+//    Authorize(func() {
+//        Role("admin", "auditor") // Caller must have both roles
+//    })
+//
+func Role(names ...string) {
+	authz, ok := eval.Current().(*expr.AuthorizationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	authz.Roles = append(authz.Roles, names...)
+}
+
+// Resource lists the payload attributes whose values are given to the
+// authorizer. The authorizer uses these values to decide whether the caller
+// may access the corresponding resource, e.g. whether the caller owns the
+// resource identified by the attribute.
+//
+// Resource must appear in Authorize.
+//
+// Resource accepts one or more payload attribute names as argument.
+//
+// Example:
+//
+//    Authorize(func() {
+//        Resource("account_id")
+//    })
+//
+func Resource(names ...string) {
+	authz, ok := eval.Current().(*expr.AuthorizationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	authz.Attributes = append(authz.Attributes, names...)
+}
+
 // Username defines the attribute used to provide the username to an endpoint
 // secured with basic authentication. The parameters and usage of Username are
 // the same as the goa DSL Attribute function.
@@ -517,9 +666,10 @@ func TokenField(tag interface{}, name string, args ...interface{}) {
 }
 
 // Scope has two uses: in JWTSecurity or OAuth2Security it defines a scope
-// supported by the scheme. In Security it lists required scopes.
+// supported by the scheme. In Security or Authorize it lists required scopes.
 //
-// Scope must appear in Security, BasicSecurity, APIKeySecurity, JWTSecurity or OAuth2Security.
+// Scope must appear in Security, Authorize, BasicSecurity, APIKeySecurity,
+// JWTSecurity, MTLSSecurity or OAuth2Security.
 //
 // Scope accepts one or two arguments: the first argument is the scope name and
 // when used in JWTSecurity or OAuth2Security the second argument is a
@@ -546,6 +696,12 @@ func Scope(name string, desc ...string) {
 			return
 		}
 		current.Scopes = append(current.Scopes, name)
+	case *expr.AuthorizationExpr:
+		if len(desc) >= 1 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		current.Scopes = append(current.Scopes, name)
 	case *expr.SchemeExpr:
 		if len(desc) > 1 {
 			eval.ReportError("too many arguments")
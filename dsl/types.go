@@ -38,6 +38,19 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = expr.Any
+
+	// Time is the type for a point in time (time.Time in Go). Time values
+	// are encoded as RFC3339 strings.
+	Time = expr.Time
+
+	// Duration is the type for an elapsed time (time.Duration in Go). Duration
+	// values are encoded as integer numbers of nanoseconds except in gRPC
+	// messages where they use google.protobuf.Duration.
+	Duration = expr.Duration
+
+	// Decimal is the type for an arbitrary-precision decimal number
+	// (goa.Decimal in Go). Decimal values are encoded as strings.
+	Decimal = expr.Decimal
 )
 
 // Empty represents empty values.
@@ -1,9 +1,11 @@
 package dsl
 
 import (
+	"go/token"
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -51,6 +53,30 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = expr.FormatRFC1123
+
+	// FormatTime describes RFC3339 partial time values.
+	FormatTime = expr.FormatTime
+
+	// FormatDuration describes ISO8601 duration values.
+	FormatDuration = expr.FormatDuration
+
+	// FormatURIReference describes RFC3986 URI reference values.
+	FormatURIReference = expr.FormatURIReference
+
+	// FormatIRI describes RFC3987 IRI values.
+	FormatIRI = expr.FormatIRI
+
+	// FormatJSONPointer describes RFC6901 JSON pointer values.
+	FormatJSONPointer = expr.FormatJSONPointer
+
+	// FormatIDNEmail describes RFC6531 internationalized email addresses.
+	FormatIDNEmail = expr.FormatIDNEmail
+
+	// FormatSemver describes semantic version 2.0.0 values.
+	FormatSemver = expr.FormatSemver
+
+	// FormatDecimal describes arbitrary-precision decimal number literals.
+	FormatDecimal = expr.FormatDecimal
 )
 
 // Enum adds a "enum" validation to the attribute.
@@ -142,6 +168,24 @@ func Enum(vals ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// FormatTime: RFC3339 partial time
+//
+// FormatDuration: ISO8601 duration
+//
+// FormatURIReference: RFC3986 URI reference
+//
+// FormatIRI: RFC3987 IRI
+//
+// FormatJSONPointer: RFC6901 JSON pointer
+//
+// FormatIDNEmail: RFC6531 internationalized email address
+//
+// FormatSemver: semantic version 2.0.0
+//
+// FormatDecimal: arbitrary-precision decimal number
+//
+// Additional formats may be defined with RegisterFormat.
+//
 // Example:
 //
 //    Attribute("created_at", String, func() {
@@ -163,6 +207,43 @@ func Format(f expr.ValidationFormat) {
 	}
 }
 
+// RegisterFormat defines a custom string format that may be used with
+// Format. example generates example values that conform to the format, if nil
+// examples are generated from the other validations of the attribute.
+//
+// The generated code validates custom formats with goa.ValidateFormat which
+// calls the validator registered for the format with the goa package
+// RegisterFormat function. The validator must be registered by the service
+// implementation before any request is handled, for example in an init
+// function.
+//
+// RegisterFormat must be called before the design DSL runs, typically to
+// initialize a package variable.
+//
+// Example:
+//
+//    var FormatSKU = RegisterFormat("sku", func(r *expr.Random) string {
+//        return fmt.Sprintf("SKU-%06d", r.Int()%1000000)
+//    })
+//
+//    var Product = Type("Product", func() {
+//        Attribute("sku", String, func() {
+//            Format(FormatSKU)
+//        })
+//    })
+//
+// and in the service implementation:
+//
+//    func init() {
+//        goa.RegisterFormat("sku", validateSKU)
+//    }
+//
+func RegisterFormat(name string, example func(*expr.Random) string) expr.ValidationFormat {
+	f := expr.ValidationFormat(name)
+	expr.RegisterFormat(f, example)
+	return f
+}
+
 // Pattern adds a "pattern" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor33.
 //
@@ -201,32 +282,7 @@ func Pattern(p string) {
 //
 func Minimum(val interface{}) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
-		if a.Type != nil &&
-			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
-			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
-			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
-
-			incompatibleAttributeType("minimum", a.Type.Name(), "an integer or a number")
-		} else {
-			var f float64
-			switch v := val.(type) {
-			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
-				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
-			case string:
-				var err error
-				f, err = strconv.ParseFloat(v, 64)
-				if err != nil {
-					eval.ReportError("invalid number value %#v", v)
-					return
-				}
-			default:
-				eval.ReportError("invalid number value %#v", v)
-				return
-			}
-			if a.Validation == nil {
-				a.Validation = &expr.ValidationExpr{}
-			}
+		if f, ok := numberValidation(a, "minimum", val); ok {
 			a.Validation.Minimum = &f
 		}
 	}
@@ -243,33 +299,66 @@ func Minimum(val interface{}) {
 //
 func Maximum(val interface{}) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
-		if a.Type != nil &&
-			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
-			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
-			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+		if f, ok := numberValidation(a, "maximum", val); ok {
+			a.Validation.Maximum = &f
+		}
+	}
+}
 
-			incompatibleAttributeType("maximum", a.Type.Name(), "an integer or a number")
-		} else {
-			var f float64
-			switch v := val.(type) {
-			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
-				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
-			case string:
-				var err error
-				f, err = strconv.ParseFloat(v, 64)
-				if err != nil {
-					eval.ReportError("invalid number value %#v", v)
-					return
-				}
-			default:
-				eval.ReportError("invalid number value %#v", v)
+// ExclusiveMinimum adds an "exclusiveMinimum" validation to the attribute.
+// The attribute value must be strictly greater than the given value.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.5.
+//
+// Example:
+//
+//    Attribute("price", Float64, func() {
+//        ExclusiveMinimum(0)
+//    })
+//
+func ExclusiveMinimum(val interface{}) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if f, ok := numberValidation(a, "exclusive minimum", val); ok {
+			a.Validation.ExclusiveMinimum = &f
+		}
+	}
+}
+
+// ExclusiveMaximum adds an "exclusiveMaximum" validation to the attribute.
+// The attribute value must be strictly lesser than the given value.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.3.
+//
+// Example:
+//
+//    Attribute("ratio", Float64, func() {
+//        ExclusiveMaximum(1)
+//    })
+//
+func ExclusiveMaximum(val interface{}) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if f, ok := numberValidation(a, "exclusive maximum", val); ok {
+			a.Validation.ExclusiveMaximum = &f
+		}
+	}
+}
+
+// MultipleOf adds a "multipleOf" validation to the attribute. The attribute
+// value must be a multiple of the given strictly positive value.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.1.
+//
+// Example:
+//
+//    Attribute("amount", Float64, func() {
+//        MultipleOf(0.01)
+//    })
+//
+func MultipleOf(val interface{}) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if f, ok := numberValidation(a, "multiple of", val); ok {
+			if f <= 0 {
+				eval.ReportError("invalid multiple of validation definition: value must be strictly positive (but value is %v)", f)
 				return
 			}
-			if a.Validation == nil {
-				a.Validation = &expr.ValidationExpr{}
-			}
-			a.Validation.Maximum = &f
+			a.Validation.MultipleOf = &f
 		}
 	}
 }
@@ -341,6 +430,154 @@ func MaxLength(val int) {
 	}
 }
 
+// MinProperties adds a "minProperties" validation to the attribute. The
+// attribute must be a map and MinProperties sets the minimum number of
+// key-values it may contain. MinProperties is equivalent to MinLength for map
+// attributes.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.5.2.
+//
+// Example:
+//
+//    Attribute("labels", MapOf(String, String), func() {
+//        MinProperties(1)
+//    })
+//
+func MinProperties(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.MapKind {
+			incompatibleAttributeType("minimum properties", a.Type.Name(), "a map")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.MinLength = &val
+	}
+}
+
+// MaxProperties adds a "maxProperties" validation to the attribute. The
+// attribute must be a map and MaxProperties sets the maximum number of
+// key-values it may contain. MaxProperties is equivalent to MaxLength for map
+// attributes.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.5.1.
+//
+// Example:
+//
+//    Attribute("labels", MapOf(String, String), func() {
+//        MaxProperties(20)
+//    })
+//
+func MaxProperties(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.MapKind {
+			incompatibleAttributeType("maximum properties", a.Type.Name(), "a map")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.MaxLength = &val
+	}
+}
+
+// UniqueItems adds a "uniqueItems" validation to the attribute. The attribute
+// must be an array and may not contain duplicate elements.
+// See https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.4.3.
+//
+// Example:
+//
+//    Attribute("tags", ArrayOf(String), func() {
+//        UniqueItems()
+//    })
+//
+func UniqueItems() {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("unique items", a.Type.Name(), "an array")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.UniqueItems = true
+	}
+}
+
+// Validator adds a user defined validation function to the attribute or type.
+// The generated validation code calls the function and merges the error it
+// returns if any. Validator makes it
+// possible to express rules that cannot be described with the other
+// validation DSL functions such as cross-field checks or checksums.
+//
+// Validator must appear in an Attribute, Type, ResultType or Field
+// expression.
+//
+// Validator accepts one to three arguments. The first argument is the
+// qualified name of the Go function of the form "pkg.Func". The second argument is the import path
+// of the package that defines the function if any. The third argument
+// overrides the name used to import the package. The arguments follow the
+// same conventions as the "struct:field:type" metadata.
+//
+// The function must have the signature:
+//
+//    func(name string, v T) error
+//
+// where name is the name of the validated value used in error messages (e.g.
+// "body.iban") and T the Go type of the value. The value is dereferenced if
+// it is a pointer to a primitive type. Object values are passed as pointers
+// to the generated structs which are different for the service and transport
+// packages so T must be interface{} in this case.
+//
+// Example:
+//
+//    var Account = Type("Account", func() {
+//        Attribute("iban", String, func() {
+//            Validator("validators.ValidateIBAN", "github.com/acme/validators")
+//        })
+//    })
+//
+//    var Period = Type("Period", func() {
+//        Attribute("start_date", String, func() {
+//            Format(FormatDate)
+//        })
+//        Attribute("end_date", String, func() {
+//            Format(FormatDate)
+//        })
+//        Validator("validators.ValidatePeriod", "github.com/acme/validators")
+//    })
+//
+func Validator(fn string, args ...string) {
+	if parts := strings.Split(fn, "."); len(parts) != 2 || !token.IsIdentifier(parts[0]) || !token.IsIdentifier(parts[1]) {
+		eval.ReportError("invalid validator function name %q, must be of the form pkg.Func", fn)
+		return
+	}
+	if len(args) > 2 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	var at *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	v := &expr.CustomValidatorExpr{Function: fn}
+	if len(args) > 0 {
+		v.ImportPath = args[0]
+	}
+	if len(args) > 1 {
+		v.ImportName = args[1]
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	at.Validation.Validators = append(at.Validation.Validators, v)
+}
+
 // Required adds a "required" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor61.
 //
@@ -377,6 +614,41 @@ func Required(names ...string) {
 	}
 }
 
+// numberValidation checks that the attribute is a number and returns the
+// float64 value of val. It initializes the attribute validation if needed. It
+// reports an error and returns false if the attribute is not a number or val
+// is not a valid number.
+func numberValidation(a *expr.AttributeExpr, validation string, val interface{}) (float64, bool) {
+	if a.Type != nil &&
+		a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
+		a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
+		a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
+		a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+
+		incompatibleAttributeType(validation, a.Type.Name(), "an integer or a number")
+		return 0, false
+	}
+	var f float64
+	switch v := val.(type) {
+	case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
+	case string:
+		var err error
+		f, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			eval.ReportError("invalid number value %#v", v)
+			return 0, false
+		}
+	default:
+		eval.ReportError("invalid number value %#v", v)
+		return 0, false
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	return f, true
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
@@ -44,3 +44,41 @@ func TestFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestValidator(t *testing.T) {
+	cases := map[string]struct {
+		Function string
+		Error    bool
+	}{
+		"qualified":   {"validators.ValidateIBAN", false},
+		"empty":       {"", true},
+		"unqualified": {"ValidateIBAN", true},
+		"nested":      {"acme.validators.ValidateIBAN", true},
+		"no-package":  {".ValidateIBAN", true},
+		"no-function": {"validators.", true},
+		"invalid":     {"validators.Validate-IBAN", true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		expr := &expr.AttributeExpr{}
+		eval.Execute(func() { Validator(tc.Function) }, expr)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: Validator did not fail", k)
+			}
+			if expr.Validation != nil {
+				t.Errorf("%s: Validator initialized Validation in %+v", k, expr)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: Validator failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+		if expr.Validation == nil || len(expr.Validation.Validators) != 1 {
+			t.Errorf("%s: Validator not set on %+v", k, expr)
+		} else if f := expr.Validation.Validators[0].Function; f != tc.Function {
+			t.Errorf("%s: got function %q, expected %q", k, f, tc.Function)
+		}
+	}
+}
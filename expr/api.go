@@ -2,6 +2,7 @@ package expr
 
 import (
 	"sort"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -36,6 +37,15 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// RequestBodyLimit is the default maximum size in bytes of the
+		// request bodies of the API methods, zero means no limit.
+		RequestBodyLimit int64
+		// RequestTimeout is the default maximum duration of the requests
+		// handled by the API methods, zero means no timeout.
+		RequestTimeout time.Duration
+		// CORS lists the default CORS policies of the API methods, one
+		// per allowed origin.
+		CORS []*CORSExpr
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
@@ -76,6 +76,18 @@ type (
 		// Maximum represents a maximum value validation as described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor17.
 		Maximum *float64
+		// ExclusiveMinimum represents an exclusive minimum value
+		// validation as described at
+		// https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.5.
+		ExclusiveMinimum *float64
+		// ExclusiveMaximum represents an exclusive maximum value
+		// validation as described at
+		// https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.3.
+		ExclusiveMaximum *float64
+		// MultipleOf represents a "multipleOf" validation as described
+		// at
+		// https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.2.1.
+		MultipleOf *float64
 		// MinLength represents an minimum length validation as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor29.
@@ -84,10 +96,31 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor26.
 		MaxLength *int
+		// UniqueItems represents a "uniqueItems" validation as
+		// described at
+		// https://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.4.3.
+		UniqueItems bool
 		// Required list the required fields of object attributes as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// Validators lists the user defined validation functions
+		// called by the generated validation code.
+		Validators []*CustomValidatorExpr
+	}
+
+	// CustomValidatorExpr describes a user defined Go function that
+	// validates the values of an attribute.
+	CustomValidatorExpr struct {
+		// Function is the qualified name of the Go function, e.g.
+		// "validators.ValidateIBAN".
+		Function string
+		// ImportPath is the import path of the package that defines the
+		// function if any.
+		ImportPath string
+		// ImportName is the name used to import the package if different
+		// from the default.
+		ImportName string
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -137,8 +170,36 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatTime describes RFC3339 partial time values.
+	FormatTime = "time"
+
+	// FormatDuration describes ISO8601 duration values.
+	FormatDuration = "duration"
+
+	// FormatURIReference describes RFC3986 URI reference values.
+	FormatURIReference = "uri-reference"
+
+	// FormatIRI describes RFC3987 IRI values.
+	FormatIRI = "iri"
+
+	// FormatJSONPointer describes RFC6901 JSON pointer values.
+	FormatJSONPointer = "json-pointer"
+
+	// FormatIDNEmail describes RFC6531 internationalized email addresses.
+	FormatIDNEmail = "idn-email"
+
+	// FormatSemver describes semantic version 2.0.0 values.
+	FormatSemver = "semver"
+
+	// FormatDecimal describes arbitrary-precision decimal number literals.
+	FormatDecimal = "decimal"
 )
 
+// customFormats records the example generators of the formats registered
+// with RegisterFormat indexed by format name.
+var customFormats = make(map[ValidationFormat]func(*Random) string)
+
 // EvalName returns the name used by the DSL evaluation.
 func (a *AttributeExpr) EvalName() string {
 	return "attribute"
@@ -196,6 +257,25 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		ctx += " - "
 	}
 	verr.Merge(a.validateEnumDefault(ctx, parent))
+	switch a.Type.Kind() {
+	case TimeKind, DurationKind, DecimalKind:
+		// The generated code renders default values and enum values as
+		// Go literals which cannot represent these types.
+		if a.DefaultValue != nil {
+			verr.Add(parent, "%sdefault values are not supported for %s attributes", ctx, a.Type.Name())
+		}
+		if v := a.Validation; v != nil && (len(v.Values) > 0 || v.Format != "" || v.Pattern != "" || v.MinLength != nil || v.MaxLength != nil) {
+			verr.Add(parent, "%senum, format, pattern and length validations are not supported for %s attributes", ctx, a.Type.Name())
+		}
+	}
+	if a.IsNullable() {
+		if _, ok := a.Type.(Primitive); !ok {
+			verr.Add(parent, "%snullable attributes must be of a primitive type", ctx)
+		}
+		if a.DefaultValue != nil {
+			verr.Add(parent, "%snullable attributes cannot have a default value", ctx)
+		}
+	}
 	if o := AsObject(a.Type); o != nil {
 		for _, n := range a.AllRequired() {
 			if a.Find(n) == nil {
@@ -366,12 +446,23 @@ func (a *AttributeExpr) IsPrimitivePointer(attName string, useDefault bool) bool
 		return false
 	}
 	if IsPrimitive(att.Type) {
-		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind &&
+		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind && !att.IsNullable() &&
 			!a.IsRequired(attName) && (!a.HasDefaultValue(attName) || !useDefault)
 	}
 	return false
 }
 
+// IsNullable returns true if the attribute is defined with the Nullable DSL,
+// i.e. if the generated code distinguishes the attribute being set to null from
+// the attribute not being set.
+func (a *AttributeExpr) IsNullable() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta["nullable"]
+	return ok
+}
+
 // HasTag returns true if the attribute is an object that has an attribute with
 // the given tag.
 func (a *AttributeExpr) HasTag(tag string) bool {
@@ -622,13 +713,35 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.Maximum == nil || (other.Maximum != nil && *v.Maximum < *other.Maximum) {
 		v.Maximum = other.Maximum
 	}
+	if v.ExclusiveMinimum == nil || (other.ExclusiveMinimum != nil && *v.ExclusiveMinimum > *other.ExclusiveMinimum) {
+		v.ExclusiveMinimum = other.ExclusiveMinimum
+	}
+	if v.ExclusiveMaximum == nil || (other.ExclusiveMaximum != nil && *v.ExclusiveMaximum < *other.ExclusiveMaximum) {
+		v.ExclusiveMaximum = other.ExclusiveMaximum
+	}
+	if v.MultipleOf == nil {
+		v.MultipleOf = other.MultipleOf
+	}
 	if v.MinLength == nil || (other.MinLength != nil && *v.MinLength > *other.MinLength) {
 		v.MinLength = other.MinLength
 	}
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	v.UniqueItems = v.UniqueItems || other.UniqueItems
 	v.AddRequired(other.Required...)
+	for _, ov := range other.Validators {
+		found := false
+		for _, vv := range v.Validators {
+			if *vv == *ov {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Validators = append(v.Validators, ov)
+		}
+	}
 }
 
 // AddRequired merges the required fields into v.
@@ -669,7 +782,10 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 	if (v.Minimum != nil) || (v.Maximum != nil) || (v.MinLength != nil) || (v.MaxLength != nil) {
 		return false
 	}
-	return true
+	if (v.ExclusiveMinimum != nil) || (v.ExclusiveMaximum != nil) || (v.MultipleOf != nil) || v.UniqueItems {
+		return false
+	}
+	return len(v.Validators) == 0
 }
 
 // Dup makes a shallow dup of the validation.
@@ -679,15 +795,25 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		req = make([]string, len(v.Required))
 		copy(req, v.Required)
 	}
+	var vals []*CustomValidatorExpr
+	if len(v.Validators) > 0 {
+		vals = make([]*CustomValidatorExpr, len(v.Validators))
+		copy(vals, v.Validators)
+	}
 	return &ValidationExpr{
-		Values:    v.Values,
-		Format:    v.Format,
-		Pattern:   v.Pattern,
-		Minimum:   v.Minimum,
-		Maximum:   v.Maximum,
-		MinLength: v.MinLength,
-		MaxLength: v.MaxLength,
-		Required:  req,
+		Values:           v.Values,
+		Format:           v.Format,
+		Pattern:          v.Pattern,
+		Minimum:          v.Minimum,
+		Maximum:          v.Maximum,
+		ExclusiveMinimum: v.ExclusiveMinimum,
+		ExclusiveMaximum: v.ExclusiveMaximum,
+		MultipleOf:       v.MultipleOf,
+		MinLength:        v.MinLength,
+		MaxLength:        v.MaxLength,
+		UniqueItems:      v.UniqueItems,
+		Required:         req,
+		Validators:       vals,
 	}
 }
 
@@ -722,8 +848,40 @@ func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 		return true
 	case FormatRFC1123:
 		return true
+	case FormatTime:
+		return true
+	case FormatDuration:
+		return true
+	case FormatURIReference:
+		return true
+	case FormatIRI:
+		return true
+	case FormatJSONPointer:
+		return true
+	case FormatIDNEmail:
+		return true
+	case FormatSemver:
+		return true
+	case FormatDecimal:
+		return true
 	}
-	return false
+	_, ok := customFormats[vf]
+	return ok
+}
+
+// RegisterFormat registers a custom validation format. example generates
+// example values that conform to the format, it may be nil in which case
+// examples are generated from the other validations of the attribute. The
+// generated code validates the format at runtime using the validator
+// registered with the goa package RegisterFormat function.
+func RegisterFormat(name ValidationFormat, example func(*Random) string) {
+	customFormats[name] = example
+}
+
+// IsCustomFormat returns true if vf was registered with RegisterFormat.
+func IsCustomFormat(vf ValidationFormat) bool {
+	_, ok := customFormats[vf]
+	return ok
 }
 
 // walkAttribute iterates over the given attribute, its bases and references
@@ -758,3 +916,15 @@ func walkAttribute(att *AttributeExpr, it func(name string, a *AttributeExpr) er
 	}
 	return nil
 }
+
+// hasObject returns true if the given data type is an object or an array or a
+// map whose elements or keys are objects.
+func hasObject(dt DataType) bool {
+	if a := AsArray(dt); a != nil {
+		return hasObject(a.ElemType.Type)
+	}
+	if m := AsMap(dt); m != nil {
+		return hasObject(m.KeyType.Type) || hasObject(m.ElemType.Type)
+	}
+	return IsObject(dt)
+}
@@ -124,6 +124,8 @@ func TestAttributeExprValidate(t *testing.T) {
 		errRequiredFieldNotExist = fmt.Errorf(`%srequired field %q does not exist in type %s`, normalizedCtx, "foo", fieldNotExistType.Name())
 		errViewButNotAResultType = fmt.Errorf("%sdefines a view %v but type %s is not a result type", normalizedCtx, metadata["view"], notAResultType.Name())
 		errTypeNotDefineView     = fmt.Errorf("%stype %s does not define view %q", normalizedCtx, viewNotDefinedTypeName, "foo")
+		errTimeValidation        = fmt.Errorf("%senum, format, pattern and length validations are not supported for %s attributes", normalizedCtx, Time.Name())
+		errNullableNotPrimitive  = fmt.Errorf("%snullable attributes must be of a primitive type", normalizedCtx)
 	)
 	cases := map[string]struct {
 		typ        DataType
@@ -135,6 +137,11 @@ func TestAttributeExprValidate(t *testing.T) {
 			typ:      Boolean,
 			expected: &eval.ValidationErrors{},
 		},
+		"pattern on time": {
+			typ:        Time,
+			validation: &ValidationExpr{Pattern: "^2020"},
+			expected:   &eval.ValidationErrors{Errors: []error{errTimeValidation}},
+		},
 		"attribute type is nil": {
 			typ:      nil,
 			expected: &eval.ValidationErrors{Errors: []error{errAttributeTypeNil}},
@@ -264,6 +271,16 @@ func TestAttributeExprValidate(t *testing.T) {
 			validation: validation,
 			expected:   &eval.ValidationErrors{Errors: []error{}},
 		},
+		"nullable primitive": {
+			typ:      String,
+			metadata: MetaExpr{"nullable": []string{"true"}},
+			expected: &eval.ValidationErrors{},
+		},
+		"nullable array": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"nullable": []string{"true"}},
+			expected: &eval.ValidationErrors{Errors: []error{errNullableNotPrimitive}},
+		},
 		"defines a view but is not a result type": {
 			typ:      Boolean,
 			metadata: metadata,
@@ -0,0 +1,51 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// CORSExpr describes the Cross-Origin Resource Sharing policy that
+	// applies to the requests made from a given origin.
+	CORSExpr struct {
+		// Origin is the allowed origin. It is either "*", an exact
+		// origin, an origin containing "*" wildcards or a regular
+		// expression delimited by slashes.
+		Origin string
+		// Methods lists the HTTP methods allowed in actual requests,
+		// empty means the method of the requested endpoint.
+		Methods []string
+		// Headers lists the request headers allowed in actual requests,
+		// "*" allows any header.
+		Headers []string
+		// ExposedHeaders lists the response headers exposed to the
+		// browsers.
+		ExposedHeaders []string
+		// MaxAge is the number of seconds the browsers may cache the
+		// results of preflight requests, zero means no caching.
+		MaxAge uint
+		// Credentials indicates whether the browsers may send
+		// credentials with the requests.
+		Credentials bool
+		// Parent is the API, service or method expression that defines
+		// the policy.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *CORSExpr) EvalName() string {
+	var suffix string
+	if c.Parent != nil {
+		suffix = fmt.Sprintf(" of %s", c.Parent.EvalName())
+	}
+	return fmt.Sprintf("CORS origin %q%s", c.Origin, suffix)
+}
+
+// IsRegexp returns true if the origin is a regular expression.
+func (c *CORSExpr) IsRegexp() bool {
+	l := len(c.Origin)
+	return l > 1 && c.Origin[0] == '/' && c.Origin[l-1] == '/'
+}
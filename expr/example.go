@@ -3,7 +3,9 @@ package expr
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"regexp"
+	"strconv"
 	"time"
 
 	regen "github.com/zach-klippenstein/goregen"
@@ -33,6 +35,9 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 	if hasLengthValidation(a) {
 		return byLength(a, r)
 	}
+	if hasUniqueItemsValidation(a) {
+		return byUniqueItems(a, NewLength(a, r), r)
+	}
 	// enum should dominate, because the potential "examples" are fixed
 	if hasEnumValidation(a) {
 		return byEnum(a, r)
@@ -42,6 +47,7 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 		hasFormat  = hasFormatValidation(a)
 		hasPattern = hasPatternValidation(a)
 		hasMinMax  = hasMinMaxValidation(a)
+		hasMult    = hasMultipleOfValidation(a)
 		attempts   = 0
 	)
 	for attempts < maxAttempts {
@@ -59,6 +65,14 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 				continue
 			}
 		}
+		if hasMult {
+			if example == nil {
+				example = byMultipleOf(a, r)
+			}
+			if !checkMultipleOf(a, example) {
+				continue
+			}
+		}
 		if hasMinMax {
 			if example == nil {
 				example = byMinMax(a, r)
@@ -128,11 +142,50 @@ func hasPatternValidation(a *AttributeExpr) bool {
 	return a.Validation != nil && a.Validation.Pattern != ""
 }
 
+func hasMultipleOfValidation(a *AttributeExpr) bool {
+	return a.Validation != nil && a.Validation.MultipleOf != nil && *a.Validation.MultipleOf > 0
+}
+
+func hasUniqueItemsValidation(a *AttributeExpr) bool {
+	return a.Validation != nil && a.Validation.UniqueItems && a.Type.Kind() == ArrayKind
+}
+
 func hasMinMaxValidation(a *AttributeExpr) bool {
 	if a.Validation == nil {
 		return false
 	}
-	return a.Validation.Minimum != nil || a.Validation.Maximum != nil
+	v := a.Validation
+	return v.Minimum != nil || v.Maximum != nil || v.ExclusiveMinimum != nil || v.ExclusiveMaximum != nil
+}
+
+// minMaxBounds returns the inclusive bounds of the values that validate the
+// attribute minimum, maximum and exclusive minimum and maximum validations.
+func minMaxBounds(a *AttributeExpr) (min, max *float64) {
+	min, max = a.Validation.Minimum, a.Validation.Maximum
+	integer := false
+	switch a.Type.Kind() {
+	case IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+		integer = true
+	}
+	if em := a.Validation.ExclusiveMinimum; em != nil {
+		v := math.Nextafter(*em, math.Inf(1))
+		if integer {
+			v = math.Floor(*em) + 1
+		}
+		if min == nil || v > *min {
+			min = &v
+		}
+	}
+	if em := a.Validation.ExclusiveMaximum; em != nil {
+		v := math.Nextafter(*em, math.Inf(-1))
+		if integer {
+			v = math.Ceil(*em) - 1
+		}
+		if max == nil || v < *max {
+			max = &v
+		}
+	}
+	return
 }
 
 // byLength generates a random size array of examples based on what's given.
@@ -151,6 +204,9 @@ func byLength(a *AttributeExpr, r *Random) interface{} {
 		}
 		return m.MakeMap(raw)
 	case ArrayKind:
+		if hasUniqueItemsValidation(a) {
+			return byUniqueItems(a, count, r)
+		}
 		raw := make([]interface{}, count)
 		ar := a.Type.(*Array)
 		for i := 0; i < count; i++ {
@@ -162,6 +218,32 @@ func byLength(a *AttributeExpr, r *Random) interface{} {
 	}
 }
 
+// byUniqueItems generates an array of count distinct examples. The array may
+// contain fewer items if the element type does not have enough distinct
+// values.
+func byUniqueItems(a *AttributeExpr, count int, r *Random) interface{} {
+	ar := a.Type.(*Array)
+	raw := make([]interface{}, 0, count)
+	for attempts := 0; len(raw) < count && attempts < maxAttempts; attempts++ {
+		ex := ar.ElemType.Example(r)
+		if ex == nil {
+			// Handle the case of recursive data structures
+			ex = make(map[string]interface{})
+		}
+		dup := false
+		for _, v := range raw {
+			if reflect.DeepEqual(v, ex) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			raw = append(raw, ex)
+		}
+	}
+	return ar.MakeSlice(raw)
+}
+
 // byEnum returns a random selected enum value.
 func byEnum(a *AttributeExpr, r *Random) interface{} {
 	if !hasEnumValidation(a) {
@@ -179,6 +261,30 @@ func byFormat(a *AttributeExpr, r *Random) interface{} {
 		return nil
 	}
 	format := a.Validation.Format
+	if gen, ok := customFormats[format]; ok {
+		if gen == nil {
+			return nil
+		}
+		return gen(r)
+	}
+	switch format {
+	case FormatTime:
+		return time.Unix(int64(r.Int())%86400, 0).UTC().Format("15:04:05")
+	case FormatDuration:
+		return fmt.Sprintf("P%dDT%dH%dM", r.Int()%30, r.Int()%24, r.Int()%60)
+	case FormatURIReference:
+		return "/" + r.faker.Characters(5) + "?page=" + strconv.Itoa(r.Int()%10)
+	case FormatIRI:
+		return "https://例え.jp/" + r.faker.Characters(5)
+	case FormatJSONPointer:
+		return "/" + r.faker.Characters(5) + "/" + strconv.Itoa(r.Int()%10)
+	case FormatIDNEmail:
+		return "用户@例子.广告"
+	case FormatSemver:
+		return fmt.Sprintf("%d.%d.%d", r.Int()%10, r.Int()%20, r.Int()%50)
+	case FormatDecimal:
+		return r.Decimal()
+	}
 	if res, ok := map[ValidationFormat]interface{}{
 		FormatEmail:    r.faker.Email(),
 		FormatHostname: r.faker.DomainName() + "." + r.faker.DomainSuffix(),
@@ -236,11 +342,12 @@ func byMinMax(a *AttributeExpr, r *Random) interface{} {
 		max  = math.Inf(1)
 		sign = 1
 	)
-	if a.Validation.Maximum != nil {
-		max = *a.Validation.Maximum
+	minimum, maximum := minMaxBounds(a)
+	if maximum != nil {
+		max = *maximum
 	}
-	if a.Validation.Minimum != nil {
-		min = *a.Validation.Minimum
+	if minimum != nil {
+		min = *minimum
 	} else {
 		sign = -1
 		min = max
@@ -308,6 +415,88 @@ func byMinMax(a *AttributeExpr, r *Random) interface{} {
 	}
 }
 
+// byMultipleOf returns a random multiple of the attribute multipleOf
+// validation that also validates the minimum and maximum validations if any.
+// It returns nil if the attribute is not numeric or if there is no such
+// multiple.
+func byMultipleOf(a *AttributeExpr, r *Random) interface{} {
+	if !hasMultipleOfValidation(a) {
+		return nil
+	}
+	m := *a.Validation.MultipleOf
+	lo, hi := 1.0, float64(maxAttempts)
+	if hasMinMaxValidation(a) {
+		minimum, maximum := minMaxBounds(a)
+		switch {
+		case minimum != nil && maximum != nil:
+			lo, hi = math.Ceil(*minimum/m), math.Floor(*maximum/m)
+		case minimum != nil:
+			lo = math.Ceil(*minimum / m)
+			hi = lo + maxAttempts
+		default:
+			hi = math.Floor(*maximum / m)
+			lo = hi - maxAttempts
+		}
+		if lo > hi {
+			return nil
+		}
+		if hi-lo > maxAttempts {
+			hi = lo + maxAttempts
+		}
+	}
+	v := (lo + float64(r.Int()%(int(hi-lo)+1))) * m
+	switch a.Type.Kind() {
+	case IntKind:
+		return int(v)
+	case Int32Kind:
+		return int32(v)
+	case Int64Kind:
+		return int64(v)
+	case UIntKind:
+		return uint(v)
+	case UInt32Kind:
+		return uint32(v)
+	case UInt64Kind:
+		return uint64(v)
+	case Float32Kind:
+		return float32(v)
+	case Float64Kind:
+		return v
+	}
+	return nil
+}
+
+// checkMultipleOf returns true if example is a number that validates the
+// attribute multipleOf validation.
+func checkMultipleOf(a *AttributeExpr, example interface{}) bool {
+	if !hasMultipleOfValidation(a) {
+		return true
+	}
+	var f float64
+	switch v := example.(type) {
+	case int:
+		f = float64(v)
+	case int32:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint:
+		f = float64(v)
+	case uint32:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case float32:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return false
+	}
+	q := f / *a.Validation.MultipleOf
+	return math.Abs(q-math.Round(q)) <= 1e-9*math.Max(1, math.Abs(q))
+}
+
 func checkPattern(a *AttributeExpr, example interface{}) bool {
 	if !hasPatternValidation(a) {
 		return true
@@ -327,14 +516,15 @@ func checkMinMaxValue(a *AttributeExpr, example interface{}) bool {
 	if !hasMinMaxValidation(a) {
 		return true
 	}
-	if min := a.Validation.Minimum; min != nil {
+	minimum, maximum := minMaxBounds(a)
+	if min := minimum; min != nil {
 		if v, ok := example.(int); ok && float64(v) < *min {
 			return false
 		} else if v, ok := example.(float64); ok && v < *min {
 			return false
 		}
 	}
-	if max := a.Validation.Maximum; max != nil {
+	if max := maximum; max != nil {
 		if v, ok := example.(int); ok && float64(v) > *max {
 			return false
 		} else if v, ok := example.(float64); ok && v > *max {
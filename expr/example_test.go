@@ -9,6 +9,7 @@ import (
 
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
+	goa "goa.design/goa/v3/pkg"
 )
 
 func TestByPattern(t *testing.T) {
@@ -49,6 +50,39 @@ func TestByFormatUUID(t *testing.T) {
 	}
 }
 
+func TestByFormat(t *testing.T) {
+	expr.RegisterFormat("sku", func(r *expr.Random) string { return "SKU-0001" })
+	cases := []expr.ValidationFormat{
+		expr.FormatTime,
+		expr.FormatDuration,
+		expr.FormatURIReference,
+		expr.FormatIRI,
+		expr.FormatJSONPointer,
+		expr.FormatIDNEmail,
+		expr.FormatSemver,
+		expr.FormatDecimal,
+	}
+	r := expr.NewRandom("test")
+	for _, f := range cases {
+		t.Run(string(f), func(t *testing.T) {
+			att := expr.AttributeExpr{Type: expr.String, Validation: &expr.ValidationExpr{Format: f}}
+			example := att.Example(r).(string)
+			if err := goa.ValidateFormat("example", example, goa.Format(f)); err != nil {
+				t.Errorf("got invalid example %q: %s", example, err)
+			}
+		})
+	}
+	t.Run("custom", func(t *testing.T) {
+		att := expr.AttributeExpr{Type: expr.String, Validation: &expr.ValidationExpr{Format: "sku"}}
+		if !att.IsSupportedValidationFormat("sku") {
+			t.Error("custom format is not supported")
+		}
+		if example := att.Example(r); example != "SKU-0001" {
+			t.Errorf("got %v, expected SKU-0001", example)
+		}
+	})
+}
+
 func TestExample(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -88,3 +122,92 @@ func TestExample(t *testing.T) {
 		})
 	}
 }
+
+func TestExampleMultipleOf(t *testing.T) {
+	var (
+		three = 3.0
+		half  = 0.5
+		ten   = 10.0
+		fifty = 50.0
+		seven = 7.0
+		eight = 8.0
+	)
+	cases := []struct {
+		Name       string
+		Type       expr.DataType
+		Validation *expr.ValidationExpr
+	}{
+		{"int", expr.Int, &expr.ValidationExpr{MultipleOf: &three}},
+		{"uint64", expr.UInt64, &expr.ValidationExpr{MultipleOf: &three}},
+		{"float64", expr.Float64, &expr.ValidationExpr{MultipleOf: &half}},
+		{"int-min-max", expr.Int, &expr.ValidationExpr{MultipleOf: &three, Minimum: &ten, Maximum: &fifty}},
+		{"int-exclusive-max", expr.Int32, &expr.ValidationExpr{MultipleOf: &three, ExclusiveMaximum: &ten}},
+		{"float-min", expr.Float64, &expr.ValidationExpr{MultipleOf: &half, Minimum: &fifty}},
+		{"single-value", expr.Int64, &expr.ValidationExpr{MultipleOf: &eight, Minimum: &seven, Maximum: &ten}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			att := &expr.AttributeExpr{Type: c.Type, Validation: c.Validation}
+			r := expr.NewRandom(c.Name)
+			for i := 0; i < 20; i++ {
+				ex := att.Example(r)
+				v := reflect.ValueOf(ex)
+				var f float64
+				switch v.Kind() {
+				case reflect.Int, reflect.Int32, reflect.Int64:
+					f = float64(v.Int())
+				case reflect.Uint, reflect.Uint32, reflect.Uint64:
+					f = float64(v.Uint())
+				case reflect.Float32, reflect.Float64:
+					f = v.Float()
+				default:
+					t.Fatalf("got example %#v, expected a number", ex)
+				}
+				if err := goa.ValidateMultipleOf("example", f, *c.Validation.MultipleOf); err != nil {
+					t.Errorf("invalid example: %s", err)
+				}
+				if min := c.Validation.Minimum; min != nil && f < *min {
+					t.Errorf("got example %v, expected at least %v", f, *min)
+				}
+				if max := c.Validation.Maximum; max != nil && f > *max {
+					t.Errorf("got example %v, expected at most %v", f, *max)
+				}
+				if max := c.Validation.ExclusiveMaximum; max != nil && f >= *max {
+					t.Errorf("got example %v, expected less than %v", f, *max)
+				}
+			}
+		})
+	}
+}
+
+func TestExampleUniqueItems(t *testing.T) {
+	var (
+		three = 3
+		four  = 4
+	)
+	cases := []struct {
+		Name       string
+		Type       expr.DataType
+		Validation *expr.ValidationExpr
+		MaxLen     int
+	}{
+		{"ints", &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.Int, Validation: &expr.ValidationExpr{Values: []interface{}{1, 2, 3}}}}, &expr.ValidationExpr{UniqueItems: true}, 3},
+		{"strings-min-length", &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String, Validation: &expr.ValidationExpr{Values: []interface{}{"a", "b", "c", "d"}}}}, &expr.ValidationExpr{UniqueItems: true, MinLength: &three}, 4},
+		{"booleans", &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.Boolean}}, &expr.ValidationExpr{UniqueItems: true, MaxLength: &four}, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			att := &expr.AttributeExpr{Type: c.Type, Validation: c.Validation}
+			r := expr.NewRandom(c.Name)
+			for i := 0; i < 20; i++ {
+				ex := att.Example(r)
+				if err := goa.ValidateUniqueItems("example", ex); err != nil {
+					t.Errorf("invalid example %#v: %s", ex, err)
+				}
+				if l := reflect.ValueOf(ex).Len(); l == 0 || l > c.MaxLen {
+					t.Errorf("got example %#v with %d items, expected between 1 and %d", ex, l, c.MaxLen)
+				}
+			}
+		})
+	}
+}
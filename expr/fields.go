@@ -0,0 +1,158 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// FieldSelectionExpr describes the selection of the result attributes
+	// rendered by a method. The method payload lists the names of the
+	// attributes to render, the result is projected using the view chosen
+	// by the server and the attributes of the view that are not listed are
+	// omitted.
+	FieldSelectionExpr struct {
+		// Method is the method whose results are projected.
+		Method *MethodExpr
+	}
+)
+
+// FieldSelectionAttribute is the name of the payload attribute that lists the
+// names of the result attributes to render.
+const FieldSelectionAttribute = "fields"
+
+// EvalName returns the generic expression name used in error messages.
+func (f *FieldSelectionExpr) EvalName() string {
+	return "field selection of " + f.Method.EvalName()
+}
+
+// Prepare adds the fields attribute to the method payload unless it is already
+// defined. The payload is initialized to an empty object if the method does
+// not define one. The values of the attribute are restricted to the names of
+// the attributes rendered by the result view. The attribute is not added to
+// user types that are also used by other methods, Validate reports an error
+// in this case unless the type defines the attribute explicitly.
+func (f *FieldSelectionExpr) Prepare() {
+	m := f.Method
+	if m.Payload == nil || m.Payload.Type == Empty {
+		m.Payload = &AttributeExpr{Type: &Object{}}
+	}
+	obj := AsObject(m.Payload.Type)
+	if obj == nil || sharedWith(m, m.Payload.Type) != nil {
+		return
+	}
+	elem := &AttributeExpr{Type: String}
+	if names := f.renderedAttributes(); len(names) > 0 {
+		vals := make([]interface{}, len(names))
+		for i, n := range names {
+			vals[i] = n
+		}
+		elem.Validation = &ValidationExpr{Values: vals}
+	}
+	att := addAttribute(obj, FieldSelectionAttribute, &AttributeExpr{
+		Type:        &Array{ElemType: elem},
+		Description: "Names of the result attributes to render, omit to render the whole view.",
+	})
+	if att != nil {
+		// The gRPC transport uses a google.protobuf.FieldMask message.
+		att.Meta["rpc:fieldmask"] = nil
+	}
+}
+
+// Validate makes sure the method result is a result type and that the fields
+// attribute is an array of strings that is neither required nor has a default
+// value.
+func (f *FieldSelectionExpr) Validate() *eval.ValidationErrors {
+	var (
+		verr = new(eval.ValidationErrors)
+		m    = f.Method
+	)
+	if m.IsStreaming() {
+		verr.Add(f, "methods that select the result fields cannot use streaming")
+	}
+	if _, ok := m.Result.Type.(*ResultTypeExpr); !ok || f.resultObject() == nil {
+		verr.Add(f, "result must be a result type")
+	}
+	if AsObject(m.Payload.Type) == nil {
+		verr.Add(f, "payload must be an object")
+		return verr
+	}
+	att := m.Payload.Find(FieldSelectionAttribute)
+	if att == nil {
+		if o := sharedWith(m, m.Payload.Type); o != nil {
+			verr.Add(f, "payload type %q is also used by method %q and does not define the %q attribute, define it explicitly or use a dedicated type", m.Payload.Type.Name(), o.Name, FieldSelectionAttribute)
+			return verr
+		}
+		verr.Add(f, "payload must define the %q attribute", FieldSelectionAttribute)
+		return verr
+	}
+	if arr := AsArray(att.Type); arr == nil || arr.ElemType.Type.Kind() != StringKind {
+		verr.Add(f, "payload attribute %q must be an array of strings", FieldSelectionAttribute)
+	}
+	if m.Payload.IsRequired(FieldSelectionAttribute) {
+		verr.Add(f, "payload attribute %q cannot be required", FieldSelectionAttribute)
+	}
+	if att.DefaultValue != nil {
+		verr.Add(f, "payload attribute %q cannot have a default value", FieldSelectionAttribute)
+	}
+	return verr
+}
+
+// renderedAttributes returns the names of the result attributes that may be
+// rendered by the method. These are the attributes of the view set with the
+// method result if any, of all the views of the result type otherwise as the
+// view is then chosen by the server at runtime.
+func (f *FieldSelectionExpr) renderedAttributes() []string {
+	robj := f.resultObject()
+	if robj == nil {
+		return nil
+	}
+	var rt *ResultTypeExpr
+	{
+		res := f.Method.Result
+		if arr := AsArray(res.Type); arr != nil {
+			rt, _ = arr.ElemType.Type.(*ResultTypeExpr)
+		} else {
+			rt, _ = res.Type.(*ResultTypeExpr)
+		}
+	}
+	var views []*ViewExpr
+	if rt != nil {
+		if v, ok := f.Method.Result.Meta["view"]; ok {
+			if view := rt.View(v[0]); view != nil {
+				views = []*ViewExpr{view}
+			}
+		} else if rt.View(DefaultView) != nil {
+			// The default view renders all the attributes unless it is
+			// explicitly defined.
+			views = rt.Views
+		}
+	}
+	var names []string
+	for _, nat := range *robj {
+		rendered := len(views) == 0
+		for _, v := range views {
+			if v.AttributeExpr.Find(nat.Name) != nil {
+				rendered = true
+				break
+			}
+		}
+		if rendered {
+			names = append(names, nat.Name)
+		}
+	}
+	return names
+}
+
+// resultObject returns the attributes of the method result or of its elements
+// if the result is a collection. It returns nil if the result is not an object
+// or a collection of objects.
+func (f *FieldSelectionExpr) resultObject() *Object {
+	res := f.Method.Result
+	if res == nil {
+		return nil
+	}
+	if arr := AsArray(res.Type); arr != nil {
+		return AsObject(arr.ElemType.Type)
+	}
+	return AsObject(res.Type)
+}
@@ -0,0 +1,76 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFieldSelectionPrepare(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []interface{}
+	}{
+		{"default-view", testdata.SparseFieldsetsDSL, []interface{}{"id", "name", "href"}},
+		{"method-view", testdata.SparseFieldsetsViewDSL, []interface{}{"id", "name"}},
+		{"all-views", testdata.SparseFieldsetsViewsDSL, []interface{}{"id", "href"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, tc.DSL)
+			m := root.Services[0].Methods[0]
+			att := m.Payload.Find(expr.FieldSelectionAttribute)
+			if att == nil {
+				t.Fatalf("payload attribute %q not found", expr.FieldSelectionAttribute)
+			}
+			arr := expr.AsArray(att.Type)
+			if arr == nil {
+				t.Fatalf("got type %s, expected array", att.Type.Name())
+			}
+			if v := arr.ElemType.Validation; v == nil || len(v.Values) != len(tc.Expected) {
+				t.Fatalf("got enum %v, expected %v", v, tc.Expected)
+			}
+			for i, v := range arr.ElemType.Validation.Values {
+				if v != tc.Expected[i] {
+					t.Errorf("got enum value %v at index %d, expected %v", v, i, tc.Expected[i])
+				}
+			}
+			if root.API.HTTP.Services == nil {
+				return
+			}
+			params := root.API.HTTP.Services[0].HTTPEndpoints[0].QueryParams()
+			if params.Find(expr.FieldSelectionAttribute) == nil {
+				t.Errorf("query param %q not found", expr.FieldSelectionAttribute)
+			}
+		})
+	}
+}
+
+func TestFieldSelectionValidate(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidSparseFieldsetsDSL)
+	expected := `field selection of service "InvalidSparseFieldsetsService" method "Show": result must be a result type
+field selection of service "InvalidSparseFieldsetsService" method "Show": payload attribute "fields" must be an array of strings
+field selection of service "InvalidSparseFieldsetsService" method "Show": payload attribute "fields" cannot be required`
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if err.Error() != expected {
+		t.Errorf("invalid error, got:\n%s\n\nexpected:\n%s", err.Error(), expected)
+	}
+}
+
+func TestFieldSelectionSharedTypes(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.SharedTypeSparseFieldsetsDSL)
+	expected := `field selection of service "SharedTypeSparseFieldsetsService" method "Show": payload type "ID" is also used by method "Delete" and does not define the "fields" attribute, define it explicitly or use a dedicated type`
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if err.Error() != expected {
+		t.Errorf("invalid error, got:\n%s\n\nexpected:\n%s", err.Error(), expected)
+	}
+	if expr.Root.Services[0].Methods[1].Payload.Find(expr.FieldSelectionAttribute) != nil {
+		t.Errorf("payload of method %q has attribute %q", "Delete", expr.FieldSelectionAttribute)
+	}
+}
@@ -226,7 +226,7 @@ func (e *GRPCEndpointExpr) Finalize() {
 				for _, sch := range dupReq.Schemes {
 					var field string
 					switch sch.Kind {
-					case NoKind:
+					case NoKind, MTLSKind:
 						continue
 					case BasicAuthKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:username")
@@ -319,11 +319,15 @@ func appendSuffix(dt DataType, suffix string, seen ...map[string]struct{}) {
 		appendSuffix(actual.Attribute().Type, suffix, seen...)
 	case *Object:
 		for _, nat := range *actual {
+			UnwrapPrimitive(nat.Attribute)
 			appendSuffix(nat.Attribute.Type, suffix, seen...)
 		}
 	case *Array:
+		UnwrapPrimitive(actual.ElemType)
 		appendSuffix(actual.ElemType.Type, suffix, seen...)
 	case *Map:
+		UnwrapPrimitive(actual.KeyType)
+		UnwrapPrimitive(actual.ElemType)
 		appendSuffix(actual.KeyType.Type, suffix, seen...)
 		appendSuffix(actual.ElemType.Type, suffix, seen...)
 	}
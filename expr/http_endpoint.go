@@ -238,6 +238,32 @@ func (e *HTTPEndpointExpr) Prepare() {
 		}
 	}
 
+	// Map the pagination and field selection attributes to query string
+	// parameters unless they are mapped explicitly.
+	var queryAtts []string
+	if pag := e.MethodExpr.Pagination; pag != nil {
+		queryAtts = append(queryAtts, pag.TokenAttribute(), PaginationLimitAttribute)
+	}
+	if e.MethodExpr.FieldSelection != nil {
+		queryAtts = append(queryAtts, FieldSelectionAttribute)
+	}
+	for _, name := range queryAtts {
+		if params.Find(name) != nil || e.Headers.Find(name) != nil {
+			continue
+		}
+		if e.Body != nil && e.Body.Find(name) != nil {
+			continue
+		}
+		params.Merge(NewMappedAttributeExpr(&AttributeExpr{
+			Type: &Object{
+				&NamedAttributeExpr{
+					Name:      name,
+					Attribute: &AttributeExpr{Type: String},
+				},
+			},
+		}))
+	}
+
 	// Make sure there's a default response if none define explicitly
 	if len(e.Responses) == 0 {
 		status := StatusOK
@@ -308,6 +334,18 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	// Make sure the CORS preflight routes do not conflict with explicit
+	// OPTIONS routes
+	if e.hasCORS() {
+		for _, r := range e.Routes {
+			for _, fp := range r.FullPaths() {
+				if o := optionsEndpoint(fp); o != nil {
+					verr.Add(r, "CORS is enabled but path %q is also used by the OPTIONS route of %s", fp, o.EvalName())
+				}
+			}
+		}
+	}
+
 	// Validate responses
 
 	// All responses but one must have tags for the same status code
@@ -499,7 +537,7 @@ func (e *HTTPEndpointExpr) Finalize() {
 			for _, sch := range dupReq.Schemes {
 				var field string
 				switch sch.Kind {
-				case NoKind:
+				case NoKind, MTLSKind:
 					continue
 				case BasicAuthKind:
 					sch.In = "header"
@@ -639,12 +677,16 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			WalkMappedAttr(pparams, func(name, _ string, a *AttributeExpr) error {
 				if e.MethodExpr.Payload.Find(name) == nil {
 					verr.Add(e, "Path parameter %q not found in payload.", name)
+				} else if e.MethodExpr.Payload.Find(name).IsNullable() {
+					verr.Add(e, "Path parameter %q is nullable, nullable attributes can only be mapped to the request body.", name)
 				}
 				return nil
 			})
 			WalkMappedAttr(qparams, func(name, _ string, a *AttributeExpr) error {
 				if e.MethodExpr.Payload.Find(name) == nil {
 					verr.Add(e, "Query string parameter %q not found in payload.", name)
+				} else if e.MethodExpr.Payload.Find(name).IsNullable() {
+					verr.Add(e, "Query string parameter %q is nullable, nullable attributes can only be mapped to the request body.", name)
 				}
 				return nil
 			})
@@ -693,6 +735,8 @@ func (e *HTTPEndpointExpr) validateHeaders() *eval.ValidationErrors {
 		WalkMappedAttr(headers, func(name, elem string, a *AttributeExpr) error {
 			if e.MethodExpr.Payload.Find(name) == nil {
 				verr.Add(e, "header %q not found in payload.", name)
+			} else if e.MethodExpr.Payload.Find(name).IsNullable() {
+				verr.Add(e, "header %q is nullable, nullable attributes can only be mapped to the request body.", name)
 			}
 			if elem == "Authorization" && hasBasicAuth {
 				// BasicAuth security implicitly sets the Authorization header. If any
@@ -714,6 +758,39 @@ func (e *HTTPEndpointExpr) validateHeaders() *eval.ValidationErrors {
 	return verr
 }
 
+// hasCORS returns true if a CORS policy applies to the endpoint, either
+// defined on the method or inherited from the service or API.
+func (e *HTTPEndpointExpr) hasCORS() bool {
+	m := e.MethodExpr
+	if len(m.CORS) > 0 || m.Service != nil && len(m.Service.CORS) > 0 {
+		return true
+	}
+	return Root.API != nil && len(Root.API.CORS) > 0
+}
+
+// optionsEndpoint returns the HTTP endpoint that defines an OPTIONS route
+// with the given full path if any, nil otherwise.
+func optionsEndpoint(fullPath string) *HTTPEndpointExpr {
+	if Root.API == nil || Root.API.HTTP == nil {
+		return nil
+	}
+	for _, svc := range Root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				if r.Method != "OPTIONS" {
+					continue
+				}
+				for _, fp := range r.FullPaths() {
+					if fp == fullPath {
+						return e
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // EvalName returns the generic definition name used in error messages.
 func (r *RouteExpr) EvalName() string {
 	return fmt.Sprintf(`route %s "%s" of %s`, r.Method, r.Path, r.Endpoint.EvalName())
@@ -844,6 +921,10 @@ func initAttrFromDesign(att, patt *AttributeExpr) {
 		return
 	}
 	att.Type = patt.Type
+	if HasPrimitiveUserType(att.Type) {
+		att.Type = DupAtt(patt).Type
+		UnwrapPrimitive(att)
+	}
 	if att.Description == "" {
 		att.Description = patt.Description
 	}
@@ -135,6 +135,12 @@ func TestHTTPEndpointValidation(t *testing.T) {
 		"endpoint-has-parent-and-other": {
 			DSL: testdata.EndpointHasParentAndOther,
 		},
+		"endpoint-cors-options-conflict": {
+			DSL: testdata.CORSOptionsConflictDSL,
+			Errors: []string{
+				"route GET \"/items\" of service \"Service\" HTTP endpoint \"Method\": CORS is enabled but path \"/items\" is also used by the OPTIONS route of service \"Service\" HTTP endpoint \"Options\"",
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
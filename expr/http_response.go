@@ -187,6 +187,9 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 				if t == nil {
 					verr.Add(r, "header %q has no equivalent attribute in%s result type, use notation 'attribute_name:header_name' to identify corresponding result type attribute.", h.Name, inview)
 				}
+				if e.MethodExpr.Result.Find(h.Name).IsNullable() {
+					verr.Add(r, "header %q is nullable, nullable attributes can only be mapped to the response body.", h.Name)
+				}
 				if IsArray(t) {
 					if !IsPrimitive(AsArray(t).ElemType.Type) {
 						verr.Add(e, "attribute %q used in HTTP headers must be a primitive type or an array of primitive types.", h.Name)
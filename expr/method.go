@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -31,6 +32,22 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Authorizations contains the authorization requirements for
+		// the method. Authenticated requests must satisfy at least one
+		// requirement to be authorized.
+		Authorizations []*AuthorizationExpr
+		// RequestBodyLimit is the maximum size in bytes of the request
+		// body, zero means no limit. Finalize initializes it with the
+		// service or API value if not set.
+		RequestBodyLimit int64
+		// RequestTimeout is the maximum duration of the requests handled
+		// by the method, zero means no timeout. Finalize initializes it
+		// with the service or API value if not set.
+		RequestTimeout time.Duration
+		// CORS lists the CORS policies of the method, one per allowed
+		// origin. Finalize initializes it with the service or API
+		// policies if not set.
+		CORS []*CORSExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -40,6 +57,12 @@ type (
 		Stream StreamKind
 		// StreamingPayload is the payload sent across the stream.
 		StreamingPayload *AttributeExpr
+		// Pagination describes the pagination of the method results if
+		// any.
+		Pagination *PaginationExpr
+		// FieldSelection describes the selection of the rendered result
+		// attributes by the client if any.
+		FieldSelection *FieldSelectionExpr
 	}
 )
 
@@ -94,12 +117,24 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	if m.Pagination != nil {
+		m.Pagination.Prepare()
+	}
+	if m.FieldSelection != nil {
+		m.FieldSelection.Prepare()
+	}
 }
 
 // Validate validates the method payloads, results, and errors (if any).
 func (m *MethodExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
 	verr.Merge(m.Payload.Validate("payload", m))
+	if m.Pagination != nil {
+		verr.Merge(m.Pagination.Validate())
+	}
+	if m.FieldSelection != nil {
+		verr.Merge(m.FieldSelection.Validate())
+	}
 	// validate security scheme requirements
 	var requirements []*SecurityExpr
 	if len(m.Requirements) > 0 {
@@ -145,7 +180,7 @@ func (m *MethodExpr) Validate() error {
 		for _, scope := range r.Scopes {
 			found := false
 			for _, s := range r.Schemes {
-				if s.Kind == BasicAuthKind || s.Kind == APIKeyKind || s.Kind == OAuth2Kind || s.Kind == JWTKind {
+				if s.Kind == BasicAuthKind || s.Kind == APIKeyKind || s.Kind == OAuth2Kind || s.Kind == JWTKind || s.Kind == MTLSKind {
 					for _, se := range s.Scopes {
 						if se.Name == scope {
 							found = true
@@ -159,6 +194,13 @@ func (m *MethodExpr) Validate() error {
 			}
 		}
 	}
+	authorizations := m.Authorizations
+	if len(authorizations) == 0 {
+		authorizations = m.Service.Authorizations
+	}
+	for _, a := range authorizations {
+		verr.Merge(a.Validate(m))
+	}
 	if !hasBasicAuth {
 		if hasTag(m.Payload, "security:username") {
 			verr.Add(m, "payload of method %q of service %q defines a username attribute, but no basic auth security scheme exist", m.Name, m.Service.Name)
@@ -187,6 +229,11 @@ func (m *MethodExpr) Validate() error {
 	}
 	if m.Result.Type != Empty {
 		verr.Merge(m.Result.Validate("result", m))
+		if rt, ok := m.Result.Type.(*ResultTypeExpr); ok {
+			if _, ok := rt.Attribute().Meta["type:generate:builder"]; ok {
+				verr.Add(m, "result type %q of method %q of service %q cannot set the type:generate:builder meta, the constructor name is used to initialize the result type from its viewed result type", rt.Name(), m.Name, m.Service.Name)
+			}
+		}
 	}
 	for i, e := range m.Errors {
 		if err := e.Validate(); err != nil {
@@ -306,6 +353,35 @@ func (m *MethodExpr) Finalize() {
 		m.Requirements = copyReqs(m.Service.Requirements)
 	}
 
+	// Inherit authorization requirements
+	if len(m.Authorizations) == 0 && len(m.Service.Authorizations) > 0 {
+		m.Authorizations = make([]*AuthorizationExpr, len(m.Service.Authorizations))
+		for i, a := range m.Service.Authorizations {
+			m.Authorizations[i] = DupAuthorization(a)
+		}
+	}
+
+	// Inherit request limits
+	if m.RequestBodyLimit == 0 {
+		m.RequestBodyLimit = m.Service.RequestBodyLimit
+		if m.RequestBodyLimit == 0 && Root.API != nil {
+			m.RequestBodyLimit = Root.API.RequestBodyLimit
+		}
+	}
+	if m.RequestTimeout == 0 {
+		m.RequestTimeout = m.Service.RequestTimeout
+		if m.RequestTimeout == 0 && Root.API != nil {
+			m.RequestTimeout = Root.API.RequestTimeout
+		}
+	}
+
+	// Inherit CORS policies
+	if len(m.CORS) == 0 {
+		m.CORS = m.Service.CORS
+		if len(m.CORS) == 0 && Root.API != nil {
+			m.CORS = Root.API.CORS
+		}
+	}
 }
 
 // IsStreaming determines whether the method streams payload or result.
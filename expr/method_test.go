@@ -2,7 +2,9 @@ package expr_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
@@ -32,6 +34,14 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a JWT token attribute, but no JWT auth security scheme exist
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a OAuth2 access token attribute, but no OAuth2 security scheme exist`,
 		},
+		{"invalid-authorization", testdata.InvalidAuthorizationDSL,
+			`Authorize: authorization attribute "not_found" not found in payload of method "Method" of service "InvalidAuthorizationService"
+Authorize: authorization attribute "tenant_id" not found in payload of method "ReferenceMethod" of service "InvalidAuthorizationService"
+Authorize: authorization requirement of method "EmptyMethod" of service "InvalidAuthorizationService" is empty, use Role, Scope or Resource to define one`,
+		},
+		{"invalid-result-builder", testdata.InvalidResultBuilderDSL,
+			`service "InvalidResultBuilderService" method "Method": result type "Builder" of method "Method" of service "InvalidResultBuilderService" cannot set the type:generate:builder meta, the constructor name is used to initialize the result type from its viewed result type`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -154,3 +164,65 @@ func TestMethodExprIsPayloadStreaming(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodExprFinalizeRequestLimits(t *testing.T) {
+	cases := []struct {
+		Service   string
+		Method    string
+		BodyLimit int64
+		Timeout   time.Duration
+	}{
+		{"APIDefaultsService", "Method", 1024, time.Minute},
+		{"ServiceDefaultsService", "Method", 2048, time.Second},
+		{"ServiceDefaultsService", "MethodOverride", 4096, time.Hour},
+	}
+	root := expr.RunDSL(t, testdata.RequestLimitsDSL)
+	for _, tc := range cases {
+		t.Run(tc.Service+"/"+tc.Method, func(t *testing.T) {
+			m := root.Service(tc.Service).Method(tc.Method)
+			if m.RequestBodyLimit != tc.BodyLimit {
+				t.Errorf("got request body limit %d, expected %d", m.RequestBodyLimit, tc.BodyLimit)
+			}
+			if m.RequestTimeout != tc.Timeout {
+				t.Errorf("got request timeout %s, expected %s", m.RequestTimeout, tc.Timeout)
+			}
+		})
+	}
+}
+
+func TestMethodExprFinalizeCORS(t *testing.T) {
+	cases := []struct {
+		Service string
+		Method  string
+		Origins []string
+	}{
+		{"APIDefaultsService", "Method", []string{"*"}},
+		{"ServiceDefaultsService", "Method", []string{"https://goa.design", "/^http://localhost:[0-9]+$/"}},
+		{"ServiceDefaultsService", "MethodOverride", []string{"https://*.goa.design"}},
+	}
+	root := expr.RunDSL(t, testdata.CORSDSL)
+	for _, tc := range cases {
+		t.Run(tc.Service+"/"+tc.Method, func(t *testing.T) {
+			m := root.Service(tc.Service).Method(tc.Method)
+			if len(m.CORS) != len(tc.Origins) {
+				t.Fatalf("got %d CORS origins, expected %d", len(m.CORS), len(tc.Origins))
+			}
+			for i, c := range m.CORS {
+				if c.Origin != tc.Origins[i] {
+					t.Errorf("got CORS origin %q at index %d, expected %q", c.Origin, i, tc.Origins[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMethodExprCORSCredentialsAnyOrigin(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.CORSCredentialsAnyOriginDSL)
+	if err == nil {
+		t.Fatal("expected DSL error")
+	}
+	expected := `AllowCredentials cannot be used with the "*" origin`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected it to contain %q", err.Error(), expected)
+	}
+}
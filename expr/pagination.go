@@ -0,0 +1,290 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationKind is a type denoting the kind of pagination.
+	PaginationKind int
+
+	// PaginationExpr describes the pagination of the results of a method.
+	// A paginated method payload defines the position of the page to
+	// retrieve and the maximum number of items in the page. Its result
+	// defines the position of the next page if any.
+	PaginationExpr struct {
+		// Method is the paginated method.
+		Method *MethodExpr
+		// Kind is the kind of pagination (cursor or page number).
+		Kind PaginationKind
+	}
+)
+
+const (
+	// CursorPaginationKind represents a pagination where pages are
+	// identified by opaque cursors returned by the server.
+	CursorPaginationKind PaginationKind = iota + 1
+	// PagePaginationKind represents a pagination where pages are identified
+	// by their number.
+	PagePaginationKind
+)
+
+const (
+	// PaginationCursorAttribute is the name of the payload attribute that
+	// holds the cursor of the page to retrieve.
+	PaginationCursorAttribute = "cursor"
+	// PaginationPageAttribute is the name of the payload attribute that
+	// holds the number of the page to retrieve.
+	PaginationPageAttribute = "page"
+	// PaginationLimitAttribute is the name of the payload attribute that
+	// holds the maximum number of items in a page.
+	PaginationLimitAttribute = "limit"
+	// PaginationNextCursorAttribute is the name of the result attribute that
+	// holds the cursor of the next page.
+	PaginationNextCursorAttribute = "next_cursor"
+	// PaginationNextPageAttribute is the name of the result attribute that
+	// holds the number of the next page.
+	PaginationNextPageAttribute = "next_page"
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	return "pagination of " + p.Method.EvalName()
+}
+
+// TokenAttribute returns the name of the payload attribute that identifies
+// the page to retrieve.
+func (p *PaginationExpr) TokenAttribute() string {
+	if p.Kind == PagePaginationKind {
+		return PaginationPageAttribute
+	}
+	return PaginationCursorAttribute
+}
+
+// NextAttribute returns the name of the result attribute that identifies the
+// next page.
+func (p *PaginationExpr) NextAttribute() string {
+	if p.Kind == PagePaginationKind {
+		return PaginationNextPageAttribute
+	}
+	return PaginationNextCursorAttribute
+}
+
+// Prepare adds the pagination attributes to the method payload and result
+// unless they are already defined. The payload is initialized to an empty
+// object if the method does not define one. The attributes are not added to
+// user types that are also used by other methods, Validate reports an error
+// in this case unless the types define the attributes explicitly.
+func (p *PaginationExpr) Prepare() {
+	m := p.Method
+	if m.Payload == nil || m.Payload.Type == Empty {
+		m.Payload = &AttributeExpr{Type: &Object{}}
+	}
+	one := 1.0
+	if obj := AsObject(m.Payload.Type); obj != nil && sharedWith(p.Method, m.Payload.Type) == nil {
+		if p.Kind == PagePaginationKind {
+			addAttribute(obj, PaginationPageAttribute, &AttributeExpr{
+				Type:        Int,
+				Description: "Number of the page to retrieve starting at 1, omit to retrieve the first page.",
+				Validation:  &ValidationExpr{Minimum: &one},
+			})
+		} else {
+			addAttribute(obj, PaginationCursorAttribute, &AttributeExpr{
+				Type:        String,
+				Description: "Cursor of the page to retrieve, omit to retrieve the first page.",
+			})
+		}
+		addAttribute(obj, PaginationLimitAttribute, &AttributeExpr{
+			Type:        Int,
+			Description: "Maximum number of items in the page.",
+			Validation:  &ValidationExpr{Minimum: &one},
+		})
+	}
+	if m.Result == nil || m.Result.Type == Empty {
+		return
+	}
+	var next *AttributeExpr
+	if p.Kind == PagePaginationKind {
+		next = &AttributeExpr{
+			Type:        Int,
+			Description: "Number of the next page, not set if the page is the last one.",
+		}
+	} else {
+		next = &AttributeExpr{
+			Type:        String,
+			Description: "Cursor of the next page, not set if the page is the last one.",
+		}
+	}
+	if obj := AsObject(m.Result.Type); obj != nil && sharedWith(p.Method, m.Result.Type) == nil {
+		if added := addAttribute(obj, p.NextAttribute(), next); added != nil {
+			if rt, ok := m.Result.Type.(*ResultTypeExpr); ok {
+				for _, v := range rt.Views {
+					if vobj := AsObject(v.Type); vobj != nil && vobj.Attribute(p.NextAttribute()) == nil {
+						vobj.Set(p.NextAttribute(), added)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Validate makes sure the method payload and result are objects and that the
+// pagination attributes have the expected types and are neither required nor
+// have a default value.
+func (p *PaginationExpr) Validate() *eval.ValidationErrors {
+	var (
+		verr = new(eval.ValidationErrors)
+		m    = p.Method
+	)
+	if m.IsStreaming() {
+		verr.Add(p, "paginated methods cannot use streaming")
+	}
+	kind := StringKind
+	if p.Kind == PagePaginationKind {
+		kind = IntKind
+	}
+	validate := func(att *AttributeExpr, name, typ string, kind Kind) {
+		a := att.Find(name)
+		if a == nil {
+			if o := sharedWith(p.Method, att.Type); o != nil {
+				verr.Add(p, "%s type %q is also used by method %q and does not define the %q attribute, define it explicitly or use a dedicated type", typ, att.Type.Name(), o.Name, name)
+				return
+			}
+			verr.Add(p, "%s must define the %q attribute", typ, name)
+			return
+		}
+		if a.Type.Kind() != kind {
+			verr.Add(p, "%s attribute %q must be of type %s", typ, name, kindName(kind))
+		}
+		if att.IsRequired(name) {
+			verr.Add(p, "%s attribute %q cannot be required", typ, name)
+		}
+		if a.DefaultValue != nil {
+			verr.Add(p, "%s attribute %q cannot have a default value", typ, name)
+		}
+	}
+	if AsObject(m.Payload.Type) == nil {
+		verr.Add(p, "payload must be an object")
+	} else {
+		validate(m.Payload, p.TokenAttribute(), "payload", kind)
+		validate(m.Payload, PaginationLimitAttribute, "payload", IntKind)
+	}
+	if m.Result.Type == Empty || AsObject(m.Result.Type) == nil {
+		verr.Add(p, "result must be an object")
+	} else {
+		validate(m.Result, p.NextAttribute(), "result", kind)
+	}
+	for _, o := range m.Service.Methods {
+		if normalizeName(o.Name) == normalizeName(m.Name)+"pages" {
+			verr.Add(p, "method %q conflicts with the name of the client method that iterates over the pages of method %q", o.Name, m.Name)
+		}
+	}
+	return verr
+}
+
+// sharedWith returns a method of the design other than m whose payload, result
+// or errors use the given user type, nil if there is none or if dt is not a
+// user type. Attributes added to such types by the pagination and field
+// selection expressions would change the other methods.
+func sharedWith(m *MethodExpr, dt DataType) *MethodExpr {
+	ut, ok := dt.(UserType)
+	if !ok || Root == nil {
+		return nil
+	}
+	for _, s := range Root.Services {
+		for _, o := range s.Methods {
+			if o == m {
+				continue
+			}
+			atts := []*AttributeExpr{o.Payload, o.StreamingPayload, o.Result}
+			for _, e := range o.Errors {
+				atts = append(atts, e.AttributeExpr)
+			}
+			for _, att := range atts {
+				if usesType(att, ut, make(map[string]struct{})) {
+					return o
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// usesType returns true if the given attribute type is ut or makes use of ut.
+func usesType(att *AttributeExpr, ut UserType, seen map[string]struct{}) bool {
+	if att == nil {
+		return false
+	}
+	for _, b := range att.Bases {
+		if usesType(&AttributeExpr{Type: b}, ut, seen) {
+			return true
+		}
+	}
+	switch dt := att.Type.(type) {
+	case UserType:
+		if dt.ID() == ut.ID() {
+			return true
+		}
+		if _, ok := seen[dt.ID()]; ok {
+			return false
+		}
+		seen[dt.ID()] = struct{}{}
+		return usesType(dt.Attribute(), ut, seen)
+	case *Object:
+		for _, nat := range *dt {
+			if usesType(nat.Attribute, ut, seen) {
+				return true
+			}
+		}
+	case *Array:
+		return usesType(dt.ElemType, ut, seen)
+	case *Map:
+		return usesType(dt.KeyType, ut, seen) || usesType(dt.ElemType, ut, seen)
+	}
+	return false
+}
+
+// addAttribute adds the attribute att with the given name to obj unless obj
+// already defines an attribute with that name. The attribute is tagged with
+// the next available field number so it can be used in gRPC messages.
+// addAttribute returns the added attribute or nil if obj already defined it.
+func addAttribute(obj *Object, name string, att *AttributeExpr) *AttributeExpr {
+	if obj.Attribute(name) != nil {
+		return nil
+	}
+	tag := len(*obj)
+	for _, nat := range *obj {
+		if t, ok := nat.Attribute.FieldTag(); ok {
+			if n, err := strconv.Atoi(t); err == nil && n > tag {
+				tag = n
+			}
+		}
+	}
+	att.Meta = MetaExpr{"rpc:tag": []string{fmt.Sprint(tag + 1)}}
+	obj.Set(name, att)
+	return att
+}
+
+// normalizeName returns the lower case version of the given name stripped of
+// the characters that are not letters or digits.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name))
+}
+
+// kindName returns the name of the primitive type with the given kind.
+func kindName(k Kind) string {
+	if k == IntKind {
+		return Int.Name()
+	}
+	return String.Name()
+}
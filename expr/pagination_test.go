@@ -0,0 +1,83 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPaginationPrepare(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Payload []string
+		Result  string
+		Params  []string
+	}{
+		{"cursor", testdata.CursorPaginationDSL, []string{"filter", "cursor", "limit"}, "next_cursor", []string{"filter", "cursor", "limit"}},
+		{"page", testdata.PagePaginationDSL, []string{"page", "limit"}, "next_page", []string{"page"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, tc.DSL)
+			m := root.Services[0].Methods[0]
+			for _, n := range tc.Payload {
+				if m.Payload.Find(n) == nil {
+					t.Errorf("payload attribute %q not found", n)
+				}
+			}
+			if m.Result.Find(tc.Result) == nil {
+				t.Errorf("result attribute %q not found", tc.Result)
+			}
+			if rt, ok := m.Result.Type.(*expr.ResultTypeExpr); ok {
+				for _, v := range rt.Views {
+					if expr.AsObject(v.Type).Attribute(tc.Result) == nil {
+						t.Errorf("result attribute %q not found in view %q", tc.Result, v.Name)
+					}
+				}
+			}
+			params := root.API.HTTP.Services[0].HTTPEndpoints[0].QueryParams()
+			if l := len(*expr.AsObject(params.Type)); l != len(tc.Params) {
+				t.Errorf("got %d query params, expected %d", l, len(tc.Params))
+			}
+			for _, n := range tc.Params {
+				if params.Find(n) == nil {
+					t.Errorf("query param %q not found", n)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginationValidate(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidPaginationDSL)
+	expected := `pagination of service "InvalidPaginationService" method "List": payload attribute "cursor" must be of type string
+pagination of service "InvalidPaginationService" method "List": payload attribute "cursor" cannot be required
+pagination of service "InvalidPaginationService" method "List": payload attribute "limit" cannot have a default value
+pagination of service "InvalidPaginationService" method "List": result must be an object
+pagination of service "InvalidPaginationService" method "List": method "ListPages" conflicts with the name of the client method that iterates over the pages of method "List"
+pagination of service "InvalidPaginationService" method "ListPages": result attribute "next_page" must be of type int`
+	if err.Error() != expected {
+		t.Errorf("invalid error:\ngot:\n%s\n\ngot vs expected:\n%s", err.Error(), expr.Diff(t, err.Error(), expected))
+	}
+}
+
+func TestPaginationSharedTypes(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.SharedTypePaginationDSL)
+	expected := `pagination of service "SharedTypePaginationService" method "List": payload type "Filter" is also used by method "Count" and does not define the "cursor" attribute, define it explicitly or use a dedicated type
+pagination of service "SharedTypePaginationService" method "List": payload type "Filter" is also used by method "Count" and does not define the "limit" attribute, define it explicitly or use a dedicated type
+pagination of service "SharedTypePaginationService" method "List": result type "SharedPage" is also used by method "Count" and does not define the "next_cursor" attribute, define it explicitly or use a dedicated type`
+	if err.Error() != expected {
+		t.Errorf("invalid error:\ngot:\n%s\n\ngot vs expected:\n%s", err.Error(), expr.Diff(t, err.Error(), expected))
+	}
+	count := expr.Root.Services[0].Methods[1]
+	for _, n := range []string{"cursor", "limit"} {
+		if count.Payload.Find(n) != nil {
+			t.Errorf("payload of non-paginated method has attribute %q", n)
+		}
+	}
+	if count.Result.Find("next_cursor") != nil {
+		t.Errorf("result of non-paginated method has attribute %q", "next_cursor")
+	}
+}
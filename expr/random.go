@@ -3,7 +3,9 @@ package expr
 import (
 	"crypto/md5"
 	"encoding/binary"
+	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/manveru/faker"
 )
@@ -90,3 +92,22 @@ func (r *Random) UInt32() uint32 {
 func (r *Random) UInt64() uint64 {
 	return r.rand.Uint64()
 }
+
+// Time produces a random time between 1970 and 2040 with a second
+// resolution.
+func (r *Random) Time() time.Time {
+	return time.Unix(r.rand.Int63n(2208988800), 0).UTC()
+}
+
+// Duration produces a random duration shorter than a day with a second
+// resolution.
+func (r *Random) Duration() time.Duration {
+	return time.Duration(r.rand.Int63n(86400)) * time.Second
+}
+
+// Decimal produces a random decimal value with two fractional digits
+// formatted as a string.
+func (r *Random) Decimal() string {
+	n := r.rand.Int63n(10000000)
+	return fmt.Sprintf("%d.%02d", n/100, n%100)
+}
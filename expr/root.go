@@ -227,9 +227,43 @@ func (r *RootExpr) Validate() error {
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
 	}
+	for _, t := range r.Types {
+		verr.Merge(validateBuilder(t))
+	}
+	for _, t := range r.ResultTypes {
+		verr.Merge(validateBuilder(t))
+	}
 	return &verr
 }
 
+// validateBuilder makes sure that the constructor generated for the given user
+// type when it sets the type:generate:builder meta can initialize the type
+// attributes with their default values. The constructor renders the default
+// values as Go literals which cannot represent objects.
+func validateBuilder(ut UserType) *eval.ValidationErrors {
+	if _, ok := ut.Attribute().Meta["type:generate:builder"]; !ok {
+		return nil
+	}
+	verr := new(eval.ValidationErrors)
+	var check func(dt DataType)
+	check = func(dt DataType) {
+		o := AsObject(dt)
+		if o == nil {
+			return
+		}
+		for _, nat := range *o {
+			if nat.Attribute.DefaultValue != nil && hasObject(nat.Attribute.Type) {
+				verr.Add(ut, "default value of field %q is not supported, the constructor generated with the type:generate:builder meta cannot initialize objects", nat.Name)
+			}
+		}
+	}
+	check(ut)
+	for _, b := range ut.Attribute().Bases {
+		check(b)
+	}
+	return verr
+}
+
 // Finalize finalizes the server expressions.
 func (r *RootExpr) Finalize() {
 	if r.API == nil {
@@ -8,8 +8,27 @@ import (
 )
 
 func TestRootExprValidate(t *testing.T) {
+	var (
+		child = &UserTypeExpr{
+			TypeName:      "Child",
+			AttributeExpr: &AttributeExpr{Type: &Object{{Name: "name", Attribute: &AttributeExpr{Type: String}}}},
+		}
+		builder = &UserTypeExpr{
+			TypeName: "Builder",
+			AttributeExpr: &AttributeExpr{
+				Type: &Object{
+					{Name: "child", Attribute: &AttributeExpr{Type: child, DefaultValue: map[string]interface{}{"name": "foo"}}},
+					{Name: "children", Attribute: &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: child}}, DefaultValue: []interface{}{}}},
+					{Name: "blob", Attribute: &AttributeExpr{Type: Bytes, DefaultValue: "foo"}},
+					{Name: "any", Attribute: &AttributeExpr{Type: Any, DefaultValue: 1}},
+				},
+				Meta: MetaExpr{"type:generate:builder": nil},
+			},
+		}
+	)
 	cases := map[string]struct {
 		api      *APIExpr
+		types    []UserType
 		expected *eval.ValidationErrors
 	}{
 		"no error": {
@@ -26,11 +45,22 @@ func TestRootExprValidate(t *testing.T) {
 				Errors: []error{fmt.Errorf("Missing API declaration")},
 			},
 		},
+		"builder object defaults": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{child, builder},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf(`default value of field "child" is not supported, the constructor generated with the type:generate:builder meta cannot initialize objects`),
+					fmt.Errorf(`default value of field "children" is not supported, the constructor generated with the type:generate:builder meta cannot initialize objects`),
+				},
+			},
+		},
 	}
 
 	for k, tc := range cases {
 		e := RootExpr{
-			API: tc.api,
+			API:   tc.api,
+			Types: tc.types,
 		}
 		if actual := e.Validate().(*eval.ValidationErrors); len(tc.expected.Errors) != len(actual.Errors) {
 			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected.Errors), len(actual.Errors))
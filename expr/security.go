@@ -22,6 +22,9 @@ const (
 	JWTKind
 	// NoKind means to have no security for this endpoint.
 	NoKind
+	// MTLSKind means a mutual TLS security scheme where clients
+	// authenticate using certificates.
+	MTLSKind
 )
 
 // FlowKind is a type of OAuth2 flow.
@@ -65,7 +68,7 @@ type (
 		// Name refers to a header or parameter name, based on In's
 		// value.
 		Name string
-		// Scopes lists the Basic, APIKey, JWT or OAuth2 scopes.
+		// Scopes lists the Basic, APIKey, JWT, OAuth2 or MTLS scopes.
 		Scopes []*ScopeExpr
 		// Flows determine the oauth2 flows supported by this scheme.
 		Flows []*FlowExpr
@@ -94,6 +97,20 @@ type (
 		// Description is the description of the scope.
 		Description string
 	}
+
+	// AuthorizationExpr defines an authorization requirement. Authorization
+	// requirements are checked once the request has been authenticated and
+	// before the service method is invoked.
+	AuthorizationExpr struct {
+		// Roles lists the roles the caller must have.
+		Roles []string
+		// Scopes lists the scopes the caller must have.
+		Scopes []string
+		// Attributes lists the names of the payload attributes whose
+		// values are given to the authorizer, e.g. to check that the
+		// caller owns the resource identified by the attribute.
+		Attributes []string
+	}
 )
 
 // EvalName returns the generic definition name used in error messages.
@@ -117,6 +134,15 @@ func DupRequirement(req *SecurityExpr) *SecurityExpr {
 	return dup
 }
 
+// DupAuthorization creates a copy of the given authorization requirement.
+func DupAuthorization(a *AuthorizationExpr) *AuthorizationExpr {
+	return &AuthorizationExpr{
+		Roles:      a.Roles,
+		Scopes:     a.Scopes,
+		Attributes: a.Attributes,
+	}
+}
+
 // DupScheme creates a copy of the given scheme expression.
 func DupScheme(sch *SchemeExpr) *SchemeExpr {
 	dup := SchemeExpr{
@@ -131,6 +157,33 @@ func DupScheme(sch *SchemeExpr) *SchemeExpr {
 	return &dup
 }
 
+// EvalName returns the generic definition name used in error messages.
+func (a *AuthorizationExpr) EvalName() string {
+	return "Authorize"
+}
+
+// Validate makes sure the attributes referenced by the authorization
+// requirement are defined in the given method payload.
+func (a *AuthorizationExpr) Validate(m *MethodExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if len(a.Roles) == 0 && len(a.Scopes) == 0 && len(a.Attributes) == 0 {
+		verr.Add(a, "authorization requirement of method %q of service %q is empty, use Role, Scope or Resource to define one", m.Name, m.Service.Name)
+	}
+	if len(a.Attributes) == 0 {
+		return verr
+	}
+	if !IsObject(m.Payload.Type) {
+		verr.Add(a, "method %q of service %q defines authorization attributes but its payload is not an object", m.Name, m.Service.Name)
+		return verr
+	}
+	for _, n := range a.Attributes {
+		if findAttribute(m.Payload, n) == nil {
+			verr.Add(a, "authorization attribute %q not found in payload of method %q of service %q", n, m.Name, m.Service.Name)
+		}
+	}
+	return verr
+}
+
 // Type returns the type of the scheme.
 func (s *SchemeExpr) Type() string {
 	switch s.Kind {
@@ -142,6 +195,8 @@ func (s *SchemeExpr) Type() string {
 		return "APIKey"
 	case JWTKind:
 		return "JWT"
+	case MTLSKind:
+		return "MTLS"
 	default:
 		panic(fmt.Sprintf("unknown scheme kind: %#v", s.Kind)) // bug
 	}
@@ -215,9 +270,34 @@ func (k SchemeKind) String() string {
 		return "JWT"
 	case OAuth2Kind:
 		return "OAuth2"
+	case MTLSKind:
+		return "MTLS"
 	case NoKind:
 		return "None"
 	default:
 		panic("unknown kind") // bug
 	}
 }
+
+// findAttribute returns the attribute with the given name defined by the
+// object attribute p or by one of its bases. Unlike AttributeExpr.Find it does
+// not look in references as these do not add attributes to p. Bases are
+// traversed explicitly because p may not have been finalized yet.
+func findAttribute(p *AttributeExpr, name string) *AttributeExpr {
+	if att := AsObject(p.Type).Attribute(name); att != nil {
+		return att
+	}
+	for _, base := range p.Bases {
+		ut, ok := base.(UserType)
+		if !ok {
+			continue
+		}
+		if att := findAttribute(ut.Attribute(), name); att != nil {
+			return att
+		}
+	}
+	if ut, ok := p.Type.(UserType); ok {
+		return findAttribute(ut.Attribute(), name)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -26,6 +27,19 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Authorizations contains the authorization requirements that
+		// apply to all the service methods. Authenticated requests must
+		// satisfy at least one requirement to be authorized.
+		Authorizations []*AuthorizationExpr
+		// RequestBodyLimit is the maximum size in bytes of the request
+		// bodies of the service methods, zero means the API default.
+		RequestBodyLimit int64
+		// RequestTimeout is the maximum duration of the requests handled
+		// by the service methods, zero means the API default.
+		RequestTimeout time.Duration
+		// CORS lists the CORS policies of the service methods, one per
+		// allowed origin. Empty means the API policies.
+		CORS []*CORSExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
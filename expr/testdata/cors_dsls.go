@@ -0,0 +1,64 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSDSL = func() {
+	API("CORS", func() {
+		Origin("*")
+	})
+	Service("APIDefaultsService", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("ServiceDefaultsService", func() {
+		Origin("https://goa.design")
+		Origin("/^http://localhost:[0-9]+$/")
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodOverride", func() {
+			Origin("https://*.goa.design", func() {
+				AllowCredentials()
+			})
+			HTTP(func() {
+				GET("/override")
+			})
+		})
+	})
+}
+
+var CORSOptionsConflictDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Origin("*")
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("Options", func() {
+			HTTP(func() {
+				OPTIONS("/items")
+			})
+		})
+	})
+}
+
+var CORSCredentialsAnyOriginDSL = func() {
+	Service("Service", func() {
+		Origin("*", func() {
+			AllowCredentials()
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var SparseFieldsetsDSL = func() {
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("href", String)
+			Required("id")
+		})
+	})
+	Service("SparseFieldsetsService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Account)
+			SparseFieldsets()
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
+
+var SparseFieldsetsViewDSL = func() {
+	var Account = ResultType("application/vnd.account.view", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("href", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("href")
+		})
+		View("tiny", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+	})
+	Service("SparseFieldsetsViewService", func() {
+		Method("Show", func() {
+			Result(Account, func() {
+				View("tiny")
+			})
+			SparseFieldsets()
+		})
+	})
+}
+
+var SparseFieldsetsViewsDSL = func() {
+	var Account = ResultType("application/vnd.account.views", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("href", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("href")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("SparseFieldsetsViewsService", func() {
+		Method("Show", func() {
+			Result(Account)
+			SparseFieldsets()
+		})
+	})
+}
+
+var SharedTypeSparseFieldsetsDSL = func() {
+	var ID = Type("ID", func() {
+		Attribute("id", String)
+	})
+	var Account = ResultType("application/vnd.shared.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+		})
+	})
+	Service("SharedTypeSparseFieldsetsService", func() {
+		Method("Show", func() {
+			Payload(ID)
+			Result(Account)
+			SparseFieldsets()
+		})
+		Method("Delete", func() {
+			Payload(ID)
+		})
+	})
+}
+
+var InvalidSparseFieldsetsDSL = func() {
+	Service("InvalidSparseFieldsetsService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("fields", String)
+				Required("fields")
+			})
+			Result(func() {
+				Attribute("name", String)
+			})
+			SparseFieldsets()
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
+
+var RequestLimitsDSL = func() {
+	API("RequestLimits", func() {
+		RequestBodyLimit(1024)
+		RequestTimeout(time.Minute)
+	})
+	Service("APIDefaultsService", func() {
+		Method("Method", func() {
+			Payload(String)
+		})
+	})
+	Service("ServiceDefaultsService", func() {
+		RequestBodyLimit(2048)
+		RequestTimeout(time.Second)
+		Method("Method", func() {
+			Payload(String)
+		})
+		Method("MethodOverride", func() {
+			RequestBodyLimit(4096)
+			RequestTimeout(time.Hour)
+			Payload(String)
+		})
+	})
+}
@@ -71,3 +71,62 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var InvalidAuthorizationDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	var Tenant = Type("Tenant", func() {
+		Attribute("tenant_id", String)
+	})
+	Service("InvalidAuthorizationService", func() {
+		Security(JWT)
+		Authorize(func() {
+			Resource("not_found")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String)
+			})
+		})
+		Method("ReferenceMethod", func() {
+			Authorize(func() {
+				Resource("tenant_id")
+			})
+			Payload(func() {
+				Reference(Tenant)
+				Token("token", String)
+				Attribute("not_found", String)
+			})
+		})
+		Method("ExtendMethod", func() {
+			Authorize(func() {
+				Resource("tenant_id")
+			})
+			Payload(func() {
+				Extend(Tenant)
+				Token("token", String)
+				Attribute("not_found", String)
+			})
+		})
+		Method("EmptyMethod", func() {
+			Authorize(func() {})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
+
+var InvalidResultBuilderDSL = func() {
+	var RT = ResultType("application/vnd.builder", func() {
+		Attributes(func() {
+			Attribute("name", String)
+		})
+		Meta("type:generate:builder")
+	})
+	Service("InvalidResultBuilderService", func() {
+		Method("Method", func() {
+			Result(RT)
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var CursorPaginationDSL = func() {
+	Service("CursorPaginationService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Field(1, "filter", String)
+			})
+			Result(func() {
+				Field(1, "items", ArrayOf(String))
+			})
+			Paginated(CursorPagination)
+			HTTP(func() {
+				GET("/")
+				Param("filter")
+			})
+		})
+	})
+}
+
+var PagePaginationDSL = func() {
+	var Page = ResultType("application/vnd.page", func() {
+		Attributes(func() {
+			Attribute("items", ArrayOf(String))
+			Attribute("total", Int)
+		})
+		View("default", func() {
+			Attribute("items")
+		})
+	})
+	Service("PagePaginationService", func() {
+		Method("List", func() {
+			Result(Page)
+			Paginated(PagePagination)
+			HTTP(func() {
+				GET("/")
+				Header("limit:X-Limit")
+			})
+		})
+	})
+}
+
+var InvalidPaginationDSL = func() {
+	Service("InvalidPaginationService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", Int)
+				Attribute("limit", Int, func() {
+					Default(10)
+				})
+				Required("cursor")
+			})
+			Result(String)
+			Paginated(CursorPagination)
+		})
+		Method("ListPages", func() {
+			Payload(func() {
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("next_page", String)
+			})
+			Paginated(PagePagination)
+		})
+	})
+}
+
+var SharedTypePaginationDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("filter", String)
+	})
+	var Page = ResultType("application/vnd.shared.page", func() {
+		Attributes(func() {
+			Attribute("items", ArrayOf(String))
+		})
+	})
+	Service("SharedTypePaginationService", func() {
+		Method("List", func() {
+			Payload(Filter)
+			Result(Page)
+			Paginated(CursorPagination)
+		})
+		Method("Count", func() {
+			Payload(Filter)
+			Result(Page)
+		})
+	})
+}
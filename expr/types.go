@@ -3,6 +3,7 @@ package expr
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -119,6 +120,12 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// TimeKind represents a point in time.
+	TimeKind
+	// DurationKind represents an elapsed time.
+	DurationKind
+	// DecimalKind represents an arbitrary-precision decimal number.
+	DecimalKind
 )
 
 const (
@@ -157,6 +164,19 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = Primitive(AnyKind)
+
+	// Time is the type for a point in time (time.Time in Go). Time
+	// values are encoded as RFC3339 strings.
+	Time = Primitive(TimeKind)
+
+	// Duration is the type for an elapsed time (time.Duration in Go). Duration
+	// values are encoded as integer numbers of nanoseconds except in gRPC
+	// messages where they use google.protobuf.Duration.
+	Duration = Primitive(DurationKind)
+
+	// Decimal is the type for an arbitrary-precision decimal number
+	// (goa.Decimal in Go). Decimal values are encoded as strings.
+	Decimal = Primitive(DecimalKind)
 )
 
 // Built-in composite types
@@ -237,6 +257,57 @@ func IsPrimitive(dt DataType) bool {
 	}
 }
 
+// UnwrapPrimitive replaces the type of att with the underlying primitive type
+// if it is a primitive user type and merges the user type validations into
+// the attribute validations. UnwrapPrimitive also unwraps the element types
+// of arrays and maps. It is used to build the transport and projected types
+// which hold the primitive values, the generated code converts them to and
+// from the service types.
+func UnwrapPrimitive(att *AttributeExpr) {
+	switch actual := att.Type.(type) {
+	case UserType:
+		if !IsPrimitive(actual) {
+			return
+		}
+		uatt := actual.Attribute()
+		att.Type = uatt.Type
+		if uatt.Validation != nil {
+			if att.Validation == nil {
+				att.Validation = uatt.Validation.Dup()
+			} else {
+				att.Validation = att.Validation.Dup()
+				att.Validation.Merge(uatt.Validation)
+			}
+		}
+		if att.Description == "" {
+			att.Description = uatt.Description
+		}
+		if att.UserExamples == nil {
+			att.UserExamples = uatt.UserExamples
+		}
+		UnwrapPrimitive(att)
+	case *Array:
+		UnwrapPrimitive(actual.ElemType)
+	case *Map:
+		UnwrapPrimitive(actual.KeyType)
+		UnwrapPrimitive(actual.ElemType)
+	}
+}
+
+// HasPrimitiveUserType returns true if dt is a primitive user type or an
+// array or map of primitive user types.
+func HasPrimitiveUserType(dt DataType) bool {
+	switch actual := dt.(type) {
+	case UserType:
+		return IsPrimitive(actual)
+	case *Array:
+		return HasPrimitiveUserType(actual.ElemType.Type)
+	case *Map:
+		return HasPrimitiveUserType(actual.KeyType.Type) || HasPrimitiveUserType(actual.ElemType.Type)
+	}
+	return false
+}
+
 // Equal compares the types recursively and returns true if they are equal. Two
 // types are equal if:
 //
@@ -347,6 +418,12 @@ func (p Primitive) Name() string {
 		return "bytes"
 	case Any:
 		return "any"
+	case Time:
+		return "time"
+	case Duration:
+		return "duration"
+	case Decimal:
+		return "decimal"
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -363,15 +440,20 @@ func (p Primitive) IsCompatible(val interface{}) bool {
 	case int, int8, int16, int32, uint, uint8, uint16, uint32:
 		return p == Int || p == Int32 || p == Int64 ||
 			p == UInt || p == UInt32 || p == UInt64 ||
-			p == Float32 || p == Float64
+			p == Float32 || p == Float64 || p == Duration || p == Decimal
 	case int64, uint64:
-		return p == Int64 || p == UInt64 || p == Float32 || p == Float64
+		return p == Int64 || p == UInt64 || p == Float32 || p == Float64 ||
+			p == Duration || p == Decimal
 	case float32, float64:
-		return p == Float32 || p == Float64
+		return p == Float32 || p == Float64 || p == Decimal
 	case string:
-		return p == String || p == Bytes
+		return p == String || p == Bytes || p == Time || p == Duration || p == Decimal
 	case []byte:
 		return p == Bytes
+	case time.Time:
+		return p == Time
+	case time.Duration:
+		return p == Duration
 	}
 	return false
 }
@@ -402,6 +484,12 @@ func (p Primitive) Example(r *Random) interface{} {
 		return r.String()
 	case Bytes:
 		return []byte(r.String())
+	case Time:
+		return r.Time().Format(time.RFC3339)
+	case Duration:
+		return int64(r.Duration())
+	case Decimal:
+		return r.Decimal()
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -702,7 +790,12 @@ func toReflectType(dtype DataType) reflect.Type {
 		return reflect.TypeOf("")
 	case BytesKind:
 		return reflect.TypeOf([]byte{})
-	case ObjectKind, UserTypeKind, ResultTypeKind:
+	case UserTypeKind, ResultTypeKind:
+		if ut := dtype.(UserType); IsPrimitive(ut.Attribute().Type) {
+			return toReflectType(ut.Attribute().Type)
+		}
+		return reflect.TypeOf(map[string]interface{}{})
+	case ObjectKind:
 		return reflect.TypeOf(map[string]interface{}{})
 	case ArrayKind:
 		return reflect.SliceOf(toReflectType(dtype.(*Array).ElemType.Type))
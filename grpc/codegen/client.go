@@ -43,15 +43,17 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
 				codegen.GoaNamedImport("grpc/pb", "goapb"),
+				codegen.GoaImport("security"),
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
 			}),
 		}
 		sections = append(sections, &codegen.SectionTemplate{
-			Name:   "client-struct",
-			Source: clientStructT,
-			Data:   data,
+			Name:    "client-struct",
+			Source:  clientStructT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"hasCredentials": hasCredentials},
 		})
 		for _, e := range data.Endpoints {
 			if e.ClientStream != nil {
@@ -106,6 +108,13 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				}
 			}
 		}
+		for _, e := range data.Endpoints {
+			if e.PayloadRef != "" {
+				if s := service.ClientCredentialsSection(e.Method, e.PayloadRef); s != nil {
+					sections = append(sections, s)
+				}
+			}
+		}
 	}
 	return &codegen.File{Path: fpath, SectionTemplates: sections}
 }
@@ -165,6 +174,17 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 	return &codegen.File{Path: fpath, SectionTemplates: sections}
 }
 
+// hasCredentials returns true if at least one of the service endpoints
+// payloads defines credentials that may be obtained from a credential source.
+func hasCredentials(data *ServiceData) bool {
+	for _, e := range data.Endpoints {
+		if len(e.Method.Requirements.Credentials()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // isBearer returns true if the security scheme uses a Bearer scheme.
 func isBearer(schemes []*service.SchemeData) bool {
 	for _, s := range schemes {
@@ -181,6 +201,11 @@ func isBearer(schemes []*service.SchemeData) bool {
 // input: ServiceData
 const clientStructT = `{{ printf "%s lists the service endpoint gRPC clients." .ClientStruct | comment }}
 type {{ .ClientStruct }} struct {
+{{- if hasCredentials . }}
+	// Credentials provides the credentials of the requests made to the
+	// secured endpoints whose payloads do not set them if not nil.
+	Credentials security.CredentialSource
+{{ end }}
 	grpccli {{ .PkgName }}.{{ .ClientInterface }}
 	opts []grpc.CallOption
 }
@@ -200,6 +225,14 @@ func New{{ .ClientStruct }}(cc *grpc.ClientConn, opts ...grpc.CallOption) *{{ .C
 const clientEndpointInitT = `{{ printf "%s calls the %q function in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
 func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
+	{{- if .Method.Requirements.Credentials }}
+		if c.Credentials != nil {
+			var err error
+			if v, err = set{{ .Method.VarName }}Credentials(ctx, c.Credentials, v); err != nil {
+				return nil, err
+			}
+		}
+	{{- end }}
 		inv := goagrpc.NewInvoker(
 			Build{{ .Method.VarName }}Func(c.grpccli, c.opts...),
 			{{ if .PayloadRef }}Encode{{ .Method.VarName }}Request{{ else }}nil{{ end }},
@@ -401,7 +434,9 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md *
 					{{- if eq .Type.Name "bytes" }} string(
 					{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
 					{{- end }}
+					{{- with .FieldConv }} {{ . }}({{ end }}
 					{{- if .Pointer }}*{{ end }}payload{{ if .FieldName }}.{{ .FieldName }}{{ end }}
+					{{- if .FieldConv }}){{ end }}
 					{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 					{{- end }})
 			{{- if (and (eq .Name "Authorization") (isBearer $.MetadataSchemes)) }}
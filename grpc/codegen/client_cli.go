@@ -61,6 +61,7 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 		{Path: "os"},
 		{Path: "strconv"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("cli", "goacli"),
 		codegen.GoaNamedImport("grpc", "goagrpc"),
 		{Path: "google.golang.org/grpc", Name: "grpc"},
 	}
@@ -95,6 +96,7 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 				data,
 			},
 		},
+		cli.CommandsSection(data),
 	}
 	for _, cmd := range data {
 		sections = append(sections, cli.CommandUsage(cmd))
@@ -113,6 +115,7 @@ func payloadBuilders(genpkg string, svc *expr.GRPCServiceExpr, data *cli.Command
 		{Path: "encoding/json"},
 		{Path: "fmt"},
 		{Path: "strconv"},
+		codegen.GoaNamedImport("grpc", "goagrpc"),
 		{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 		{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
 	}
@@ -153,9 +156,12 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 		pInitArgs[i] = &cli.PayloadInitArgData{
 			Name:      arg.Name,
 			FieldName: arg.FieldName,
+			Conv:      arg.Conv,
 		}
 
 		f := cli.NewFlagData(e.ServiceName, e.Method.Name, arg.Name, arg.TypeName, arg.Description, arg.Required, arg.Example)
+		f.Values = cli.FlagValues(arg.Enum)
+		f.Credential = cli.FlagCredential(e.Method, arg.FieldName)
 		flags[i] = f
 		params[i] = f.FullName
 		code, chek := cli.FieldLoadCode(f, arg.Name, arg.TypeName, arg.Validate, arg.DefaultValue)
@@ -45,6 +45,34 @@ func TestClientEndpointInit(t *testing.T) {
 	}
 }
 
+func TestClientCredentials(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Section string
+		Code    string
+	}{
+		{"client-endpoint-init", "client-endpoint-init", testdata.MessageWithSecurityAttrsClientEndpointInitCode},
+		{"client-credentials", "client-credentials", testdata.MessageWithSecurityAttrsClientCredentialsCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, testdata.MessageWithSecurityAttrsDSL)
+			fs := ClientFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[0].Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
 func TestRequestEncoder(t *testing.T) {
 	cases := []struct {
 		Name string
@@ -66,6 +66,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 			{Path: "os"},
 			{Path: "time"},
 			codegen.GoaImport(""),
+			codegen.GoaNamedImport("cli", "goacli"),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			{Path: rootPath, Name: apiPkg},
 			{Path: path.Join(genpkg, "grpc", "cli", svrdata.Dir), Name: "cli"},
@@ -102,6 +103,10 @@ func grpcUsageCommands() string {
 func grpcUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func grpcCommands() []*goacli.Command {
+	return cli.Commands()
+}
 {{- end }}
 `
 )
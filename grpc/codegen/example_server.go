@@ -8,6 +8,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -44,15 +45,19 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 	{
 		specs = []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "crypto/tls"},
+			{Path: "crypto/x509"},
+			{Path: "io/ioutil"},
 			{Path: "log"},
-			{Path: "net"},
 			{Path: "net/url"},
 			{Path: "os"},
-			{Path: "sync"},
+			{Path: "time"},
+			codegen.GoaImport("lifecycle"),
 			codegen.GoaImport("middleware"),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			codegen.GoaNamedImport("grpc/middleware", "grpcmdlwr"),
 			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/credentials"},
 			{Path: "google.golang.org/grpc/reflection"},
 			{Path: "github.com/grpc-ecosystem/go-grpc-middleware", Name: "grpcmiddleware"},
 		}
@@ -93,10 +98,14 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		sections []*codegen.SectionTemplate
 	)
 	{
-		var svcdata []*ServiceData
+		var (
+			svcdata []*ServiceData
+			svcs    []*service.Data
+		)
 		for _, svc := range svr.Services {
 			if data := GRPCServices.Get(svc); data != nil {
 				svcdata = append(svcdata, data)
+				svcs = append(svcs, data.Service)
 			}
 		}
 		sections = []*codegen.SectionTemplate{
@@ -120,7 +129,9 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Name:   "server-grpc-register",
 				Source: grpcRegisterSvrT,
 				Data: map[string]interface{}{
-					"Services": svcdata,
+					"Services":       svcdata,
+					"MTLS":           service.NeedMTLS(svcs),
+					"MaxRecvMsgSize": maxRecvMsgSize(svcdata),
 				},
 				FuncMap: map[string]interface{}{
 					"goify":      codegen.Goify,
@@ -152,10 +163,38 @@ func needStream(data []*ServiceData) bool {
 	return false
 }
 
+// defaultMaxRecvMsgSize is the default maximum size of the messages received
+// by gRPC servers.
+const defaultMaxRecvMsgSize = 4 << 20
+
+// maxRecvMsgSize returns the maximum size of the messages received by the
+// server so that the messages that exceed the request body limit of all the
+// methods are rejected before being decoded. It returns 0 if the default
+// maximum size applies. The request body limits are enforced by the generated
+// servers once the messages are decoded.
+func maxRecvMsgSize(data []*ServiceData) int64 {
+	var max int64
+	for _, svc := range data {
+		for _, e := range svc.Endpoints {
+			limit := e.Method.RequestBodyLimit
+			if limit == 0 {
+				limit = defaultMaxRecvMsgSize
+			}
+			if limit > max {
+				max = limit
+			}
+		}
+	}
+	if max == defaultMaxRecvMsgSize {
+		return 0
+	}
+	return max
+}
+
 const (
 	// input: map[string]interface{}{"Services":[]*ServiceData}
-	grpcSvrStartT = `{{ comment "handleGRPCServer starts configures and starts a gRPC server on the given URL. It shuts down the server if any error is received in the error channel." }}
-func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	grpcSvrStartT = `{{ comment "handleGRPCServer configures a gRPC server on the given URL and adds it to the group that runs the servers." }}
+func handleGRPCServer(grp *lifecycle.Group, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, logger *log.Logger, debug bool) {
 `
 
 	grpcSvrLoggerT = `
@@ -190,10 +229,39 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "MTLS": bool}
 	grpcRegisterSvrT = `
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+{{ if .MTLS }}	// Require and verify client certificates as required by the mutual TLS
+	// security scheme. Change the paths to the CA and server certificates
+	// as required.
+	var creds credentials.TransportCredentials
+	{
+		cert, err := tls.LoadX509KeyPair("server.crt", "server.key")
+		if err != nil {
+			logger.Fatalf("failed to load server certificate: %s", err)
+		}
+		ca, err := ioutil.ReadFile("ca.crt")
+		if err != nil {
+			logger.Fatalf("failed to read CA certificate: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			logger.Fatalf("failed to load CA certificate")
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+		})
+	}
+
+{{ end }}	// Initialize gRPC server with the timeouts and the middleware. Change
+	// the timeouts as required by your service.
+	opts := lifecycle.GRPCServerOptions(lifecycle.Timeouts{Read: 30 * time.Second, Idle: 2 * time.Minute})
+	opts = append(opts,
+	{{- if .MTLS }}
+		grpc.Creds(creds),
+	{{- end }}
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
@@ -204,7 +272,13 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 			grpcmdlwr.StreamServerLog(adapter),
 		),
 	{{- end }}
+	{{- if .MaxRecvMsgSize }}
+		// Reject the messages that exceed the request body limits before
+		// decoding them.
+		grpc.MaxRecvMsgSize({{ .MaxRecvMsgSize }}),
+	{{- end }}
 	)
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	{{- range .Services }}
@@ -224,24 +298,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 
 	// input: map[string]interface{}{"Services":[]*ServiceData}
 	grpcSvrEndT = `
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		{{ comment "Start gRPC server in a separate goroutine." }}
-		go func() {
-			lis, err := net.Listen("tcp", u.Host)
-			if err != nil {
-				errc <- err
-			}
-			logger.Printf("gRPC server listening on %q", u.Host)
-			errc <- srv.Serve(lis)
-		}()
-
-		<-ctx.Done()
+	{{ comment "Add the server to the group that starts it and shuts it down gracefully." }}
+	grp.Add(lifecycle.GRPC(srv, u.Host))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
-  }()
+	})
+	logger.Printf("gRPC server listening on %q", u.Host)
 }
 `
 )
@@ -21,6 +21,7 @@ func TestExampleServerFiles(t *testing.T) {
 		{"no-server", ctestdata.NoServerDSL, testdata.NoServerServerHandleCode},
 		{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL, testdata.ServerHostingServiceSubsetServerHandleCode},
 		{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ServerHostingMultipleServicesServerHandleCode},
+		{"server-with-body-limit", testdata.UnaryRPCWithLimitsDSL, testdata.ServerWithBodyLimitServerHandleCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
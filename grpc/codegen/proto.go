@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 	goa "goa.design/goa/v3/pkg"
 )
@@ -47,6 +48,7 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Data: map[string]interface{}{
 				"ProtoVersion": ProtoVersion,
 				"Pkg":          codegen.SnakeCase(codegen.Goify(svcName, false)),
+				"Imports":      protoImports(data.Messages),
 			},
 		},
 		// service definition
@@ -65,6 +67,46 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	}
 }
 
+// protoImports returns the well-known protocol buffer definitions imported by
+// the given messages.
+func protoImports(msgs []*service.UserTypeData) []string {
+	var ts, ds, fm bool
+	var walk func(att *expr.AttributeExpr)
+	walk = func(att *expr.AttributeExpr) {
+		if isFieldMask(att) {
+			fm = true
+			return
+		}
+		switch dt := att.Type.(type) {
+		case *expr.Object:
+			for _, nat := range *dt {
+				walk(nat.Attribute)
+			}
+		case *expr.Array:
+			walk(dt.ElemType)
+		case *expr.Map:
+			walk(dt.ElemType)
+		case expr.Primitive:
+			ts = ts || dt.Kind() == expr.TimeKind
+			ds = ds || dt.Kind() == expr.DurationKind
+		}
+	}
+	for _, m := range msgs {
+		walk(m.Type.Attribute())
+	}
+	var imports []string
+	if ds {
+		imports = append(imports, "google/protobuf/duration.proto")
+	}
+	if fm {
+		imports = append(imports, "google/protobuf/field_mask.proto")
+	}
+	if ts {
+		imports = append(imports, "google/protobuf/timestamp.proto")
+	}
+	return imports
+}
+
 func protoc(path string) error {
 	dir := filepath.Dir(path)
 	os.MkdirAll(dir, 0777)
@@ -97,6 +139,11 @@ syntax = {{ printf "%q" .ProtoVersion }};
 package {{ .Pkg }};
 
 option go_package = "{{ .Pkg }}pb";
+{{- if .Imports }}
+{{ range .Imports }}
+import {{ printf "%q" . }};
+{{- end }}
+{{- end }}
 `
 
 	// input: ServiceData
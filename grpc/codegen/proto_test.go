@@ -62,6 +62,7 @@ func TestMessageDefSection(t *testing.T) {
 		{"primitive", testdata.MessagePrimitiveDSL, testdata.MessagePrimitiveCode},
 		{"with-metadata", testdata.MessageWithMetadataDSL, testdata.MessageWithMetadataCode},
 		{"with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.MessageWithSecurityAttrsCode},
+		{"with-field-mask", testdata.MessageWithFieldMaskDSL, testdata.MessageWithFieldMaskCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
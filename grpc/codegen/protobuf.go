@@ -53,12 +53,17 @@ func protoBufTypeContext(pkg string, scope *codegen.NameScope) *codegen.Attribut
 // NOTE: Protocol buffer does not provide native support for nested
 // arrays/maps (See grpc/docs/FAQ.md)
 //
+// User types with a primitive underlying type (such as enums defined with the
+// "type:enum" meta) are represented using the underlying protocol buffer
+// type.
+//
 // makeProtoBufMessage ensures the resulting attribute is a user type. If the
 // given attribute type is a primitive, array, or a map, it wraps the given
 // attribute under an attribute with name "field" and RPC tag number 1. For,
 // nested arrays/maps, the inner array/map is wrapped into a user type.
 func makeProtoBufMessage(att *expr.AttributeExpr, tname string, sd *ServiceData) *expr.AttributeExpr {
 	att = expr.DupAtt(att)
+	expr.UnwrapPrimitive(att)
 	switch dt := att.Type.(type) {
 	case expr.Primitive:
 		wrapAttr(att, tname, sd)
@@ -105,6 +110,15 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 		}
 	}
 	switch dt := att.Type.(type) {
+	case expr.Primitive:
+		if dt.Kind() == expr.DecimalKind {
+			// Decimal values are encoded as strings, validate them so that
+			// invalid literals are reported instead of decoding to 0.
+			if att.Validation == nil {
+				att.Validation = &expr.ValidationExpr{}
+			}
+			att.Validation.Format = expr.FormatDecimal
+		}
 	case expr.UserType:
 		var s map[string]struct{}
 		if len(seen) > 0 {
@@ -122,15 +136,40 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 		}
 		makeProtoBufMessageR(dt.Attribute(), tname, sd, seen...)
 	case *expr.Array:
+		expr.UnwrapPrimitive(dt.ElemType)
 		makeProtoBufMessageR(dt.ElemType, tname, sd, seen...)
 		wrap(dt.ElemType, *tname)
 	case *expr.Map:
 		// need not worry about map keys because protocol buffer supports
 		// only primitives as map keys.
+		expr.UnwrapPrimitive(dt.KeyType)
+		expr.UnwrapPrimitive(dt.ElemType)
 		makeProtoBufMessageR(dt.ElemType, tname, sd, seen...)
 		wrap(dt.ElemType, *tname)
 	case *expr.Object:
 		for _, nat := range *dt {
+			expr.UnwrapPrimitive(nat.Attribute)
+			// protocol buffer messages have no notion of null values
+			delete(nat.Attribute.Meta, "nullable")
+			if isFieldMask(nat.Attribute) {
+				// The selected fields are validated against the rendered
+				// view by the service endpoint. The example matches the JSON
+				// representation of the FieldMask message.
+				var paths []interface{}
+				if arr := expr.AsArray(nat.Attribute.Type); arr != nil && arr.ElemType.Validation != nil {
+					paths = arr.ElemType.Validation.Values
+				}
+				if len(paths) > 1 {
+					paths = paths[:1]
+				}
+				nat.Attribute.Type = &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String}}
+				nat.Attribute.UserExamples = []*expr.ExampleExpr{{Value: map[string]interface{}{"paths": paths}}}
+				continue
+			}
+			if nat.Attribute.Type.Kind() == expr.DecimalKind && !att.IsRequired(nat.Name) {
+				// Unset fields hold the empty string.
+				nat.Attribute.ZeroValue = ""
+			}
 			makeProtoBufMessageR(nat.Attribute, tname, sd, seen...)
 		}
 	}
@@ -209,6 +248,9 @@ func protoBufGoTypeName(att *expr.AttributeExpr, s *codegen.NameScope) string {
 // the given package name for the given attribute generated after compiling
 // the proto file (in *.pb.go).
 func protoBufGoFullTypeName(att *expr.AttributeExpr, pkg string, s *codegen.NameScope) string {
+	if isFieldMask(att) {
+		return "field_mask.FieldMask"
+	}
 	switch actual := att.Type.(type) {
 	case expr.UserType, expr.CompositeExpr:
 		return protoBufFullMessageName(att, pkg, s)
@@ -231,6 +273,9 @@ func protoBufGoFullTypeName(att *expr.AttributeExpr, pkg string, s *codegen.Name
 // which matches the data structure definition (the part that comes after
 // `message foo`). The message is defined using the proto3 syntax.
 func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
+	if isFieldMask(att) {
+		return "google.protobuf.FieldMask"
+	}
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
 		return protoBufNativeMessageTypeName(att.Type)
@@ -272,7 +317,7 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 // (in *.pb.go) for the given attribute.
 func protoBufGoFullTypeRef(att *expr.AttributeExpr, pkg string, s *codegen.NameScope) string {
 	name := protoBufGoFullTypeName(att, pkg, s)
-	if expr.IsObject(att.Type) {
+	if expr.IsObject(att.Type) || isFieldMask(att) {
 		return "*" + name
 	}
 	return name
@@ -349,6 +394,12 @@ func protoBufNativeMessageTypeName(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "bytes"
+	case expr.TimeKind:
+		return "google.protobuf.Timestamp"
+	case expr.DurationKind:
+		return "google.protobuf.Duration"
+	case expr.DecimalKind:
+		return "string"
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T", t)) // bug
 	}
@@ -381,11 +432,25 @@ func protoBufNativeGoTypeName(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "[]byte"
+	case expr.TimeKind:
+		return "*timestamp.Timestamp"
+	case expr.DurationKind:
+		return "*duration.Duration"
+	case expr.DecimalKind:
+		return "string"
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T", t)) // bug
 	}
 }
 
+// isFieldMask returns true if the given attribute lists the result attributes
+// selected by the client and is thus represented with a
+// google.protobuf.FieldMask message.
+func isFieldMask(att *expr.AttributeExpr) bool {
+	_, ok := att.Meta["rpc:fieldmask"]
+	return ok
+}
+
 // rpcTag returns the unique numbered RPC tag from the given attribute.
 func rpcTag(a *expr.AttributeExpr) uint64 {
 	var tag uint64
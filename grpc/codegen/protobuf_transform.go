@@ -152,6 +152,22 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				srcPtr   = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
 				tgtPtr   = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
 			)
+			if srcc.IsNullable() != tgtc.IsNullable() {
+				// Protocol buffer messages have no notion of null values, nullable
+				// attributes are mapped to regular fields.
+				if srcc.IsNullable() {
+					postInitCode += fmt.Sprintf("if %s.Valid() {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, convertType(srcc, tgtc, srcField+".Value", ta))
+					return
+				}
+				ctor := strings.Replace(codegen.GoNullableTypeName(tgtc.Type), "goa.", "goa.New", 1)
+				val := fmt.Sprintf("%s(%s)", ctor, convertType(srcc, tgtc, srcField, ta))
+				if !srcMatt.IsRequired(n) && srcc.Type != expr.Boolean {
+					postInitCode += fmt.Sprintf("if %s {\n\t%s.%s = %s\n}\n", checkZeroValue(srcc.Type, srcField, true), targetVar, tgtField, val)
+					return
+				}
+				initCode += fmt.Sprintf("\n%s: %s,", tgtField, val)
+				return
+			}
 			srcFieldConv := convertType(srcc, tgtc, srcField, ta)
 			switch {
 			case srcPtr && !tgtPtr:
@@ -231,11 +247,17 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			}
 			_, ok := srcc.Type.(expr.UserType)
 			switch {
+			case isFieldMask(srcc) || isFieldMask(tgtc):
+				conv := "goagrpc.FieldMaskPaths"
+				if ta.proto {
+					conv = "goagrpc.FieldMaskProto"
+				}
+				code = fmt.Sprintf("%s = %s(%s)\n", tgtVar, conv, srcVar)
 			case expr.IsArray(srcc.Type):
 				code, err = transformArray(expr.AsArray(srcc.Type), expr.AsArray(tgtc.Type), srcVar, tgtVar, false, ta)
 			case expr.IsMap(srcc.Type):
 				code, err = transformMap(expr.AsMap(srcc.Type), expr.AsMap(tgtc.Type), srcVar, tgtVar, false, ta)
-			case ok:
+			case ok && !expr.IsPrimitive(srcc.Type):
 				code = fmt.Sprintf("%s = %s\n", tgtVar, convertType(srcc, tgtc, srcVar, ta))
 			case expr.IsObject(srcc.Type):
 				code, err = transformAttribute(srcc, tgtc, srcVar, tgtVar, false, ta)
@@ -284,7 +306,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				if !srcMatt.IsRequired(n) && srcc.Type != expr.Boolean {
 					code += fmt.Sprintf("if %s {\n\t", checkZeroValue(srcc.Type, srcVar, false))
 					if ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && expr.IsPrimitive(tgtc.Type) {
-						code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", codegen.GoNativeTypeName(tgtc.Type), tdef, tgtVar)
+						code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", ta.TargetCtx.Scope.Ref(tgtc, ta.TargetCtx.Pkg), tdef, tgtVar)
 					} else {
 						code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
 					}
@@ -443,12 +465,42 @@ func transformMap(source, target *expr.Map, sourceVar, targetVar string, newVar
 // held by sourceVar.
 // NOTE: For Int and UInt kinds, protocol buffer Go compiler generates
 // int32 and uint32 respectively whereas goa v2 generates int and uint.
+// Time, Duration and Decimal kinds are converted from and to the
+// google.protobuf.Timestamp, google.protobuf.Duration and string protocol
+// buffer types using the goagrpc helpers. User types with a primitive
+// underlying type are converted with a cast.
 func convertType(source, target *expr.AttributeExpr, sourceVar string, ta *transformAttrs) string {
+	if ut, ok := target.Type.(expr.UserType); ok && expr.IsPrimitive(ut) {
+		return fmt.Sprintf("%s(%s)", ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg), sourceVar)
+	}
+	if ut, ok := source.Type.(expr.UserType); ok && expr.IsPrimitive(ut) {
+		if ta.proto {
+			return fmt.Sprintf("%s(%s)", protoBufNativeGoTypeName(ut.Attribute().Type), sourceVar)
+		}
+		return fmt.Sprintf("%s(%s)", codegen.GoNativeTypeName(ut.Attribute().Type), sourceVar)
+	}
 	if _, ok := source.Type.(expr.UserType); ok {
 		// return a function name for the conversion
 		return fmt.Sprintf("%s(%s)", transformHelperName(source, target, ta), sourceVar)
 	}
 
+	switch source.Type.Kind() {
+	case expr.TimeKind:
+		if ta.proto {
+			return fmt.Sprintf("goagrpc.TimestampProto(%s)", sourceVar)
+		}
+		return fmt.Sprintf("goagrpc.Timestamp(%s)", sourceVar)
+	case expr.DurationKind:
+		if ta.proto {
+			return fmt.Sprintf("goagrpc.DurationProto(%s)", sourceVar)
+		}
+		return fmt.Sprintf("goagrpc.Duration(%s)", sourceVar)
+	case expr.DecimalKind:
+		if ta.proto {
+			return fmt.Sprintf("goagrpc.DecimalProto(%s)", sourceVar)
+		}
+		return fmt.Sprintf("goagrpc.Decimal(%s)", sourceVar)
+	}
 	if source.Type.Kind() != expr.IntKind && source.Type.Kind() != expr.UIntKind {
 		return sourceVar
 	}
@@ -470,7 +522,7 @@ func checkZeroValue(dt expr.DataType, target string, negate bool) string {
 		expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind,
 		expr.Float32Kind, expr.Float64Kind:
 		return fmt.Sprintf("%s %s 0", target, eq)
-	case expr.StringKind:
+	case expr.StringKind, expr.DecimalKind:
 		return fmt.Sprintf("%s %s \"\"", target, eq)
 	case expr.BytesKind, expr.ArrayKind, expr.MapKind:
 		return fmt.Sprintf("len(%s) %s 0", target, eq)
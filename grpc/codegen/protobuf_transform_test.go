@@ -36,6 +36,8 @@ func TestProtoBufTransform(t *testing.T) {
 		customField = root.UserType("CompositeWithCustomField")
 		optional    = root.UserType("Optional")
 		defaults    = root.UserType("WithDefaults")
+		timeTypes   = root.UserType("TimeTypes")
+		enumTypes   = root.UserType("EnumTypes")
 
 		resultType = root.UserType("ResultType")
 		rtCol      = root.UserType("ResultTypeCollection")
@@ -84,6 +86,8 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, true, svcCtx, rtColSvcToRTColProtoCode},
 			{"optional-to-optional", optional, optional, true, svcCtx, optionalSvcToOptionalProtoCode},
 			{"defaults-to-defaults", defaults, defaults, true, svcCtx, defaultsSvcToDefaultsProtoCode},
+			{"time-types-to-time-types", timeTypes, timeTypes, true, svcCtx, timeTypesSvcToTimeTypesProtoCode},
+			{"enum-types-to-enum-types", enumTypes, enumTypes, true, svcCtx, enumTypesSvcToEnumTypesProtoCode},
 		},
 
 		// test cases to transform protocol buffer type to service type
@@ -116,6 +120,8 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, false, svcCtx, rtColProtoToRTColSvcCode},
 			{"optional-to-optional", optional, optional, false, svcCtx, optionalProtoToOptionalSvcCode},
 			{"defaults-to-defaults", defaults, defaults, false, svcCtx, defaultsProtoToDefaultsSvcCode},
+			{"time-types-to-time-types", timeTypes, timeTypes, false, svcCtx, timeTypesProtoToTimeTypesSvcCode},
+			{"enum-types-to-enum-types", enumTypes, enumTypes, false, svcCtx, enumTypesProtoToEnumTypesSvcCode},
 		},
 	}
 	for name, cases := range tc {
@@ -923,6 +929,28 @@ const (
 		target.UserType = protobufOptionalToOptional(source.UserType)
 	}
 }
+`
+
+	timeTypesSvcToTimeTypesProtoCode = `func transform() {
+	target := &TimeTypes{
+		RequiredTime: goagrpc.TimestampProto(source.RequiredTime),
+	}
+	if source.Time != nil {
+		target.Time = goagrpc.TimestampProto(*source.Time)
+	}
+	if source.Duration != nil {
+		target.Duration = goagrpc.DurationProto(*source.Duration)
+	}
+	if source.Decimal != nil {
+		target.Decimal = goagrpc.DecimalProto(*source.Decimal)
+	}
+	if source.Times != nil {
+		target.Times = make([]*timestamp.Timestamp, len(source.Times))
+		for i, val := range source.Times {
+			target.Times[i] = goagrpc.TimestampProto(val)
+		}
+	}
+}
 `
 
 	defaultsProtoToDefaultsSvcCode = `func transform() {
@@ -983,5 +1011,79 @@ const (
 		}
 	}
 }
+`
+
+	enumTypesSvcToEnumTypesProtoCode = `func transform() {
+	target := &EnumTypes{
+		RequiredColor: string(source.RequiredColor),
+	}
+	if source.Color != nil {
+		target.Color = string(*source.Color)
+	}
+	if source.Colors != nil {
+		target.Colors = make([]string, len(source.Colors))
+		for i, val := range source.Colors {
+			target.Colors[i] = string(val)
+		}
+	}
+	if source.ByColor != nil {
+		target.ByColor = make(map[string]int32, len(source.ByColor))
+		for key, val := range source.ByColor {
+			tk := string(key)
+			tv := int32(val)
+			target.ByColor[tk] = tv
+		}
+	}
+}
+`
+
+	timeTypesProtoToTimeTypesSvcCode = `func transform() {
+	target := &TimeTypes{
+		RequiredTime: goagrpc.Timestamp(source.RequiredTime),
+	}
+	if source.Time != nil {
+		time_ptr := goagrpc.Timestamp(source.Time)
+		target.Time = &time_ptr
+	}
+	if source.Duration != nil {
+		durationptr := goagrpc.Duration(source.Duration)
+		target.Duration = &durationptr
+	}
+	if source.Decimal != "" {
+		decimalptr := goagrpc.Decimal(source.Decimal)
+		target.Decimal = &decimalptr
+	}
+	if source.Times != nil {
+		target.Times = make([]time.Time, len(source.Times))
+		for i, val := range source.Times {
+			target.Times[i] = goagrpc.Timestamp(val)
+		}
+	}
+}
+`
+
+	enumTypesProtoToEnumTypesSvcCode = `func transform() {
+	target := &EnumTypes{
+		RequiredColor: Color(source.RequiredColor),
+	}
+	if source.Color != "" {
+		colorptr := Color(source.Color)
+		target.Color = &colorptr
+	}
+	if source.Colors != nil {
+		target.Colors = make([]Color, len(source.Colors))
+		for i, val := range source.Colors {
+			target.Colors[i] = Color(val)
+		}
+	}
+	if source.ByColor != nil {
+		target.ByColor = make(map[Color]int, len(source.ByColor))
+		for key, val := range source.ByColor {
+			tk := Color(key)
+			tv := int(val)
+			target.ByColor[tk] = tv
+		}
+	}
+}
 `
 )
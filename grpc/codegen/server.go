@@ -40,8 +40,11 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server", "server", []*codegen.ImportSpec{
 				{Path: "context"},
+				{Path: "time"},
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
+				codegen.GoaImport("middleware"),
+				codegen.GoaImport("security"),
 				{Path: "google.golang.org/grpc/codes"},
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
@@ -240,6 +243,19 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	middleware.SetServiceMethod(ctx)
+{{- if .Method.Schemes.HasType "MTLS" }}
+	ctx = security.WithPeerCertificate(ctx, goagrpc.PeerCertificate(ctx))
+{{- end }}
+{{- if .Method.RequestBodyLimit }}
+	if err := goagrpc.LimitMessageSize(message, {{ .Method.RequestBodyLimit }}); err != nil {
+		return nil, err
+	}
+{{- end }}
+{{- if .Method.RequestTimeout }}
+	ctx, cancel := context.WithTimeout(ctx, {{ .Method.RequestTimeout }})
+	defer cancel()
+{{- end }}
 
 {{- if .ServerStream }}
 	{{if .PayloadRef }}p{{ else }}_{{ end }}, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
@@ -253,6 +269,11 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 	err = s.{{ .Method.VarName }}H.Handle(ctx, ep)
 {{- else }}
 	resp, err := s.{{ .Method.VarName }}H.Handle(ctx, message)
+	{{- if .Method.RequestTimeout }}
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = goa.DeadlineExceededError({{ .Method.RequestTimeout }})
+	}
+	{{- end }}
 {{- end }}
 	{{- template "handle_error" . }}
 	return {{ if not $.ServerStream }}resp.({{ .Response.ServerConvert.TgtRef }}), {{ end }}nil
@@ -433,7 +454,9 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v interface{}, hdr
 			{{- if eq .Metadata.Type.Name "bytes" }} string(
 			{{- else if not (eq .Metadata.Type.Name "string") }} fmt.Sprintf("%v",
 			{{- end }}
+			{{- with .Metadata.FieldConv }} {{ . }}({{ end }}
 			{{- if .Metadata.Pointer }}*{{ end }}p.{{ .Metadata.FieldName }}
+			{{- if .Metadata.FieldConv }}){{ end }}
 			{{- if or (eq .Metadata.Type.Name "bytes") (not (eq .Metadata.Type.Name "string")) }})
 			{{- end }})
 		{{- if .Metadata.Pointer }}
@@ -122,6 +122,14 @@ type (
 		// FieldName is the name of the struct field that holds the
 		// metadata value if any, empty string otherwise.
 		FieldName string
+		// Conv is the name of the type used to convert the metadata value
+		// into the struct field value if they have different Go types
+		// (e.g. the field holds a primitive user type).
+		Conv string
+		// FieldConv is the name of the type used to convert the struct
+		// field value into the metadata value if they have different Go
+		// types.
+		FieldConv string
 		// VarName is the name of the Go variable used to read or
 		// convert the metadata value.
 		VarName string
@@ -151,6 +159,8 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// Enum lists the values allowed for the metadata if any.
+		Enum []interface{}
 	}
 
 	// ErrorData contains the error information required to generate the
@@ -282,6 +292,9 @@ type (
 		// FieldName is the name of the data structure field that should
 		// be initialized with the argument if any.
 		FieldName string
+		// Conv is the name of the type used to convert the argument into
+		// the data structure field value if they have different Go types.
+		Conv string
 		// TypeName is the argument type name.
 		TypeName string
 		// TypeRef is the argument type reference.
@@ -297,6 +310,8 @@ type (
 		Validate string
 		// Example is a example value
 		Example interface{}
+		// Enum lists the values allowed for the argument if any.
+		Enum []interface{}
 	}
 
 	// StreamData contains data to render the stream struct type that implements
@@ -503,7 +518,7 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			reqMD   []*MetadataData
 		)
 		{
-			reqMD = extractMetadata(e.Metadata, e.MethodExpr.Payload, svc.Scope)
+			reqMD = extractMetadata(e.Metadata, e.MethodExpr.Payload, svc.PkgName, svc.Scope)
 			request = &RequestData{
 				Description:   e.Request.Description,
 				Metadata:      reqMD,
@@ -526,12 +541,14 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 					Name:      m.VarName,
 					Ref:       m.VarName,
 					FieldName: m.FieldName,
+					Conv:      m.Conv,
 					TypeName:  m.TypeName,
 					TypeRef:   m.TypeRef,
 					Pointer:   m.Pointer,
 					Required:  m.Required,
 					Validate:  m.Validate,
 					Example:   m.Example,
+					Enum:      m.Enum,
 				})
 			}
 			if e.StreamingRequest.Type != expr.Empty {
@@ -550,8 +567,8 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			result, svcCtx = resultContext(e, sd)
 		)
 		{
-			hdrs = extractMetadata(e.Response.Headers, result, svc.Scope)
-			trlrs = extractMetadata(e.Response.Trailers, result, svc.Scope)
+			hdrs = extractMetadata(e.Response.Headers, result, svc.PkgName, svc.Scope)
+			trlrs = extractMetadata(e.Response.Trailers, result, svc.PkgName, svc.Scope)
 			response = &ResponseData{
 				StatusCode:    statusCodeToGRPCConst(e.Response.StatusCode),
 				Description:   e.Response.Description,
@@ -575,6 +592,10 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 		{
 			for _, req := range e.Requirements {
 				for _, sch := range req.Schemes {
+					if sch.Kind == expr.MTLSKind {
+						// client certificate is provided by the TLS connection
+						continue
+					}
 					s := md.Requirements.Scheme(sch.SchemeName).Dup()
 					s.In = sch.In
 					switch s.In {
@@ -795,6 +816,7 @@ func buildRequestConvertData(request, payload *expr.AttributeExpr, md []*Metadat
 					Name:      m.VarName,
 					Ref:       m.VarName,
 					FieldName: m.FieldName,
+					Conv:      m.Conv,
 					TypeName:  m.TypeName,
 					TypeRef:   m.TypeRef,
 					Pointer:   m.Pointer,
@@ -879,6 +901,7 @@ func buildResponseConvertData(response, result *expr.AttributeExpr, svcCtx *code
 				Name:      m.VarName,
 				Ref:       m.VarName,
 				FieldName: m.FieldName,
+				Conv:      m.Conv,
 				TypeName:  m.TypeName,
 				TypeRef:   m.TypeRef,
 				Pointer:   m.Pointer,
@@ -893,6 +916,7 @@ func buildResponseConvertData(response, result *expr.AttributeExpr, svcCtx *code
 				Name:      m.VarName,
 				Ref:       m.VarName,
 				FieldName: m.FieldName,
+				Conv:      m.Conv,
 				TypeName:  m.TypeName,
 				TypeRef:   m.TypeRef,
 				Pointer:   m.Pointer,
@@ -1173,8 +1197,9 @@ func buildStreamData(e *expr.GRPCEndpointExpr, sd *ServiceData, svr bool) *Strea
 }
 
 // extractMetadata collects the request/response metadata from the given
-// metadata attribute and service type (payload/result).
-func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*MetadataData {
+// metadata attribute and service type (payload/result). pkg is the name of the
+// service package.
+func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, pkg string, scope *codegen.NameScope) []*MetadataData {
 	var metadata []*MetadataData
 	ctx := serviceTypeContext("", scope)
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
@@ -1182,6 +1207,8 @@ func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, s
 			varn      string
 			fieldName string
 			pointer   bool
+			conv      string
+			fieldConv string
 
 			arr     = expr.AsArray(c.Type)
 			mp      = expr.AsMap(c.Type)
@@ -1198,12 +1225,29 @@ func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, s
 			if pointer {
 				typeRef = "*" + typeRef
 			}
+			sa := service
+			if fieldName != "" {
+				sa = service.Find(name)
+			}
+			if sa != nil && expr.IsPrimitive(sa.Type) {
+				if ut, ok := sa.Type.(expr.UserType); ok {
+					// the service attribute holds a primitive user type
+					// while metadata uses the underlying primitive type.
+					conv = scope.GoFullTypeRef(sa, pkg)
+					if pointer {
+						conv = "(*" + conv + ")"
+					}
+					fieldConv = codegen.GoNativeTypeName(ut.Attribute().Type)
+				}
+			}
 		}
 		metadata = append(metadata, &MetadataData{
 			Name:          elem,
 			AttributeName: name,
 			Description:   c.Description,
 			FieldName:     fieldName,
+			Conv:          conv,
+			FieldConv:     fieldConv,
 			VarName:       varn,
 			Required:      required,
 			Type:          c.Type,
@@ -1220,12 +1264,22 @@ func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, s
 			Validate:     codegen.RecursiveValidationCode(c, ctx, required, varn),
 			DefaultValue: c.DefaultValue,
 			Example:      c.Example(expr.Root.API.Random()),
+			Enum:         enumValues(c),
 		})
 		return nil
 	})
 	return metadata
 }
 
+// enumValues returns the values of the enum validation of the given attribute
+// if it is a primitive, nil otherwise.
+func enumValues(att *expr.AttributeExpr) []interface{} {
+	if !expr.IsPrimitive(att.Type) || att.Validation == nil {
+		return nil
+	}
+	return att.Validation.Values
+}
+
 // serviceTypeContext returns a contextual attribute for service types. Service
 // types are Go types and uses non-pointers to hold attributes having default
 // values.
@@ -1264,7 +1318,7 @@ func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .Ret
 {{- if .ReturnIsStruct }}
 	{{- range .Args }}
 		{{- if .FieldName }}
-			{{ $.ReturnVarName }}.{{ .FieldName }} = {{ .Name }}
+			{{ $.ReturnVarName }}.{{ .FieldName }} = {{ with .Conv }}{{ . }}({{ end }}{{ .Name }}{{ if .Conv }}){{ end }}
 		{{- end }}
 	{{- end }}
 {{- end }}
@@ -236,3 +236,78 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	}
 }
 `
+
+const MessageWithSecurityAttrsClientEndpointInitCode = `// MethodMessageWithSecurity calls the "MethodMessageWithSecurity" function in
+// service_message_with_securitypb.ServiceMessageWithSecurityClient interface.
+func (c *Client) MethodMessageWithSecurity() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		if c.Credentials != nil {
+			var err error
+			if v, err = setMethodMessageWithSecurityCredentials(ctx, c.Credentials, v); err != nil {
+				return nil, err
+			}
+		}
+		inv := goagrpc.NewInvoker(
+			BuildMethodMessageWithSecurityFunc(c.grpccli, c.opts...),
+			EncodeMethodMessageWithSecurityRequest,
+			nil)
+		res, err := inv.Invoke(ctx, v)
+		if err != nil {
+			return nil, goa.Fault(err.Error())
+		}
+		return res, nil
+	}
+}
+`
+
+const MessageWithSecurityAttrsClientCredentialsCode = `// setMethodMessageWithSecurityCredentials returns a copy of the
+// "MethodMessageWithSecurity" payload v where the credentials that are not set
+// are obtained from src.
+func setMethodMessageWithSecurityCredentials(ctx context.Context, src security.CredentialSource, v interface{}) (interface{}, error) {
+	p, ok := v.(*servicemessagewithsecurity.RequestUT)
+	if !ok || p == nil {
+		return v, nil
+	}
+	res := *p
+	if res.Token == nil {
+		cred, err := src.Credential(ctx, &security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read"},
+			RequiredScopes: []string{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.Token = &cred
+		}
+	}
+	if res.OauthToken == nil {
+		cred, err := src.Credential(ctx, &security.OAuth2Scheme{
+			Name:           "oauth2",
+			Scopes:         []string{"api:write"},
+			RequiredScopes: []string{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.OauthToken = &cred
+		}
+	}
+	if res.Key == nil {
+		cred, err := src.Credential(ctx, &security.APIKeyScheme{
+			Name:           "api_key",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.Key = &cred
+		}
+	}
+	return &res, nil
+}
+`
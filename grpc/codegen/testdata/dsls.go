@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/v3/dsl"
 )
 
@@ -52,6 +54,20 @@ var UnaryRPCNoResultDSL = func() {
 	})
 }
 
+var UnaryRPCWithLimitsDSL = func() {
+	API("RequestLimits", func() {
+		RequestBodyLimit(1 << 20)
+		RequestTimeout(1500 * time.Millisecond)
+	})
+	Service("ServiceUnaryRPCWithLimits", func() {
+		Method("MethodUnaryRPCWithLimits", func() {
+			Payload(String)
+			Result(String)
+			GRPC(func() {})
+		})
+	})
+}
+
 var UnaryRPCWithErrorsDSL = func() {
 	var ErrorType = Type("ErrorType", func() {
 		Attribute("a", String)
@@ -483,6 +499,22 @@ var PayloadWithNestedTypesDSL = func() {
 	})
 }
 
+var PayloadWithDecimalDSL = func() {
+	Service("ServicePayloadWithDecimal", func() {
+		Method("MethodPayloadWithDecimal", func() {
+			Payload(func() {
+				Field(1, "amount", Decimal)
+				Field(2, "rates", ArrayOf(Decimal))
+				Field(3, "total", Decimal)
+				Required("total")
+			})
+			GRPC(func() {
+				Response(CodeOK)
+			})
+		})
+	})
+}
+
 var MessageArrayDSL = func() {
 	var UT = Type("UT", func() {
 		Field(1, "ArrayOfPrimitives", ArrayOf(UInt))
@@ -637,6 +669,26 @@ var MessageWithSecurityAttrsDSL = func() {
 	})
 }
 
+var MessageWithFieldMaskDSL = func() {
+	var RT = ResultType("application/vnd.result", func() {
+		TypeName("ResultType")
+		Attributes(func() {
+			Field(1, "a", String)
+			Field(2, "b", Int)
+		})
+	})
+	Service("ServiceMessageWithFieldMask", func() {
+		Method("MethodMessageWithFieldMask", func() {
+			Payload(func() {
+				Field(1, "id", String)
+			})
+			Result(RT)
+			SparseFieldsets()
+			GRPC(func() {})
+		})
+	})
+}
+
 var MessageWithServiceNameDSL = func() {
 	var UT = Type("MyNameConflicts", func() {
 		Field(1, "BooleanField", Boolean)
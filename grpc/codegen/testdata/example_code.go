@@ -1,8 +1,8 @@
 package testdata
 
-const NoServerServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+const NoServerServerHandleCode = `// handleGRPCServer configures a gRPC server on the given URL and adds it to
+// the group that runs the servers.
+func handleGRPCServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -23,13 +23,16 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer = servicesvr.New(serviceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the timeouts and the middleware. Change
+	// the timeouts as required by your service.
+	opts := lifecycle.GRPCServerOptions(lifecycle.Timeouts{Read: 30 * time.Second, Idle: 2 * time.Minute})
+	opts = append(opts,
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
 	)
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -44,30 +47,18 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
 	reflection.Register(srv)
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start gRPC server in a separate goroutine.
-		go func() {
-			lis, err := net.Listen("tcp", u.Host)
-			if err != nil {
-				errc <- err
-			}
-			logger.Printf("gRPC server listening on %q", u.Host)
-			errc <- srv.Serve(lis)
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.GRPC(srv, u.Host))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
-	}()
+	})
+	logger.Printf("gRPC server listening on %q", u.Host)
 }
 `
 
-const ServerHostingServiceSubsetServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+const ServerHostingServiceSubsetServerHandleCode = `// handleGRPCServer configures a gRPC server on the given URL and adds it to
+// the group that runs the servers.
+func handleGRPCServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -88,13 +79,16 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer = servicesvr.New(serviceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the timeouts and the middleware. Change
+	// the timeouts as required by your service.
+	opts := lifecycle.GRPCServerOptions(lifecycle.Timeouts{Read: 30 * time.Second, Idle: 2 * time.Minute})
+	opts = append(opts,
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
 	)
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -109,30 +103,18 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
 	reflection.Register(srv)
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start gRPC server in a separate goroutine.
-		go func() {
-			lis, err := net.Listen("tcp", u.Host)
-			if err != nil {
-				errc <- err
-			}
-			logger.Printf("gRPC server listening on %q", u.Host)
-			errc <- srv.Serve(lis)
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.GRPC(srv, u.Host))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
-	}()
+	})
+	logger.Printf("gRPC server listening on %q", u.Host)
 }
 `
 
-const ServerHostingMultipleServicesServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+const ServerHostingMultipleServicesServerHandleCode = `// handleGRPCServer configures a gRPC server on the given URL and adds it to
+// the group that runs the servers.
+func handleGRPCServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -155,13 +137,16 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		anotherServiceServer = anotherservicesvr.New(anotherServiceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the timeouts and the middleware. Change
+	// the timeouts as required by your service.
+	opts := lifecycle.GRPCServerOptions(lifecycle.Timeouts{Read: 30 * time.Second, Idle: 2 * time.Minute})
+	opts = append(opts,
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
 	)
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -177,24 +162,71 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
 	reflection.Register(srv)
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start gRPC server in a separate goroutine.
-		go func() {
-			lis, err := net.Listen("tcp", u.Host)
-			if err != nil {
-				errc <- err
-			}
-			logger.Printf("gRPC server listening on %q", u.Host)
-			errc <- srv.Serve(lis)
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.GRPC(srv, u.Host))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
-	}()
+	})
+	logger.Printf("gRPC server listening on %q", u.Host)
+}
+`
+
+const ServerWithBodyLimitServerHandleCode = `// handleGRPCServer configures a gRPC server on the given URL and adds it to
+// the group that runs the servers.
+func handleGRPCServer(grp *lifecycle.Group, u *url.URL, serviceUnaryRPCWithLimitsEndpoints *serviceunaryrpcwithlimits.Endpoints, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to gRPC requests and
+	// responses.
+	var (
+		serviceUnaryRPCWithLimitsServer *serviceunaryrpcwithlimitssvr.Server
+	)
+	{
+		serviceUnaryRPCWithLimitsServer = serviceunaryrpcwithlimitssvr.New(serviceUnaryRPCWithLimitsEndpoints, nil)
+	}
+
+	// Initialize gRPC server with the timeouts and the middleware. Change
+	// the timeouts as required by your service.
+	opts := lifecycle.GRPCServerOptions(lifecycle.Timeouts{Read: 30 * time.Second, Idle: 2 * time.Minute})
+	opts = append(opts,
+		grpcmiddleware.WithUnaryServerChain(
+			grpcmdlwr.UnaryRequestID(),
+			grpcmdlwr.UnaryServerLog(adapter),
+		),
+		// Reject the messages that exceed the request body limits before
+		// decoding them.
+		grpc.MaxRecvMsgSize(1048576),
+	)
+	srv := grpc.NewServer(opts...)
+
+	// Register the servers.
+	service_unary_rpc_with_limitspb.RegisterServiceUnaryRPCWithLimitsServer(srv, serviceUnaryRPCWithLimitsServer)
+
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			logger.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+
+	// Register the server reflection service on the server.
+	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
+	reflection.Register(srv)
+
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.GRPC(srv, u.Host))
+	grp.BeforeShutdown(func(context.Context) {
+		logger.Printf("shutting down gRPC server at %q", u.Host)
+	})
+	logger.Printf("gRPC server listening on %q", u.Host)
 }
 `
 
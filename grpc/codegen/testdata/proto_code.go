@@ -342,6 +342,19 @@ message MethodMessageWithSecurityResponse {
 }
 `
 
+const MessageWithFieldMaskCode = `
+message MethodMessageWithFieldMaskRequest {
+	string id = 1;
+	// Names of the result attributes to render, omit to render the whole view.
+	google.protobuf.FieldMask fields = 2;
+}
+
+message MethodMessageWithFieldMaskResponse {
+	string a = 1;
+	sint32 b = 2;
+}
+`
+
 const MethodWithReservedNameProtoCode = `
 syntax = "proto3";
 
@@ -5,6 +5,7 @@ const UnaryRPCsServerInterfaceCode = `// MethodUnaryRPCA implements the "MethodU
 func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCARequest) (*service_unary_rp_cspb.MethodUnaryRPCAResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCA")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCAH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
@@ -17,6 +18,7 @@ func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unary_rp_
 func (s *Server) MethodUnaryRPCB(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCBRequest) (*service_unary_rp_cspb.MethodUnaryRPCBResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCB")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCBH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
@@ -30,6 +32,7 @@ const UnaryRPCNoPayloadServerInterfaceCode = `// MethodUnaryRPCNoPayload impleme
 func (s *Server) MethodUnaryRPCNoPayload(ctx context.Context, message *service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadRequest) (*service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoPayload")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCNoPayloadH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
@@ -43,6 +46,7 @@ const UnaryRPCNoResultServerInterfaceCode = `// MethodUnaryRPCNoResult implement
 func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultRequest) (*service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoResult")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoResult")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCNoResultH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
@@ -51,11 +55,34 @@ func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_un
 }
 `
 
+const UnaryRPCWithLimitsServerInterfaceCode = `// MethodUnaryRPCWithLimits implements the "MethodUnaryRPCWithLimits" method in
+// service_unary_rpc_with_limitspb.ServiceUnaryRPCWithLimitsServer interface.
+func (s *Server) MethodUnaryRPCWithLimits(ctx context.Context, message *service_unary_rpc_with_limitspb.MethodUnaryRPCWithLimitsRequest) (*service_unary_rpc_with_limitspb.MethodUnaryRPCWithLimitsResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithLimits")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithLimits")
+	middleware.SetServiceMethod(ctx)
+	if err := goagrpc.LimitMessageSize(message, 1048576); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	resp, err := s.MethodUnaryRPCWithLimitsH.Handle(ctx, message)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = goa.DeadlineExceededError(1500 * time.Millisecond)
+	}
+	if err != nil {
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*service_unary_rpc_with_limitspb.MethodUnaryRPCWithLimitsResponse), nil
+}
+`
+
 const UnaryRPCWithErrorsServerInterfaceCode = `// MethodUnaryRPCWithErrors implements the "MethodUnaryRPCWithErrors" method in
 // service_unary_rpc_with_errorspb.ServiceUnaryRPCWithErrorsServer interface.
 func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsRequest) (*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithErrors")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCWithErrorsH.Handle(ctx, message)
 	if err != nil {
 		if en, ok := err.(ErrorNamer); ok {
@@ -86,6 +113,7 @@ const UnaryRPCWithOverridingErrorsServerInterfaceCode = `// MethodUnaryRPCWithOv
 func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message *service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsRequest) (*service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse, error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithOverridingErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithOverridingErrors")
+	middleware.SetServiceMethod(ctx)
 	resp, err := s.MethodUnaryRPCWithOverridingErrorsH.Handle(ctx, message)
 	if err != nil {
 		if en, ok := err.(ErrorNamer); ok {
@@ -108,6 +136,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcp
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodServerStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceServerStreamingRPC")
+	middleware.SetServiceMethod(ctx)
 	p, err := s.MethodServerStreamingRPCH.Decode(ctx, message)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -130,6 +159,7 @@ func (s *Server) MethodClientStreamingRPC(stream service_client_streaming_rpcpb.
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPC")
+	middleware.SetServiceMethod(ctx)
 	_, err := s.MethodClientStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -153,6 +183,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPCWithPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPCWithPayload")
+	middleware.SetServiceMethod(ctx)
 	p, err := s.MethodClientStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -177,6 +208,7 @@ func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_st
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPC")
+	middleware.SetServiceMethod(ctx)
 	_, err := s.MethodBidirectionalStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -200,6 +232,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithPayload")
+	middleware.SetServiceMethod(ctx)
 	p, err := s.MethodBidirectionalStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -224,6 +257,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithErrors")
+	middleware.SetServiceMethod(ctx)
 	_, err := s.MethodBidirectionalStreamingRPCWithErrorsH.Decode(ctx, nil)
 	if err != nil {
 		if en, ok := err.(ErrorNamer); ok {
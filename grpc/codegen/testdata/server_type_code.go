@@ -220,3 +220,47 @@ func NewMethodUnaryRPCWithErrorsCustomErrorError(er *serviceunaryrpcwitherrors.E
 	return message
 }
 `
+
+const PayloadWithDecimalServerTypeCode = `// NewMethodPayloadWithDecimalPayload builds the payload of the
+// "MethodPayloadWithDecimal" endpoint of the "ServicePayloadWithDecimal"
+// service from the gRPC request type.
+func NewMethodPayloadWithDecimalPayload(message *service_payload_with_decimalpb.MethodPayloadWithDecimalRequest) *servicepayloadwithdecimal.MethodPayloadWithDecimalPayload {
+	v := &servicepayloadwithdecimal.MethodPayloadWithDecimalPayload{
+		Total: goagrpc.Decimal(message.Total),
+	}
+	if message.Amount != "" {
+		amountptr := goagrpc.Decimal(message.Amount)
+		v.Amount = &amountptr
+	}
+	if message.Rates != nil {
+		v.Rates = make([]goa.Decimal, len(message.Rates))
+		for i, val := range message.Rates {
+			v.Rates[i] = goagrpc.Decimal(val)
+		}
+	}
+	return v
+}
+
+// NewMethodPayloadWithDecimalResponse builds the gRPC response type from the
+// result of the "MethodPayloadWithDecimal" endpoint of the
+// "ServicePayloadWithDecimal" service.
+func NewMethodPayloadWithDecimalResponse() *service_payload_with_decimalpb.MethodPayloadWithDecimalResponse {
+	message := &service_payload_with_decimalpb.MethodPayloadWithDecimalResponse{}
+	return message
+}
+
+// ValidateMethodPayloadWithDecimalRequest runs the validations defined on
+// MethodPayloadWithDecimalRequest.
+func ValidateMethodPayloadWithDecimalRequest(message *service_payload_with_decimalpb.MethodPayloadWithDecimalRequest) (err error) {
+	if message.Amount != "" {
+		err = goa.MergeErrors(err, goa.ValidateFormat("message.amount", message.Amount, goa.FormatDecimal))
+	}
+	for _, e := range message.Rates {
+		err = goa.MergeErrors(err, goa.ValidateFormat("message.rates[*]", e, goa.FormatDecimal))
+
+	}
+	err = goa.MergeErrors(err, goa.ValidateFormat("message.total", message.Total, goa.FormatDecimal))
+
+	return
+}
+`
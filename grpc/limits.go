@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"github.com/golang/protobuf/proto"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+// LimitMessageSize returns a gRPC status error with the ResourceExhausted code
+// and a RequestBodyTooLargeError in its details if the size of the encoded
+// message exceeds n bytes. It returns nil otherwise.
+//
+// LimitMessageSize checks messages that have already been received and
+// decoded, it does not bound the memory used to read them. Use the
+// grpc.MaxRecvMsgSize server option to reject large messages before they are
+// decoded, the example server generated by goa sets it to the largest request
+// body limit.
+func LimitMessageSize(msg proto.Message, n int64) error {
+	if int64(proto.Size(msg)) <= n {
+		return nil
+	}
+	err := goa.RequestBodyTooLargeError(n)
+	return NewStatusError(codes.ResourceExhausted, err, NewErrorResponse(err))
+}
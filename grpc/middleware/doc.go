@@ -4,6 +4,8 @@
 // This package contains the following middlewares:
 //
 //   * Logging server middleware for unary and streaming endpoints.
+//   * Metrics server middleware for unary and streaming endpoints.
+//   * Panic recovery server middleware for unary and streaming endpoints.
 //   * Request ID server middleware for unary and streaming endpoints.
 //   * Stream Canceler server middleware for canceling streaming requests.
 //   * Tracing middleware for unary and streaming server and client.
@@ -8,8 +8,11 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	goagrpc "goa.design/goa/v3/grpc"
+	goapb "goa.design/goa/v3/grpc/pb"
 	"goa.design/goa/v3/middleware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -20,13 +23,33 @@ import (
 // missing for each incoming request and logs it with the request and
 // corresponding response details.
 //
-// The middleware logs the incoming requests gRPC method. It also logs the
-// response gRPC status code, message length (in bytes), and timing information.
-func UnaryServerLog(l middleware.Logger) grpc.UnaryServerInterceptor {
+// The middleware logs the incoming requests gRPC method and message length (in
+// bytes). It also logs the response gRPC status code, message length (in
+// bytes), and timing information. Use middleware.LogFieldsOption to log
+// additional fields.
+//
+// If l implements middleware.LeveledLogger then the middleware logs failed
+// requests with the warn or error level depending on the status code and all
+// other entries with the info level. The middleware also stores a logger that
+// logs the request ID with each entry in the request context, use
+// middleware.ContextLogger to retrieve it.
+//
+// If a sampler is given via middleware.LogSamplerOption then the entries of
+// successful requests are only logged when sampled. Failed requests are always
+// logged.
+func UnaryServerLog(l middleware.Logger, options ...middleware.LogOption) grpc.UnaryServerInterceptor {
+	var (
+		ll = middleware.AsLeveledLogger(l)
+		o  = middleware.NewLogOptions(options...)
+	)
 	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		var reqID string
+		var (
+			reqID string
+			md    metadata.MD
+		)
 		{
-			md, ok := metadata.FromIncomingContext(ctx)
+			var ok bool
+			md, ok = metadata.FromIncomingContext(ctx)
 			if !ok {
 				md = metadata.MD{}
 			}
@@ -37,21 +60,45 @@ func UnaryServerLog(l middleware.Logger) grpc.UnaryServerInterceptor {
 		}
 
 		started := time.Now()
+		rl := ll.With("id", reqID)
+		sampled := o.Sample()
 
 		// before executing rpc
-		l.Log("id", reqID,
+		reqKeyvals := []interface{}{
 			"method", info.FullMethod,
-			"bytes", messageLength(req))
+			"bytes", messageLength(req),
+		}
+		if o.Logs(middleware.LogUserAgent) {
+			reqKeyvals = append(reqKeyvals, "user_agent", MetadataValue(md, "user-agent"))
+		}
+		if sampled {
+			rl.Info(reqKeyvals...)
+		}
 
 		// invoke rpc
+		ctx = middleware.WithLogger(ctx, rl)
+		ri := middleware.ContextRequestInfo(ctx)
+		if ri == nil {
+			ctx, ri = middleware.WithRequestInfo(ctx)
+		}
 		resp, err = handler(ctx, req)
 
 		// after executing rpc
 		s, _ := status.FromError(err)
-		l.Log("id", reqID,
+		if !sampled && s.Code() == codes.OK {
+			return resp, err
+		}
+		var keyvals []interface{}
+		if !sampled {
+			keyvals = append(keyvals, reqKeyvals...)
+		}
+		keyvals = append(keyvals,
 			"status", s.Code(),
 			"bytes", messageLength(resp),
 			"time", time.Since(started).String())
+		keyvals = appendRoute(keyvals, o, ri)
+		keyvals = appendErrorName(keyvals, o, err)
+		logStatus(rl, s.Code(), keyvals...)
 		return resp, err
 	})
 }
@@ -60,12 +107,22 @@ func UnaryServerLog(l middleware.Logger) grpc.UnaryServerInterceptor {
 // requests and responses. The middleware uses the request ID set by the
 // RequestID middleware or creates a short unique request ID if missing for
 // each incoming request and logs it with the request and corresponding
-// response details.
-func StreamServerLog(l middleware.Logger) grpc.StreamServerInterceptor {
+// response details. The options and log levels are the same as the ones used
+// by UnaryServerLog. The middleware logs the total length of the messages
+// received by the stream if middleware.LogRequestSize is set.
+func StreamServerLog(l middleware.Logger, options ...middleware.LogOption) grpc.StreamServerInterceptor {
+	var (
+		ll = middleware.AsLeveledLogger(l)
+		o  = middleware.NewLogOptions(options...)
+	)
 	return grpc.StreamServerInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		var reqID string
+		var (
+			reqID string
+			md    metadata.MD
+		)
 		{
-			md, ok := metadata.FromIncomingContext(ss.Context())
+			var ok bool
+			md, ok = metadata.FromIncomingContext(ss.Context())
 			if !ok {
 				md = metadata.MD{}
 			}
@@ -76,25 +133,117 @@ func StreamServerLog(l middleware.Logger) grpc.StreamServerInterceptor {
 		}
 
 		started := time.Now()
+		rl := ll.With("id", reqID)
+		sampled := o.Sample()
 
 		// before executing rpc
-		l.Log("id", reqID,
+		reqKeyvals := []interface{}{
 			"method", info.FullMethod,
-			"msg", "started stream")
+			"msg", "started stream",
+		}
+		if o.Logs(middleware.LogUserAgent) {
+			reqKeyvals = append(reqKeyvals, "user_agent", MetadataValue(md, "user-agent"))
+		}
+		if sampled {
+			rl.Info(reqKeyvals...)
+		}
 
 		// invoke rpc
-		err := handler(srv, ss)
+		ctx := middleware.WithLogger(ss.Context(), rl)
+		ri := middleware.ContextRequestInfo(ctx)
+		if ri == nil {
+			ctx, ri = middleware.WithRequestInfo(ctx)
+		}
+		cs := &countingServerStream{ServerStream: ss}
+		err := handler(srv, NewWrappedServerStream(ctx, cs))
 
 		// after executing rpc
 		s, _ := status.FromError(err)
-		l.Log("id", reqID,
+		if !sampled && s.Code() == codes.OK {
+			return err
+		}
+		var keyvals []interface{}
+		if !sampled {
+			keyvals = append(keyvals, "method", info.FullMethod)
+		}
+		keyvals = append(keyvals,
 			"status", s.Code(),
 			"msg", "completed stream",
 			"time", time.Since(started).String())
+		if o.Logs(middleware.LogRequestSize) {
+			keyvals = append(keyvals, "req_bytes", cs.received)
+		}
+		keyvals = appendRoute(keyvals, o, ri)
+		keyvals = appendErrorName(keyvals, o, err)
+		logStatus(rl, s.Code(), keyvals...)
 		return err
 	})
 }
 
+// appendRoute appends the names of the service and method recorded in ri by
+// the generated code to keyvals if the options require it.
+func appendRoute(keyvals []interface{}, o *middleware.LogOptions, ri *middleware.RequestInfo) []interface{} {
+	if !o.Logs(middleware.LogRoute) || ri.Service == "" {
+		return keyvals
+	}
+	return append(keyvals, "route", ri.Service+"."+ri.Method)
+}
+
+// appendErrorName appends the name of the error encoded in the details of the
+// given gRPC status error to keyvals if the options require it.
+func appendErrorName(keyvals []interface{}, o *middleware.LogOptions, err error) []interface{} {
+	if !o.Logs(middleware.LogErrorName) {
+		return keyvals
+	}
+	if name := errorName(err); name != "" {
+		keyvals = append(keyvals, "error", name)
+	}
+	return keyvals
+}
+
+// errorName returns the name of the error encoded in the details of the given
+// gRPC status error if any.
+func errorName(err error) string {
+	if err == nil {
+		return ""
+	}
+	if resp, ok := goagrpc.DecodeError(err).(*goapb.ErrorResponse); ok {
+		return resp.Name
+	}
+	return ""
+}
+
+// logStatus logs keyvals using a level that depends on the given gRPC status
+// code.
+func logStatus(l middleware.LeveledLogger, code codes.Code, keyvals ...interface{}) {
+	switch code {
+	case codes.OK:
+		l.Info(keyvals...)
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.FailedPrecondition, codes.OutOfRange,
+		codes.Unauthenticated:
+		l.Warn(keyvals...)
+	default:
+		l.Error(keyvals...)
+	}
+}
+
+// countingServerStream is a server stream that records the total length of
+// the messages it receives.
+type countingServerStream struct {
+	grpc.ServerStream
+	received int64
+}
+
+// RecvMsg receives a message and records its length.
+func (s *countingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.received += messageLength(m)
+	return nil
+}
+
 // shortID produces a " unique" 6 bytes long string.
 // Do not use as a reliable way to get unique IDs, instead use for things like logging.
 func shortID() string {
@@ -0,0 +1,132 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	goapb "goa.design/goa/v3/grpc/pb"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type (
+	// logServerStream is a server stream that receives the given messages.
+	logServerStream struct {
+		grpc.ServerStream
+		ctx  context.Context
+		msgs []proto.Message
+	}
+)
+
+func TestUnaryServerLog(t *testing.T) {
+	var (
+		unary = &grpc.UnaryServerInfo{FullMethod: "/test.Test/Method"}
+		req   = &goapb.ErrorResponse{Name: "request"}
+	)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Test")
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		middleware.SetServiceMethod(ctx)
+		return &goapb.ErrorResponse{}, nil
+	}
+	cases := []struct {
+		Name     string
+		Options  []middleware.LogOption
+		Expected []string
+		Missing  string
+	}{
+		{"default", nil, []string{
+			"id=abc method=/test.Test/Method bytes=9\n",
+			"id=abc status=OK bytes=0 time=",
+		}, "route="},
+		{"route", []middleware.LogOption{middleware.LogFieldsOption(middleware.LogRoute)}, []string{
+			"id=abc status=OK bytes=0 time=",
+			"route=Test.Method\n",
+		}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var b bytes.Buffer
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcm.RequestIDMetadataKey, "abc"))
+			_, err := grpcm.UnaryServerLog(middleware.NewLogger(log.New(&b, "", 0)), c.Options...)(ctx, req, unary, handler)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, e := range c.Expected {
+				if !strings.Contains(b.String(), e) {
+					t.Errorf("log output %q does not contain %q", b.String(), e)
+				}
+			}
+			if c.Missing != "" && strings.Contains(b.String(), c.Missing) {
+				t.Errorf("log output %q contains %q", b.String(), c.Missing)
+			}
+		})
+	}
+}
+
+func TestStreamServerLog(t *testing.T) {
+	stream := &grpc.StreamServerInfo{FullMethod: "/test.Test/Stream"}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		ctx := context.WithValue(ss.Context(), goa.ServiceKey, "Test")
+		ctx = context.WithValue(ctx, goa.MethodKey, "Stream")
+		middleware.SetServiceMethod(ctx)
+		for {
+			var msg goapb.ErrorResponse
+			if err := ss.RecvMsg(&msg); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	cases := []struct {
+		Name     string
+		Options  []middleware.LogOption
+		Expected string
+		Missing  string
+	}{
+		{"default", nil, "status=OK msg=completed stream time=", "req_bytes="},
+		{"fields", []middleware.LogOption{middleware.LogFieldsOption(middleware.LogRequestSize | middleware.LogRoute)}, "req_bytes=12 route=Test.Stream\n", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var b bytes.Buffer
+			ss := &logServerStream{
+				ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcm.RequestIDMetadataKey, "abc")),
+				msgs: []proto.Message{&goapb.ErrorResponse{Name: "one"}, &goapb.ErrorResponse{Name: "three"}},
+			}
+			err := grpcm.StreamServerLog(middleware.NewLogger(log.New(&b, "", 0)), c.Options...)(nil, ss, stream, handler)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !strings.Contains(b.String(), c.Expected) {
+				t.Errorf("log output %q does not contain %q", b.String(), c.Expected)
+			}
+			if c.Missing != "" && strings.Contains(b.String(), c.Missing) {
+				t.Errorf("log output %q contains %q", b.String(), c.Missing)
+			}
+		})
+	}
+}
+
+func (s *logServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *logServerStream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerMetrics returns a middleware that records the metrics of the
+// unary gRPC requests using the "grpc" subsystem. The metrics are labeled with
+// the service and method names as recorded by the generated code and the name
+// of the error returned by the method if any.
+func UnaryServerMetrics(m *middleware.Metrics) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+		ri := middleware.ContextRequestInfo(ctx)
+		if ri == nil {
+			ctx, ri = middleware.WithRequestInfo(ctx)
+		}
+		completed := m.TrackRequest("grpc", ri)
+		resp, err := handler(ctx, req)
+
+		completed(errorName(err), time.Since(started), messageLength(req), messageLength(resp))
+		return resp, err
+	})
+}
+
+// StreamServerMetrics returns a middleware that records the metrics of the
+// streaming gRPC requests using the "grpc" subsystem. The sizes of the
+// streamed messages are not recorded.
+func StreamServerMetrics(m *middleware.Metrics) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		ctx := ss.Context()
+		ri := middleware.ContextRequestInfo(ctx)
+		if ri == nil {
+			ctx, ri = middleware.WithRequestInfo(ctx)
+		}
+		completed := m.TrackRequest("grpc", ri)
+		err := handler(srv, NewWrappedServerStream(ctx, ss))
+
+		completed(errorName(err), time.Since(started), -1, -1)
+		return err
+	})
+}
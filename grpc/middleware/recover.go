@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	"goa.design/goa/v3/middleware"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerRecover returns a middleware that recovers from panics raised
+// while handling unary gRPC requests. The middleware logs the panic value and
+// stack trace using l and returns a gRPC status error with the Internal code
+// and the goa fault error encoded in its details.
+func UnaryServerRecover(l middleware.Logger) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				resp, err = nil, goagrpc.EncodeError(middleware.PanicError(ctx, l, rec))
+			}
+		}()
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerRecover returns a middleware that recovers from panics raised
+// while handling streaming gRPC requests. The middleware logs the panic value
+// and stack trace using l and returns a gRPC status error with the Internal
+// code and the goa fault error encoded in its details.
+func StreamServerRecover(l middleware.Logger) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = goagrpc.EncodeError(middleware.PanicError(ss.Context(), l, rec))
+			}
+		}()
+		return handler(srv, ss)
+	})
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestPeerCertificate(t *testing.T) {
+	var (
+		leaf = &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}
+		ca   = &x509.Certificate{Subject: pkix.Name{CommonName: "ca"}}
+	)
+	cases := map[string]struct {
+		Peer     *peer.Peer
+		Expected *x509.Certificate
+	}{
+		"no-peer":         {Peer: nil, Expected: nil},
+		"no-auth-info":    {Peer: &peer.Peer{}, Expected: nil},
+		"not-tls":         {Peer: &peer.Peer{AuthInfo: fakeAuthInfo{}}, Expected: nil},
+		"no-chain":        {Peer: tlsPeer(tls.ConnectionState{}), Expected: nil},
+		"empty-chain":     {Peer: tlsPeer(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}), Expected: nil},
+		"unverified-cert": {Peer: tlsPeer(tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}), Expected: nil},
+		"verified":        {Peer: tlsPeer(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, ca}}}), Expected: leaf},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.Peer != nil {
+				ctx = peer.NewContext(ctx, tc.Peer)
+			}
+			if cert := PeerCertificate(ctx); cert != tc.Expected {
+				t.Errorf("got certificate %v, expected %v", cert, tc.Expected)
+			}
+		})
+	}
+}
+
+// tlsPeer returns a peer whose authentication information is the given TLS
+// connection state.
+func tlsPeer(state tls.ConnectionState) *peer.Peer {
+	return &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}}
+}
+
+// fakeAuthInfo is an authentication information that is not TLS.
+type fakeAuthInfo struct{}
+
+func (fakeAuthInfo) AuthType() string { return "fake" }
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// TimestampProto converts the given time into a protocol buffer timestamp.
+// It is used by the generated code to initialize the message fields
+// corresponding to Time attributes.
+func TimestampProto(t time.Time) *timestamp.Timestamp {
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		// t is outside of the range supported by protocol buffer timestamps,
+		// encode the value anyway so that the receiver can report the error.
+		return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+	}
+	return ts
+}
+
+// Timestamp converts the given protocol buffer timestamp into a time. A nil
+// timestamp converts to the zero time.
+func Timestamp(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+}
+
+// DurationProto converts the given duration into a protocol buffer duration.
+func DurationProto(d time.Duration) *duration.Duration {
+	return ptypes.DurationProto(d)
+}
+
+// Duration converts the given protocol buffer duration into a duration. A nil
+// duration converts to 0.
+func Duration(d *duration.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos)
+}
+
+// DecimalProto converts the given decimal into its protocol buffer string
+// representation.
+func DecimalProto(d goa.Decimal) string {
+	return d.String()
+}
+
+// Decimal converts the given protocol buffer string into a decimal. The empty
+// string converts to 0. The generated code validates the decimal fields of the
+// incoming messages prior to calling Decimal so that invalid literals are
+// reported to the caller.
+func Decimal(s string) goa.Decimal {
+	d, _ := goa.ParseDecimal(s)
+	return d
+}
+
+// FieldMaskProto converts the given attribute names into a protocol buffer
+// field mask. It is used by the generated code to initialize the message fields
+// that list the result attributes selected by the client. An empty list
+// converts to nil.
+func FieldMaskProto(paths []string) *field_mask.FieldMask {
+	if len(paths) == 0 {
+		return nil
+	}
+	return &field_mask.FieldMask{Paths: paths}
+}
+
+// FieldMaskPaths returns the attribute names listed in the given protocol
+// buffer field mask. A nil field mask converts to nil.
+func FieldMaskPaths(m *field_mask.FieldMask) []string {
+	if m == nil {
+		return nil
+	}
+	return m.Paths
+}
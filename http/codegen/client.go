@@ -41,15 +41,19 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 	sections = append(sections, &codegen.SectionTemplate{
-		Name:    "client-struct",
-		Source:  clientStructT,
-		Data:    data,
-		FuncMap: map[string]interface{}{"hasWebSocket": hasWebSocket},
+		Name:   "client-struct",
+		Source: clientStructT,
+		Data:   data,
+		FuncMap: map[string]interface{}{
+			"hasWebSocket":   hasWebSocket,
+			"hasCredentials": hasCredentials,
+		},
 	})
 
 	for _, e := range data.Endpoints {
@@ -79,6 +83,14 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		})
 	}
 
+	for _, e := range data.Endpoints {
+		if e.Payload != nil {
+			if s := service.ClientCredentialsSection(e.Method, e.Payload.Ref); s != nil {
+				sections = append(sections, s)
+			}
+		}
+	}
+
 	sections = append(sections, clientWSSections(data)...)
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -103,6 +115,7 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -183,6 +196,17 @@ func mapConversionData(dt expr.DataType, varName, sourceVar, sourceField string,
 	}
 }
 
+// hasCredentials returns true if at least one of the service endpoints
+// payloads defines credentials that may be obtained from a credential source.
+func hasCredentials(data *ServiceData) bool {
+	for _, e := range data.Endpoints {
+		if len(e.Method.Requirements.Credentials()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // isBearer returns true if the security scheme uses a Bearer scheme.
 func isBearer(schemes []*service.SchemeData) bool {
 	for _, s := range schemes {
@@ -206,6 +230,12 @@ type {{ .ClientStruct }} struct {
 	// RestoreResponseBody controls whether the response bodies are reset after
 	// decoding so they can be read again.
 	RestoreResponseBody bool
+	{{- if hasCredentials . }}
+
+	// Credentials provides the credentials of the requests made to the
+	// secured endpoints whose payloads do not set them if not nil.
+	Credentials security.CredentialSource
+	{{- end }}
 
 	scheme     string
 	host       string
@@ -268,6 +298,14 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		decodeResponse = {{ .ResponseDecoder }}(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
+	{{- if .Method.Requirements.Credentials }}
+		if c.Credentials != nil {
+			var err error
+			if v, err = set{{ .Method.VarName }}Credentials(ctx, c.Credentials, v); err != nil {
+				return nil, err
+			}
+		}
+	{{- end }}
 		req, err := c.{{ .RequestInit.Name }}(ctx, {{ range .RequestInit.ClientArgs }}{{ .Ref }}{{ end }})
 		if err != nil {
 			return nil, err
@@ -352,7 +390,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			req.Header.Set({{ printf "%q" .Name }}, "Bearer "+{{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }})
 		} else {
 			{{- end }}
-			head := {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
+			head := {{ with .FieldConv }}{{ . }}({{ end }}{{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}{{ if .FieldConv }}){{ end }}
 			{{- if eq .Type.Name "array" }}
 			for _, val := range head {
 				{{- if eq .Type.ElemType.Type.Name "string" }}
@@ -392,11 +430,11 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
     }
 		{{- else if .StringSlice }}
-			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+			for _, value := range {{ with .FieldConv }}{{ . }}({{ end }}p{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .FieldConv }}){{ end }} {
 				values.Add("{{ .Name }}", value)
 			}
 		{{- else if .Slice }}
-			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+			for _, value := range {{ with .FieldConv }}{{ . }}({{ end }}p{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .FieldConv }}){{ end }} {
 				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
 				values.Add("{{ .Name }}", valueStr)
 			}
@@ -408,10 +446,15 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
 		values.Add("{{ .Name }}",
 			{{- if eq .Type.Name "bytes" }} string(
-			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
+			{{- else if eq .Type.Name "duration" }} strconv.FormatInt(int64(
+			{{- else if not (or (eq .Type.Name "string") (eq .Type.Name "time")) }} fmt.Sprintf("%v",
 			{{- end }}
-			{{- if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
-			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
+			{{- $deref := and .FieldPointer (eq .Type.Name "time") (not .FieldConv) }}
+			{{- if $deref }} ({{ end }}
+			{{- with .FieldConv }}{{ . }}({{ end }}{{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}{{ if .FieldConv }}){{ end }}{{ if $deref }}){{ end }}
+			{{- if eq .Type.Name "time" }}.Format(time.RFC3339)
+			{{- else if eq .Type.Name "duration" }}), 10)
+			{{- else if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 			{{- end }})
 			{{- if .FieldPointer }}
 		}
@@ -505,6 +548,12 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
     {{ .VarName }} := string({{ .Target }})
   {{- else if eq .Type.Name "any" -}}
     {{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+  {{- else if eq .Type.Name "time" -}}
+    {{ .VarName }} := {{ .Target }}.Format(time.RFC3339)
+  {{- else if eq .Type.Name "duration" -}}
+    {{ .VarName }} := strconv.FormatInt(int64({{ .Target }}), 10)
+  {{- else if eq .Type.Name "decimal" -}}
+    {{ .VarName }} := {{ .Target }}.String()
   {{- else }}
     // unsupported type {{ .Type.Name }} for field {{ .FieldName }}
   {{- end }}
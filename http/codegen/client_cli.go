@@ -92,8 +92,10 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 		{Path: "net/http"},
 		{Path: "os"},
 		{Path: "strconv"},
+		{Path: "time"},
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("cli", "goacli"),
 		codegen.GoaNamedImport("http", "goahttp"),
 	}
 	for _, sv := range svr.Services {
@@ -131,6 +133,7 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 				"streamingCmdExists": streamingCmdExists,
 			},
 		},
+		cli.CommandsSection(cliData),
 	}
 	for _, cmd := range cliData {
 		sections = append(sections, cli.CommandUsage(cmd))
@@ -150,6 +153,7 @@ func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.Command
 		{Path: "net/http"},
 		{Path: "os"},
 		{Path: "strconv"},
+		{Path: "time"},
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
@@ -202,9 +206,12 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 			Pointer:      arg.Pointer,
 			FieldName:    arg.FieldName,
 			FieldPointer: arg.FieldPointer,
+			Conv:         arg.Conv,
 		}
 
 		f := cli.NewFlagData(e.ServiceName, e.Method.Name, arg.Name, arg.TypeName, arg.Description, arg.Required, arg.Example)
+		f.Values = cli.FlagValues(arg.Enum)
+		f.Credential = cli.FlagCredential(e.Method, arg.FieldName)
 		flags[i] = f
 		params[i] = f.FullName
 		if arg.FieldName == "" && arg.Name != "body" {
@@ -36,6 +36,9 @@ func TestClientCLIFiles(t *testing.T) {
 		{"payload-array-user-type", testdata.PayloadBodyInlineArrayUserDSL, testdata.PayloadArrayUserTypeBuildCode, 1, 1},
 		{"payload-map-user-type", testdata.PayloadBodyInlineMapUserDSL, testdata.PayloadMapUserTypeBuildCode, 1, 1},
 		{"map-query", testdata.PayloadMapQueryPrimitiveArrayDSL, testdata.MapQueryParseCode, 0, 3},
+		{"multi-commands", testdata.MultiDSL, testdata.MultiCommandsCode, 0, 4},
+		{"enum-commands", testdata.PayloadQueryStringValidateDSL, testdata.QueryStringValidateCommandsCode, 0, 4},
+		{"credential-commands", testdata.ClientCredentialsDSL, testdata.ClientCredentialsCommandsCode, 0, 4},
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.MapQueryObjectBuildCode, 1, 1},
 		{"empty-body-build", testdata.PayloadBodyPrimitiveFieldEmptyDSL, testdata.EmptyBodyBuildCode, 1, 1},
 		{"with-params-and-headers-dsl", testdata.WithParamsAndHeadersBlockDSL, testdata.WithParamsAndHeadersBlockBuildCode, 1, 1},
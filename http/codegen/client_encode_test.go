@@ -23,6 +23,8 @@ func TestClientEncode(t *testing.T) {
 		{"query-int32-validate", testdata.PayloadQueryInt32ValidateDSL, testdata.PayloadQueryInt32ValidateEncodeCode},
 		{"query-int64", testdata.PayloadQueryInt64DSL, testdata.PayloadQueryInt64EncodeCode},
 		{"query-int64-validate", testdata.PayloadQueryInt64ValidateDSL, testdata.PayloadQueryInt64ValidateEncodeCode},
+		{"query-duration", testdata.PayloadQueryDurationDSL, testdata.PayloadQueryDurationEncodeCode},
+		{"query-time", testdata.PayloadQueryTimeDSL, testdata.PayloadQueryTimeEncodeCode},
 		{"query-uint", testdata.PayloadQueryUIntDSL, testdata.PayloadQueryUIntEncodeCode},
 		{"query-uint-validate", testdata.PayloadQueryUIntValidateDSL, testdata.PayloadQueryUIntValidateEncodeCode},
 		{"query-uint32", testdata.PayloadQueryUInt32DSL, testdata.PayloadQueryUInt32EncodeCode},
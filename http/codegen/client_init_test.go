@@ -36,3 +36,32 @@ func TestClientInit(t *testing.T) {
 		})
 	}
 }
+
+func TestClientCredentials(t *testing.T) {
+	cases := []struct {
+		Name       string
+		DSL        func()
+		Code       string
+		SectionNum int
+	}{
+		{"client-struct", testdata.ClientCredentialsDSL, testdata.ClientCredentialsClientStructCode, 1},
+		{"endpoint-init", testdata.ClientCredentialsDSL, testdata.ClientCredentialsEndpointInitCode, 3},
+		{"endpoint-init-unsecure", testdata.ClientCredentialsDSL, testdata.ClientCredentialsUnsecureEndpointInitCode, 5},
+		{"token-or-key", testdata.ClientCredentialsDSL, testdata.ClientCredentialsTokenOrKeyCode, 6},
+		{"access-token", testdata.ClientCredentialsDSL, testdata.ClientCredentialsAccessTokenCode, 7},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			sections := fs[0].SectionTemplates
+			if len(sections) != 8 {
+				t.Fatalf("got %d sections, expected 8", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[c.SectionNum])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
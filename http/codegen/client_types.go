@@ -239,7 +239,7 @@ func {{ .Name }}({{- range .ClientArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 		{{- if .ReturnIsStruct }}
 			{{- range .ClientArgs }}
 				{{- if .FieldName }}
-			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}
+			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }}
 				{{- end }}
 			{{- end }}
 		{{- end }}
@@ -249,7 +249,7 @@ func {{ .Name }}({{- range .ClientArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 			return &{{ .ReturnTypeName }}{
 			{{- range .ClientArgs }}
 				{{- if .FieldName }}
-				{{ .FieldName }}: {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }},
+				{{ .FieldName }}: {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }},
 				{{- end }}
 			{{- end }}
 			}
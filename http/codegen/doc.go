@@ -1,4 +1,5 @@
-/*Package codegen contains code generation logic to generate HTTP server and
+/*
+Package codegen contains code generation logic to generate HTTP server and
 client that wrap the generated goa endpoint and the OpenAPI 2.0 specifications
 for the HTTP endpoints.
 */
@@ -57,6 +57,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{Path: "time"},
 		{Path: "github.com/gorilla/websocket"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("cli", "goacli"),
 		codegen.GoaNamedImport("http", "goahttp"),
 		{Path: genpkg + "/http/cli/" + svrdata.Dir, Name: "cli"},
 		{Path: rootPath, Name: apiPkg},
@@ -161,5 +162,9 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
   return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+  return cli.Commands()
+}
 `
 )
@@ -8,6 +8,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -33,14 +34,17 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 	fpath := filepath.Join("cmd", svrdata.Dir, "http.go")
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "crypto/tls"},
+		{Path: "crypto/x509"},
+		{Path: "io/ioutil"},
 		{Path: "log"},
 		{Path: "net/http"},
 		{Path: "net/url"},
 		{Path: "os"},
-		{Path: "sync"},
 		{Path: "time"},
 		codegen.GoaNamedImport("http", "goahttp"),
 		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		codegen.GoaImport("lifecycle"),
 		codegen.GoaImport("middleware"),
 		{Path: "github.com/gorilla/websocket"},
 	}
@@ -74,10 +78,14 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
 
-	var svcdata []*ServiceData
+	var (
+		svcdata []*ServiceData
+		svcs    []*service.Data
+	)
 	for _, svc := range svr.Services {
 		if data := HTTPServices.Get(svc); data != nil {
 			svcdata = append(svcdata, data)
+			svcs = append(svcs, data.Service)
 		}
 	}
 
@@ -108,7 +116,9 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Source: httpSvrEndT,
 			Data: map[string]interface{}{
 				"Services": svcdata,
+				"MTLS":     service.NeedMTLS(svcs),
 			},
+			FuncMap: map[string]interface{}{"needStream": needStream},
 		},
 		&codegen.SectionTemplate{Name: "server-http-errorhandler", Source: httpSvrErrorHandlerT},
 	}
@@ -199,8 +209,8 @@ func {{ .FuncName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
 `
 
 	// input: map[string]interface{}{"Services":[]*ServiceData}
-	httpSvrStartT = `{{ comment "handleHTTPServer starts configures and starts a HTTP server on the given URL. It shuts down the server if any error is received in the error channel." }}
-func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	httpSvrStartT = `{{ comment "handleHTTPServer configures a HTTP server on the given URL and adds it to the group that runs the servers." }}
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, logger *log.Logger, debug bool) {
 `
 
 	httpSvrLoggerT = `
@@ -283,11 +293,39 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "MTLS": bool}
 	httpSvrEndT = `
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	{{- if needStream .Services }}
+	// The read and write timeouts also apply to the websocket connections
+	// and are thus left unset.
+	{{- end }}
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+	{{- if not (needStream .Services) }}
+		Read:  30 * time.Second,
+		Write: 30 * time.Second,
+	{{- end }}
+		Idle:  2 * time.Minute,
+	})
+	{{- if .MTLS }}
+
+	{{ comment "Require and verify client certificates as required by the mutual TLS security scheme. Change the paths to the CA and server certificates as required." }}
+	{
+		ca, err := ioutil.ReadFile("ca.crt")
+		if err != nil {
+			logger.Fatalf("failed to read CA certificate: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			logger.Fatalf("failed to load CA certificate")
+		}
+		srv.TLSConfig = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  pool,
+		}
+	}
+	{{- end }}
 
 	{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
@@ -295,25 +333,16 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 		}
 	{{- end }}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		{{ comment "Start HTTP server in a separate goroutine." }}
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	{{ comment "Add the server to the group that starts it and shuts it down gracefully." }}
+	{{- if .MTLS }}
+	grp.Add(lifecycle.HTTPS(srv, "server.crt", "server.key"))
+	{{- else }}
+	grp.Add(lifecycle.HTTP(srv))
+	{{- end }}
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		{{ comment "Shutdown gracefully with a 30s timeout." }}
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 `
 
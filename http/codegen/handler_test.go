@@ -20,6 +20,7 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
+		{"payload result limits", testdata.ServerPayloadResultLimitsDSL, testdata.ServerPayloadResultLimitsHandlerConstructorCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -39,3 +40,32 @@ func TestHandlerInit(t *testing.T) {
 		})
 	}
 }
+
+func TestMountHandler(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"no payload no result", testdata.ServerNoPayloadNoResultDSL, testdata.ServerNoPayloadNoResultMountHandlerCode},
+		{"cors", testdata.ServerCORSDSL, testdata.ServerCORSMountHandlerCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[0].SectionTemplates
+			if len(sections) < 8 {
+				t.Fatalf("got %d sections, expected at least 8", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[7])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
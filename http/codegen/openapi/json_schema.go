@@ -39,13 +39,25 @@ type (
 		Pattern              string        `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 		Minimum              *float64      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 		Maximum              *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		ExclusiveMinimum     bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+		MultipleOf           *float64      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 		MinLength            *int          `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 		MaxLength            *int          `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 		MinItems             *int          `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 		MaxItems             *int          `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+		UniqueItems          bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+		MinProperties        *int          `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+		MaxProperties        *int          `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
 		Required             []string      `json:"required,omitempty" yaml:"required,omitempty"`
 		AdditionalProperties bool          `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
+		// Nullable is true if the value may be null. OpenAPI v2 has no
+		// equivalent to the OpenAPI v3 nullable keyword, the value is
+		// rendered with the x-nullable extension which the v2 tooling
+		// honours and converts to nullable when upgrading to v3.
+		Nullable bool `json:"x-nullable,omitempty" yaml:"x-nullable,omitempty"`
+
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 
@@ -322,6 +334,17 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		case expr.BytesKind:
 			s.Type = Type("string")
 			s.Format = "byte"
+		case expr.TimeKind:
+			s.Type = Type("string")
+			s.Format = "date-time"
+		case expr.DurationKind:
+			// time.Duration values are encoded as JSON numbers of
+			// nanoseconds.
+			s.Type = Type("integer")
+			s.Format = "int64"
+		case expr.DecimalKind:
+			s.Type = Type("string")
+			s.Format = "decimal"
 		}
 	case *expr.Array:
 		s.Type = Array
@@ -377,6 +400,13 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.MaxLength, other.MaxLength, maxInt(s.MaxLength, other.MaxLength)},
 		{&s.MinItems, other.MinItems, minInt(s.MinItems, other.MinItems)},
 		{&s.MaxItems, other.MaxItems, maxInt(s.MaxItems, other.MaxItems)},
+		{&s.ExclusiveMinimum, other.ExclusiveMinimum, !s.ExclusiveMinimum},
+		{&s.ExclusiveMaximum, other.ExclusiveMaximum, !s.ExclusiveMaximum},
+		{&s.MultipleOf, other.MultipleOf, s.MultipleOf == nil},
+		{&s.UniqueItems, other.UniqueItems, !s.UniqueItems},
+		{&s.MinProperties, other.MinProperties, minInt(s.MinProperties, other.MinProperties)},
+		{&s.MaxProperties, other.MaxProperties, maxInt(s.MaxProperties, other.MaxProperties)},
+		{&s.Nullable, other.Nullable, !s.Nullable},
 	}
 }
 
@@ -427,12 +457,19 @@ func (s *Schema) Dup() *Schema {
 		Pattern:              s.Pattern,
 		Minimum:              s.Minimum,
 		Maximum:              s.Maximum,
+		ExclusiveMinimum:     s.ExclusiveMinimum,
+		ExclusiveMaximum:     s.ExclusiveMaximum,
+		MultipleOf:           s.MultipleOf,
 		MinLength:            s.MinLength,
 		MaxLength:            s.MaxLength,
 		MinItems:             s.MinItems,
 		MaxItems:             s.MaxItems,
+		UniqueItems:          s.UniqueItems,
+		MinProperties:        s.MinProperties,
+		MaxProperties:        s.MaxProperties,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		Nullable:             s.Nullable,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -458,6 +495,7 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	s.Description = at.Description
 	s.Example = at.Example(api.Random())
 	s.Extensions = ExtensionsFromExpr(at.Meta)
+	s.Nullable = at.IsNullable()
 	initAttributeValidation(s, at)
 
 	return s
@@ -478,20 +516,38 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 	if val.Maximum != nil {
 		s.Maximum = val.Maximum
 	}
+	if min := val.ExclusiveMinimum; min != nil && (s.Minimum == nil || *min >= *s.Minimum) {
+		// JSON schema draft 4 defines exclusiveMinimum as a boolean
+		// modifier of minimum.
+		s.Minimum = min
+		s.ExclusiveMinimum = true
+	}
+	if max := val.ExclusiveMaximum; max != nil && (s.Maximum == nil || *max <= *s.Maximum) {
+		s.Maximum = max
+		s.ExclusiveMaximum = true
+	}
+	s.MultipleOf = val.MultipleOf
 	if val.MinLength != nil {
-		if _, ok := at.Type.(*expr.Array); ok {
+		switch at.Type.(type) {
+		case *expr.Array:
 			s.MinItems = val.MinLength
-		} else {
+		case *expr.Map:
+			s.MinProperties = val.MinLength
+		default:
 			s.MinLength = val.MinLength
 		}
 	}
 	if val.MaxLength != nil {
-		if _, ok := at.Type.(*expr.Array); ok {
+		switch at.Type.(type) {
+		case *expr.Array:
 			s.MaxItems = val.MaxLength
-		} else {
+		case *expr.Map:
+			s.MaxProperties = val.MaxLength
+		default:
 			s.MaxLength = val.MaxLength
 		}
 	}
+	s.UniqueItems = val.UniqueItems
 	s.Required = val.Required
 }
 
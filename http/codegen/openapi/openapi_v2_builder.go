@@ -202,6 +202,10 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 		for _, e := range svc.HTTPEndpoints {
 			for _, req := range e.Requirements {
 				for _, s := range req.Schemes {
+					if s.Kind == expr.MTLSKind {
+						// OpenAPI V2 spec does not support mutual TLS.
+						continue
+					}
 					sd := SecurityDefinition{
 						Description: s.Description,
 						Extensions:  ExtensionsFromExpr(s.Meta),
@@ -472,12 +476,31 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	case expr.Bytes:
 		p.Type = "string"
 		p.Format = "byte"
+	case expr.Time, expr.Duration, expr.Decimal:
+		p.Type, p.Format = paramTypeFormat(at.Type)
 	}
 	p.Extensions = ExtensionsFromExpr(at.Meta)
 	initValidations(at, p)
 	return p
 }
 
+// paramTypeFormat returns the OpenAPI type and format of parameters and
+// headers of type time, duration or decimal. The generated code encodes these
+// values as RFC3339 strings, integer numbers of nanoseconds (as in JSON
+// bodies) and decimal literals respectively.
+func paramTypeFormat(dt expr.DataType) (string, string) {
+	switch dt.Kind() {
+	case expr.TimeKind:
+		return "string", "date-time"
+	case expr.DurationKind:
+		return "integer", "int64"
+	case expr.DecimalKind:
+		return "string", "decimal"
+	default:
+		return "string", ""
+	}
+}
+
 func itemsFromExpr(at *expr.AttributeExpr) *Items {
 	items := &Items{Type: at.Type.Name()}
 	switch actual := at.Type.(type) {
@@ -489,6 +512,8 @@ func itemsFromExpr(at *expr.AttributeExpr) *Items {
 			items.Type = "number"
 		case expr.BytesKind:
 			items.Type = "string"
+		case expr.TimeKind, expr.DurationKind, expr.DecimalKind:
+			items.Type, items.Format = paramTypeFormat(actual)
 		}
 	}
 	initValidations(at, items)
@@ -537,6 +562,10 @@ func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 			Description: at.Description,
 			Type:        at.Type.Name(),
 		}
+		switch at.Type {
+		case expr.Time, expr.Duration, expr.Decimal:
+			header.Type, header.Format = paramTypeFormat(at.Type)
+		}
 		initValidations(at, header)
 		res[n] = header
 		return nil
@@ -715,10 +744,14 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		description := endpoint.Description()
 
-		requirements := make([]map[string][]string, len(endpoint.Requirements))
-		for i, req := range endpoint.Requirements {
+		requirements := make([]map[string][]string, 0, len(endpoint.Requirements))
+		for _, req := range endpoint.Requirements {
 			requirement := make(map[string][]string)
 			for _, s := range req.Schemes {
+				if s.Kind == expr.MTLSKind {
+					// OpenAPI V2 spec does not support mutual TLS.
+					continue
+				}
 				requirement[s.Hash()] = []string{}
 				switch s.Kind {
 				case expr.OAuth2Kind:
@@ -737,7 +770,9 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 					}
 				}
 			}
-			requirements[i] = requirement
+			if len(requirement) > 0 {
+				requirements = append(requirements, requirement)
+			}
 		}
 
 		operation := &Operation{
@@ -866,6 +901,56 @@ func initMaximumValidation(def interface{}, max *float64) {
 	}
 }
 
+func initExclusiveMinimumValidation(def interface{}, min *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Header:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Items:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	}
+}
+
+func initExclusiveMaximumValidation(def interface{}, max *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Header:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Items:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	}
+}
+
+func initMultipleOfValidation(def interface{}, multiple float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.MultipleOf = multiple
+	case *Header:
+		actual.MultipleOf = multiple
+	case *Items:
+		actual.MultipleOf = multiple
+	}
+}
+
+func initUniqueItemsValidation(def interface{}) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.UniqueItems = true
+	case *Header:
+		actual.UniqueItems = true
+	case *Items:
+		actual.UniqueItems = true
+	}
+}
+
 func initMinLengthValidation(def interface{}, isArray bool, min *int) {
 	switch actual := def.(type) {
 	case *Parameter:
@@ -910,10 +995,22 @@ func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	if val.Maximum != nil {
 		initMaximumValidation(def, val.Maximum)
 	}
+	if min := val.ExclusiveMinimum; min != nil && (val.Minimum == nil || *min >= *val.Minimum) {
+		initExclusiveMinimumValidation(def, min)
+	}
+	if max := val.ExclusiveMaximum; max != nil && (val.Maximum == nil || *max <= *val.Maximum) {
+		initExclusiveMaximumValidation(def, max)
+	}
+	if val.MultipleOf != nil {
+		initMultipleOfValidation(def, *val.MultipleOf)
+	}
 	if val.MinLength != nil {
 		initMinLengthValidation(def, expr.IsArray(attr.Type), val.MinLength)
 	}
 	if val.MaxLength != nil {
 		initMaxLengthValidation(def, expr.IsArray(attr.Type), val.MaxLength)
 	}
+	if val.UniqueItems {
+		initUniqueItemsValidation(def)
+	}
 }
@@ -51,8 +51,13 @@ func TestBuildPathFromFileServer(t *testing.T) {
 }
 
 func TestBuildPathFromExpr(t *testing.T) {
+	var (
+		mtls  = &expr.SchemeExpr{Kind: expr.MTLSKind, SchemeName: "mtls"}
+		basic = &expr.SchemeExpr{Kind: expr.BasicAuthKind, SchemeName: "basic"}
+	)
 	cases := map[string]struct {
 		multipartRequest bool
+		requirements     []*expr.SecurityExpr
 		expected         Operation
 	}{
 		"multipart request": {
@@ -77,6 +82,20 @@ func TestBuildPathFromExpr(t *testing.T) {
 				},
 			},
 		},
+		"mutual TLS requirements": {
+			requirements: []*expr.SecurityExpr{
+				{Schemes: []*expr.SchemeExpr{mtls}},
+				{Schemes: []*expr.SchemeExpr{mtls, basic}},
+			},
+			expected: Operation{
+				Parameters: []*Parameter{
+					&Parameter{
+						In: "body",
+					},
+				},
+				Security: []map[string][]string{{basic.Hash(): {}}},
+			},
+		},
 	}
 	expr.Root.API = &expr.APIExpr{
 		HTTP: &expr.HTTPExpr{
@@ -109,6 +128,7 @@ func TestBuildPathFromExpr(t *testing.T) {
 						Type: expr.String,
 					},
 					MultipartRequest: tc.multipartRequest,
+					Requirements:     tc.requirements,
 				},
 			}
 			basePath := "/"
@@ -124,6 +144,20 @@ func TestBuildPathFromExpr(t *testing.T) {
 						}
 					}
 				}
+				if len(actual.Security) != len(tc.expected.Security) {
+					t.Errorf("got %d security requirements, expected %d", len(actual.Security), len(tc.expected.Security))
+				} else {
+					for i, req := range actual.Security {
+						if len(req) != len(tc.expected.Security[i]) {
+							t.Errorf("got security requirement %#v, expected %#v at index %d", req, tc.expected.Security[i], i)
+						}
+						for name := range tc.expected.Security[i] {
+							if _, ok := req[name]; !ok {
+								t.Errorf("got security requirement %#v, expected %#v at index %d", req, tc.expected.Security[i], i)
+							}
+						}
+					}
+				}
 				if len(actual.Parameters) != len(tc.expected.Parameters) {
 					t.Errorf("expected the number of parameters to match %d got %d", len(actual.Parameters), len(tc.expected.Parameters))
 				} else {
@@ -137,3 +171,50 @@ func TestBuildPathFromExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestSecuritySpecFromExpr(t *testing.T) {
+	mtls := &expr.SchemeExpr{
+		Kind:        expr.MTLSKind,
+		SchemeName:  "mtls",
+		Description: "Client certificate",
+		Scopes:      []*expr.ScopeExpr{{Name: "api:read", Description: "Read access"}},
+	}
+	basic := &expr.SchemeExpr{
+		Kind:        expr.BasicAuthKind,
+		SchemeName:  "basic",
+		Description: "Basic authentication",
+		Scopes:      []*expr.ScopeExpr{{Name: "api:read", Description: "Read access"}},
+	}
+	root := &expr.RootExpr{
+		API: &expr.APIExpr{
+			HTTP: &expr.HTTPExpr{
+				Services: []*expr.HTTPServiceExpr{{
+					HTTPEndpoints: []*expr.HTTPEndpointExpr{{
+						Requirements: []*expr.SecurityExpr{
+							{Schemes: []*expr.SchemeExpr{mtls}},
+							{Schemes: []*expr.SchemeExpr{basic}},
+						},
+					}},
+				}},
+			},
+		},
+	}
+	sds := securitySpecFromExpr(root)
+	if len(sds) != 1 {
+		t.Fatalf("got %d security definitions, expected 1", len(sds))
+	}
+	if _, ok := sds[mtls.Hash()]; ok {
+		t.Errorf("got security definition %q, expected mutual TLS schemes to be omitted", mtls.Hash())
+	}
+	sd, ok := sds[basic.Hash()]
+	if !ok {
+		t.Fatalf("security definition %q not found", basic.Hash())
+	}
+	if sd.Type != "basic" {
+		t.Errorf("got type %q, expected %q", sd.Type, "basic")
+	}
+	expected := "Basic authentication\n\n**Security Scopes**:\n  * `api:read`: Read access"
+	if sd.Description != expected {
+		t.Errorf("got description %q, expected %q", sd.Description, expected)
+	}
+}
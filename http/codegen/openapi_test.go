@@ -211,6 +211,7 @@ func TestExtensions(t *testing.T) {
 		DSL  func()
 	}{
 		{"endpoint", testdata.ExtensionDSL},
+		{"nullable", testdata.NullableDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -44,6 +44,7 @@ func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTempl
 			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
 		}),
 	}
 	sdata := HTTPServices.Get(svc.Name())
@@ -48,6 +48,8 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaImport("middleware"),
+			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
@@ -99,10 +101,12 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "net/http"},
+			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
 			{Path: "encoding/json"},
 			{Path: "mime/multipart"},
+			{Path: "time"},
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -356,8 +360,36 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 			h.ServeHTTP(w, r)
 		}
 	}
+	{{- if .CORS }}
+	cors := goahttp.NewCORSPolicy(
+		{{- range .CORS }}
+		&goahttp.CORSOrigin{
+			Origin: {{ printf "%q" .Origin }},
+			{{- if .Methods }}
+			Methods: {{ printf "%#v" .Methods }},
+			{{- end }}
+			{{- if .Headers }}
+			Headers: {{ printf "%#v" .Headers }},
+			{{- end }}
+			{{- if .ExposedHeaders }}
+			ExposedHeaders: {{ printf "%#v" .ExposedHeaders }},
+			{{- end }}
+			{{- if .MaxAge }}
+			MaxAge: {{ .MaxAge }},
+			{{- end }}
+			{{- if .Credentials }}
+			Credentials: true,
+			{{- end }}
+		},
+		{{- end }}
+	)
+	f = cors.Handler(f)
+	{{- end }}
 	{{- range .Routes }}
 	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+	{{- if $.CORS }}
+	goahttp.MountCORSPreflight(mux, "{{ .Path }}", "{{ .Verb }}", cors)
+	{{- end }}
 	{{- end }}
 }
 `
@@ -409,10 +441,34 @@ func {{ .HandlerInit }}(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+		middleware.SetServiceMethod(ctx)
+	{{- if .NextPageLink }}
+		ctx = context.WithValue(ctx, goahttp.RequestURLKey, r.URL)
+	{{- end }}
+	{{- if .Method.Schemes.HasType "MTLS" }}
+		ctx = security.WithPeerCertificate(ctx, goahttp.PeerCertificate(r))
+	{{- end }}
+	{{- if .Method.RequestTimeout }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Method.RequestTimeout }})
+		defer cancel()
+	{{- end }}
+	{{- if .Method.RequestBodyLimit }}
+		if err := goahttp.LimitRequestBody(r, {{ .Method.RequestBodyLimit }}); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	{{- end }}
 
 	{{- if .Payload.Ref }}
 		payload, err := decodeRequest(r)
 		if err != nil {
+		{{- if .Method.RequestBodyLimit }}
+			if goahttp.RequestBodyLimitExceeded(r) {
+				err = goahttp.ErrRequestBodyTooLarge({{ .Method.RequestBodyLimit }})
+			}
+		{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
@@ -450,6 +506,11 @@ func {{ .HandlerInit }}(
 				return
 			}
 			{{- end }}
+			{{- if .Method.RequestTimeout }}
+			if ctx.Err() == context.DeadlineExceeded {
+				err = goahttp.ErrDeadlineExceeded({{ .Method.RequestTimeout }})
+			}
+			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
@@ -601,6 +662,11 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		}
 		{{- end }}
 
+	{{- else if .CommaSeparated }}
+		for _, v := range r.URL.Query()["{{ .Name }}"] {
+			{{ .VarName }} = append({{ .VarName }}, strings.Split(v, ",")...)
+		}
+
 	{{- else if .StringSlice }}
 		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
 		{{- if .Required }}
@@ -947,6 +1013,29 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "time" }}
+		v, err2 := time.Parse(time.RFC3339, {{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "time"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, 64)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{- if .Pointer }}
+		pv := time.Duration(v)
+		{{ .VarName }} = &pv
+		{{- else }}
+		{{ .VarName }} = time.Duration(v)
+		{{- end }}
+	{{- else if eq .Type.Name "decimal" }}
+		v, err2 := goa.ParseDecimal({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -1012,6 +1101,24 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
 			{{ .VarName }}[i] = rv
+		{{- else if eq .Type.ElemType.Type.Name "time" }}
+			v, err2 := time.Parse(time.RFC3339, rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of times"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "duration" }}
+			v, err2 := strconv.ParseInt(rv, 10, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of durations"))
+			}
+			{{ .VarName }}[i] = time.Duration(v)
+		{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+			v, err2 := goa.ParseDecimal(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of decimals"))
+			}
+			{{ .VarName }}[i] = v
 		{{- else }}
 			// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
 		{{- end }}
@@ -1031,6 +1138,13 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 		{{- else }}
 			res := v.({{ .Result.Ref }})
 		{{- end }}
+		{{- with .NextPageLink }}
+			if res.{{ .NextField }} != nil {
+				if u, ok := ctx.Value(goahttp.RequestURLKey).(*url.URL); ok {
+					w.Header().Set("Link", goahttp.NextPageLink(u, {{ printf "%q" .Param }}, {{ if .IsInt }}strconv.Itoa(*res.{{ .NextField }}){{ else }}*res.{{ .NextField }}{{ end }}))
+				}
+			}
+		{{- end }}
 		{{- range .Result.Responses }}
 			{{- if .ContentType }}
 				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
@@ -1132,10 +1246,10 @@ const responseT = `{{ define "response" -}}
 		{{- end }}
 
 		{{- if eq .Type.Name "string" }}
-	w.Header().Set("{{ .CanonicalName }}", {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }})
+	w.Header().Set("{{ .CanonicalName }}", {{ with .FieldConv }}{{ . }}({{ end }}{{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .FieldConv }}){{ end }})
 		{{- else }}
-	val := res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}
-	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not .FieldPointer) "val") }}
+	val := {{ with .FieldConv }}{{ . }}({{ end }}{{ if and .FieldConv .FieldPointer }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .FieldConv }}){{ end }}
+	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not (and .FieldPointer (not .FieldConv))) "val") }}
 	w.Header().Set("{{ .CanonicalName }}", {{ .VarName }}s)
 		{{- end }}
 
@@ -1181,6 +1295,12 @@ const responseT = `{{ define "response" -}}
 		{{ .VarName }} := string({{ .Target }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+	{{- else if eq .Type.Name "time" -}}
+		{{ .VarName }} := {{ .Target }}.Format(time.RFC3339)
+	{{- else if eq .Type.Name "duration" -}}
+		{{ .VarName }} := strconv.FormatInt(int64({{ if not .Required }}*{{ end }}{{ .Target }}), 10)
+	{{- else if eq .Type.Name "decimal" -}}
+		{{ .VarName }} := {{ .Target }}.String()
 	{{- else if eq .Type.Name "array" -}}
 		{{- if eq .Type.ElemType.Type.Name "string" -}}
 		{{ .VarName }} := strings.Join({{ .Target }}, ", ")
@@ -1225,7 +1345,7 @@ func {{ .InitName }}(mux goahttp.Muxer, {{ .VarName }} {{ .FuncName }}) func(r *
 			{{- if .Payload.Request.PayloadInit }}
 				{{- range .Payload.Request.PayloadInit.ServerArgs }}
 					{{- if .FieldName }}
-			(*p).{{ .FieldName }} = {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}
+			(*p).{{ .FieldName }} = {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }}
 					{{- end }}
 				{{- end }}
 			{{- end }}
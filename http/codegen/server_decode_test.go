@@ -23,6 +23,8 @@ func TestDecode(t *testing.T) {
 		{"query-int32-validate", testdata.PayloadQueryInt32ValidateDSL, testdata.PayloadQueryInt32ValidateDecodeCode},
 		{"query-int64", testdata.PayloadQueryInt64DSL, testdata.PayloadQueryInt64DecodeCode},
 		{"query-int64-validate", testdata.PayloadQueryInt64ValidateDSL, testdata.PayloadQueryInt64ValidateDecodeCode},
+		{"query-duration", testdata.PayloadQueryDurationDSL, testdata.PayloadQueryDurationDecodeCode},
+		{"query-fields", testdata.PayloadQueryFieldsDSL, testdata.PayloadQueryFieldsDecodeCode},
 		{"query-uint", testdata.PayloadQueryUIntDSL, testdata.PayloadQueryUIntDecodeCode},
 		{"query-uint-validate", testdata.PayloadQueryUIntValidateDSL, testdata.PayloadQueryUIntValidateDecodeCode},
 		{"query-uint32", testdata.PayloadQueryUInt32DSL, testdata.PayloadQueryUInt32DecodeCode},
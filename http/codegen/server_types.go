@@ -204,6 +204,13 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 // input: TypeData
 const typeDeclT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
+{{- if .OmitUnset }}
+
+{{ printf "MarshalJSON encodes %s omitting the nullable fields that are not set." .VarName | comment }}
+func (body {{ .VarName }}) MarshalJSON() ([]byte, error) {
+	return goa.MarshalOmitUnset(body)
+}
+{{- end }}
 `
 
 // input: InitData
@@ -219,7 +226,7 @@ func {{ .Name }}({{- range .ServerArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 		{{- if .ReturnIsStruct }}
 			{{- range .ServerArgs }}
 				{{- if .FieldName }}
-			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}
+			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }}
 				{{- end }}
 			{{- end }}
 		{{- end }}
@@ -229,7 +236,7 @@ func {{ .Name }}({{- range .ServerArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 			return &{{ .ReturnTypeName }}{
 			{{- range .ServerArgs }}
 				{{- if .FieldName }}
-				{{ .FieldName }}: {{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }},
+				{{ .FieldName }}: {{ with .Conv }}{{ . }}({{ end }}{{ if and (not .Pointer) .FieldPointer }}&{{ end }}{{ .Name }}{{ if .Conv }}){{ end }},
 				{{- end }}
 			{{- end }}
 			}
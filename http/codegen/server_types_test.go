@@ -19,6 +19,8 @@ func TestServerTypes(t *testing.T) {
 		{"mixed-payload-attrs", testdata.MixedPayloadInBodyDSL, MixedPayloadInBodyServerTypesFile},
 		{"multiple-methods", testdata.MultipleMethodsDSL, MultipleMethodsServerTypesFile},
 		{"payload-extend-validate", testdata.PayloadExtendedValidateDSL, PayloadExtendedValidateServerTypesFile},
+		{"payload-nullable", testdata.PayloadNullableDSL, PayloadNullableServerTypesFile},
+		{"payload-struct-tags", testdata.PayloadStructTagsDSL, PayloadStructTagsServerTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -217,3 +219,58 @@ func ValidateMethodQueryStringExtendedValidatePayloadRequestBody(body *MethodQue
 	return
 }
 `
+
+const PayloadNullableServerTypesFile = `// MethodNullableRequestBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP request body.
+type MethodNullableRequestBody struct {
+	Name     *string            ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	Nickname goa.NullableString ` + "`" + `form:"nickname,omitempty" json:"nickname,omitempty" xml:"nickname,omitempty"` + "`" + `
+	Age      goa.NullableInt    ` + "`" + `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes MethodNullableRequestBody omitting the nullable fields
+// that are not set.
+func (body MethodNullableRequestBody) MarshalJSON() ([]byte, error) {
+	return goa.MarshalOmitUnset(body)
+}
+
+// NewMethodNullablePayload builds a ServiceNullable service MethodNullable
+// endpoint payload.
+func NewMethodNullablePayload(body *MethodNullableRequestBody) *servicenullable.MethodNullablePayload {
+	v := &servicenullable.MethodNullablePayload{
+		Name:     body.Name,
+		Nickname: body.Nickname,
+		Age:      body.Age,
+	}
+	return v
+}
+
+// ValidateMethodNullableRequestBody runs the validations defined on
+// MethodNullableRequestBody
+func ValidateMethodNullableRequestBody(body *MethodNullableRequestBody) (err error) {
+	if !body.Age.Set {
+		err = goa.MergeErrors(err, goa.MissingFieldError("age", "body"))
+	}
+	return
+}
+`
+
+const PayloadStructTagsServerTypesFile = `// MethodStructTagsRequestBody is the type of the "ServiceStructTags" service
+// "MethodStructTags" endpoint HTTP request body.
+type MethodStructTagsRequestBody struct {
+	UserID    *string ` + "`" + `form:"userID,omitempty" json:"userID,omitempty" xml:"userID,omitempty"` + "`" + `
+	Ssn       *string ` + "`" + `json:"SSN,omitempty"` + "`" + `
+	OwnerName *string ` + "`" + `form:"ownerName,omitempty" json:"ownerName,omitempty" xml:"ownerName,omitempty"` + "`" + `
+}
+
+// NewMethodStructTagsPayload builds a ServiceStructTags service
+// MethodStructTags endpoint payload.
+func NewMethodStructTagsPayload(body *MethodStructTagsRequestBody) *servicestructtags.MethodStructTagsPayload {
+	v := &servicestructtags.MethodStructTagsPayload{
+		UserID:    body.UserID,
+		Ssn:       body.Ssn,
+		OwnerName: body.OwnerName,
+	}
+	return v
+}
+`
@@ -133,6 +133,12 @@ type (
 		// ServerStream holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerStream *StreamData
+		// NextPageLink holds the data needed to set the Link header of
+		// the responses of paginated endpoints.
+		NextPageLink *NextPageLinkData
+		// CORS lists the CORS origin policies that apply to the
+		// endpoint, one per allowed origin.
+		CORS []*CORSData
 
 		// client
 
@@ -375,6 +381,14 @@ type (
 		// FieldPointer if true indicates that the data structure field is a
 		// pointer.
 		FieldPointer bool
+		// Conv is the name of the type or function used to convert the
+		// argument into the data structure field value if they have
+		// different Go types (e.g. the field holds a primitive user type).
+		Conv string
+		// FieldConv is the name of the type or function used to convert
+		// the data structure field value into the argument value if they
+		// have different Go types.
+		FieldConv string
 		// TypeName is the argument type name.
 		TypeName string
 		// TypeRef is the argument type reference.
@@ -390,6 +404,8 @@ type (
 		Validate string
 		// Example is a example value
 		Example interface{}
+		// Enum lists the values allowed for the argument if any.
+		Enum []interface{}
 	}
 
 	// RouteData describes a route.
@@ -417,6 +433,14 @@ type (
 		// FieldPointer if true indicates that the struct field that holds the
 		// param value is a pointer.
 		FieldPointer bool
+		// Conv is the name of the type or function used to convert the
+		// param value into the struct field value if they have different
+		// Go types (e.g. the field holds a primitive user type).
+		Conv string
+		// FieldConv is the name of the type or function used to convert
+		// the struct field value into the param value if they have
+		// different Go types.
+		FieldConv string
 		// VarName is the name of the Go variable used to read or
 		// convert the param value.
 		VarName string
@@ -435,6 +459,10 @@ type (
 		Pointer bool
 		// StringSlice is true if the param type is array of strings.
 		StringSlice bool
+		// CommaSeparated is true if the param is a string slice whose
+		// values may also be given as a single comma separated value,
+		// e.g. "fields=id,name".
+		CommaSeparated bool
 		// Slice is true if the param type is an array.
 		Slice bool
 		// MapStringSlice is true if the param type is a map of string
@@ -448,6 +476,8 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// Enum lists the values allowed for the param if any.
+		Enum []interface{}
 		// MapQueryParams indicates that the query params must be mapped
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
@@ -470,6 +500,14 @@ type (
 		// FieldPointer if true indicates that the struct field that holds the
 		// header value is a pointer.
 		FieldPointer bool
+		// Conv is the name of the type or function used to convert the
+		// header value into the struct field value if they have different
+		// Go types (e.g. the field holds a primitive user type).
+		Conv string
+		// FieldConv is the name of the type or function used to convert
+		// the struct field value into the header value if they have
+		// different Go types.
+		FieldConv string
 		// VarName is the name of the Go variable used to read or
 		// convert the header value.
 		VarName string
@@ -494,6 +532,8 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// Enum lists the values allowed for the header if any.
+		Enum []interface{}
 	}
 
 	// TypeData contains the data needed to render a type definition.
@@ -519,6 +559,39 @@ type (
 		Example interface{}
 		// View is the view used to render the (result) type if any.
 		View string
+		// OmitUnset is true if the type has Nullable fields in which case
+		// it implements MarshalJSON to omit the fields that are not set.
+		OmitUnset bool
+	}
+
+	// NextPageLinkData contains the data needed to set the Link response
+	// header with the URL of the next page of a paginated endpoint.
+	NextPageLinkData struct {
+		// Param is the name of the query string parameter that
+		// identifies the page.
+		Param string
+		// NextField is the path to the result field that identifies the
+		// next page relative to the result variable.
+		NextField string
+		// IsInt is true if the next page is identified by its number.
+		IsInt bool
+	}
+
+	// CORSData contains the data needed to render the CORS policy that
+	// applies to the requests made from a given origin.
+	CORSData struct {
+		// Origin is the allowed origin.
+		Origin string
+		// Methods lists the allowed HTTP methods.
+		Methods []string
+		// Headers lists the allowed request headers.
+		Headers []string
+		// ExposedHeaders lists the exposed response headers.
+		ExposedHeaders []string
+		// MaxAge is the preflight cache duration in seconds.
+		MaxAge uint
+		// Credentials indicates whether credentials are allowed.
+		Credentials bool
 	}
 
 	// MultipartData contains the data needed to render multipart
@@ -660,6 +733,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		rd.FileServers = append(rd.FileServers, data)
 	}
 
+	svcctx := serviceContext(svc.PkgName, svc.Scope)
 	for _, a := range hs.HTTPEndpoints {
 		ep := svc.Method(a.MethodExpr.Name)
 
@@ -689,11 +763,13 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 							ctx := httpContext("", rd.Scope, true, false)
 							vcode = codegen.RecursiveValidationCode(att, ctx, true, name)
 						}
+						_, fieldConv := rd.primitiveConversions(att, serviceAttribute(a.MethodExpr.Payload, arg), false, svcctx)
 						initArgs[j] = &InitArgData{
 							Name:        name,
 							Description: att.Description,
 							Ref:         name,
 							FieldName:   codegen.Goify(arg, true),
+							FieldConv:   fieldConv,
 							TypeName:    rd.Scope.GoTypeName(att),
 							TypeRef:     rd.Scope.GoTypeRef(att),
 							Pointer:     pointer,
@@ -747,6 +823,8 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					switch s.Type {
 					case "Basic":
 						basch = s
+					case "MTLS":
+						// client certificate is provided by the TLS connection
 					default:
 						switch s.In {
 						case "query":
@@ -841,6 +919,31 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		}
 		buildStreamData(ad, a, rd)
 
+		for _, c := range a.MethodExpr.CORS {
+			ad.CORS = append(ad.CORS, &CORSData{
+				Origin:         c.Origin,
+				Methods:        c.Methods,
+				Headers:        c.Headers,
+				ExposedHeaders: c.ExposedHeaders,
+				MaxAge:         c.MaxAge,
+				Credentials:    c.Credentials,
+			})
+		}
+
+		if pag := a.MethodExpr.Pagination; pag != nil {
+			if param, ok := a.QueryParams().FindKey(pag.TokenAttribute()); ok {
+				next := codegen.GoifyAtt(a.MethodExpr.Result.Find(pag.NextAttribute()), pag.NextAttribute(), true)
+				if ep.ViewedResult != nil {
+					next = "Projected." + next
+				}
+				ad.NextPageLink = &NextPageLinkData{
+					Param:     param,
+					NextField: next,
+					IsInt:     pag.Kind == expr.PagePaginationKind,
+				}
+			}
+		}
+
 		if a.MultipartRequest {
 			ad.MultipartRequestDecoder = &MultipartData{
 				FuncName:    fmt.Sprintf("%s%sDecoderFunc", svc.StructName, ep.VarName),
@@ -932,9 +1035,9 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 		var (
 			serverBodyData = buildRequestBodyType(e.Body, payload, e, true, sd)
 			clientBodyData = buildRequestBodyType(e.Body, payload, e, false, sd)
-			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
-			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
-			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
+			paramsData     = extractPathParams(e.PathParams(), payload, svcctx, sd)
+			queryData      = extractQueryParams(e.QueryParams(), payload, svcctx, sd)
+			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope, sd)
 			origin         string
 
 			mustValidate bool
@@ -970,6 +1073,13 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				}
 				queryData = append(queryData, mapQueryParam)
 			}
+			if e.MethodExpr.FieldSelection != nil {
+				for _, q := range queryData {
+					if q.AttributeName == expr.FieldSelectionAttribute && q.StringSlice {
+						q.CommaSeparated = true
+					}
+				}
+			}
 			if serverBodyData != nil {
 				sd.ServerTypeNames[serverBodyData.Name] = false
 				sd.ClientTypeNames[serverBodyData.Name] = false
@@ -1079,20 +1189,26 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				Ref:          p.VarName,
 				FieldName:    p.FieldName,
 				FieldPointer: p.FieldPointer,
+				Conv:         p.Conv,
+				FieldConv:    p.FieldConv,
 				TypeName:     p.TypeName,
 				TypeRef:      p.TypeRef,
 				Pointer:      p.Pointer,
 				Required:     p.Required,
 				Validate:     p.Validate,
 				Example:      p.Example,
+				Enum:         p.Enum,
 			})
 		}
 		for _, p := range request.QueryParams {
 			args = append(args, &InitArgData{
 				Name:         p.VarName,
+				Description:  p.Description,
 				Ref:          p.VarName,
 				FieldName:    p.FieldName,
 				FieldPointer: p.FieldPointer,
+				Conv:         p.Conv,
+				FieldConv:    p.FieldConv,
 				TypeName:     p.TypeName,
 				TypeRef:      p.TypeRef,
 				Pointer:      p.Pointer,
@@ -1100,14 +1216,18 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				DefaultValue: p.DefaultValue,
 				Validate:     p.Validate,
 				Example:      p.Example,
+				Enum:         p.Enum,
 			})
 		}
 		for _, h := range request.Headers {
 			args = append(args, &InitArgData{
 				Name:         h.VarName,
+				Description:  h.Description,
 				Ref:          h.VarName,
 				FieldName:    h.FieldName,
 				FieldPointer: h.FieldPointer,
+				Conv:         h.Conv,
+				FieldConv:    h.FieldConv,
 				TypeName:     h.TypeName,
 				TypeRef:      h.TypeRef,
 				Pointer:      h.Pointer,
@@ -1115,6 +1235,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				DefaultValue: h.DefaultValue,
 				Validate:     h.Validate,
 				Example:      h.Example,
+				Enum:         h.Enum,
 			})
 		}
 		serverArgs = append(serverArgs, args...)
@@ -1144,6 +1265,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 						Pointer:      sc.UsernamePointer,
 						Validate:     codegen.RecursiveValidationCode(uatt, httpsvrctx, sc.UsernameRequired, sc.UsernameAttr),
 						Example:      uatt.Example(expr.Root.API.Random()),
+						Enum:         enumValues(uatt),
 					}
 					patt := e.MethodExpr.Payload.Find(sc.PasswordAttr)
 					pref := svc.Scope.GoTypeRef(patt)
@@ -1162,6 +1284,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 						Pointer:      sc.PasswordPointer,
 						Validate:     codegen.RecursiveValidationCode(patt, httpsvrctx, sc.PasswordRequired, sc.PasswordAttr),
 						Example:      patt.Example(expr.Root.API.Random()),
+						Enum:         enumValues(patt),
 					}
 					cliArgs = []*InitArgData{uarg, parg}
 					done = true
@@ -1358,7 +1481,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 				resAttr = result
 			)
 			{
-				headersData = extractHeaders(resp.Headers, result, svcctx, scope)
+				headersData = extractHeaders(resp.Headers, result, svcctx, scope, sd)
 				if resp.Body.Type != expr.Empty {
 					// If design uses Body("name") syntax we need to use the
 					// corresponding attribute in the result type for body
@@ -1498,6 +1621,8 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 								Ref:          h.VarName,
 								FieldName:    h.FieldName,
 								FieldPointer: h.FieldPointer,
+								Conv:         h.Conv,
+								FieldConv:    h.FieldConv,
 								Required:     h.Required,
 								Pointer:      h.Pointer,
 								TypeRef:      h.TypeRef,
@@ -1598,12 +1723,14 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 						TypeRef: sd.Scope.GoTypeRef(v.Response.Body),
 					}}
 				}
-				for _, h := range extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, svcctx, sd.Scope) {
+				for _, h := range extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, svcctx, sd.Scope, sd) {
 					args = append(args, &InitArgData{
 						Name:         h.VarName,
 						Ref:          h.VarName,
 						FieldName:    h.FieldName,
 						FieldPointer: false,
+						Conv:         h.Conv,
+						FieldConv:    h.FieldConv,
 						TypeRef:      h.TypeRef,
 						Validate:     h.Validate,
 						Example:      h.Example,
@@ -1679,7 +1806,7 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				}
 			}
 
-			headers := extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, svcctx, sd.Scope)
+			headers := extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, svcctx, sd.Scope, sd)
 			var mustValidate bool
 			{
 				for _, h := range headers {
@@ -2004,6 +2131,7 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		ValidateDef: validateDef,
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.Random()),
+		OmitUnset:   def != "" && hasNullableFields(body),
 	}
 }
 
@@ -2192,27 +2320,33 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.Random()),
 		View:        viewName,
+		OmitUnset:   def != "" && hasNullableFields(body),
 	}
 }
 
-func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, svcCtx *codegen.AttributeContext, sd *ServiceData) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, _ bool, c *expr.AttributeExpr) error {
 		var (
-			varn = scope.Name(codegen.Goify(name, false))
-			arr  = expr.AsArray(c.Type)
-			ctx  = serviceContext("", scope)
+			scope = sd.Scope
+			varn  = scope.Name(codegen.Goify(name, false))
+			arr   = expr.AsArray(c.Type)
+			ctx   = serviceContext("", scope)
 		)
 		fieldName := codegen.Goify(name, true)
 		if !expr.IsObject(service.Type) {
 			fieldName = ""
 		}
+		fieldPointer := expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true)
+		conv, fieldConv := sd.primitiveConversions(c, serviceAttribute(service, name), fieldPointer, svcCtx)
 		params = append(params, &ParamData{
 			Name:           elem,
 			AttributeName:  name,
-			Description:    c.Description,
+			Description:    enumDescription(c, conv),
 			FieldName:      fieldName,
-			FieldPointer:   expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true),
+			FieldPointer:   fieldPointer,
+			Conv:           conv,
+			FieldConv:      fieldConv,
 			VarName:        varn,
 			Required:       true,
 			Type:           c.Type,
@@ -2226,6 +2360,7 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 			Validate:       codegen.RecursiveValidationCode(c, ctx, true, varn),
 			DefaultValue:   c.DefaultValue,
 			Example:        c.Example(expr.Root.API.Random()),
+			Enum:           enumValues(c),
 		})
 		return nil
 	})
@@ -2233,10 +2368,11 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 	return params
 }
 
-func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, svcCtx *codegen.AttributeContext, sd *ServiceData) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
 		var (
+			scope   = sd.Scope
 			varn    = scope.Name(codegen.Goify(name, false))
 			arr     = expr.AsArray(c.Type)
 			mp      = expr.AsMap(c.Type)
@@ -2252,12 +2388,16 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 		if !expr.IsObject(service.Type) {
 			fieldName = ""
 		}
+		fieldPointer := expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true)
+		conv, fieldConv := sd.primitiveConversions(c, serviceAttribute(service, name), fieldPointer, svcCtx)
 		params = append(params, &ParamData{
 			Name:          elem,
 			AttributeName: name,
-			Description:   c.Description,
+			Description:   enumDescription(c, conv),
 			FieldName:     fieldName,
-			FieldPointer:  expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true),
+			FieldPointer:  fieldPointer,
+			Conv:          conv,
+			FieldConv:     fieldConv,
 			VarName:       varn,
 			Required:      required,
 			Type:          c.Type,
@@ -2274,6 +2414,7 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 			Validate:     codegen.RecursiveValidationCode(c, ctx, required, varn),
 			DefaultValue: c.DefaultValue,
 			Example:      c.Example(expr.Root.API.Random()),
+			Enum:         enumValues(c),
 		})
 		return nil
 	})
@@ -2281,16 +2422,24 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 	return params
 }
 
-func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope) []*HeaderData {
+func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope, sd *ServiceData) []*HeaderData {
 	var headers []*HeaderData
-	codegen.WalkMappedAttr(a, func(name, elem string, required bool, _ *expr.AttributeExpr) error {
+	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
 		var (
 			hattr *expr.AttributeExpr
+
+			conv, fieldConv string
 		)
 		{
 			if hattr = svcAtt.Find(name); hattr == nil {
 				hattr = svcAtt
 			}
+			fieldPointer := expr.IsObject(svcAtt.Type) && svcCtx.IsPrimitivePointer(name, svcAtt)
+			if conv, fieldConv = sd.primitiveConversions(c, hattr, fieldPointer, svcCtx); conv != "" {
+				// The header value uses the underlying primitive type
+				// of the service attribute.
+				hattr = c
+			}
 		}
 		var (
 			varn    = scope.Name(codegen.Goify(name, false))
@@ -2312,10 +2461,12 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 		headers = append(headers, &HeaderData{
 			Name:          elem,
 			AttributeName: name,
-			Description:   hattr.Description,
+			Description:   enumDescription(hattr, conv),
 			CanonicalName: http.CanonicalHeaderKey(elem),
 			FieldName:     fieldName,
 			FieldPointer:  expr.IsObject(svcAtt.Type) && svcCtx.IsPrimitivePointer(name, svcAtt),
+			Conv:          conv,
+			FieldConv:     fieldConv,
 			VarName:       varn,
 			TypeName:      scope.GoTypeName(hattr),
 			TypeRef:       typeRef,
@@ -2327,6 +2478,7 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 			Validate:      codegen.RecursiveValidationCode(hattr, svcCtx, required, varn),
 			DefaultValue:  hattr.DefaultValue,
 			Example:       hattr.Example(expr.Root.API.Random()),
+			Enum:          enumValues(hattr),
 		})
 		return nil
 	})
@@ -2408,7 +2560,97 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 		ValidateDef: validate,
 		ValidateRef: validateRef,
 		Example:     att.Example(expr.Root.API.Random()),
+		OmitUnset:   hasNullableFields(ut.Attribute()),
+	}
+}
+
+// serviceAttribute returns the attribute of the service type that holds the
+// value of the HTTP element with the given name.
+func serviceAttribute(service *expr.AttributeExpr, name string) *expr.AttributeExpr {
+	if !expr.IsObject(service.Type) {
+		return service
+	}
+	if att := service.Find(name); att != nil {
+		return att
+	}
+	return service
+}
+
+// primitiveConversions returns the names of the types or functions used to
+// convert the value of the HTTP attribute att into the value of the service
+// attribute svcAtt and vice versa when the service attribute holds a primitive
+// user type or an array or a map of primitive user types. It returns empty
+// strings otherwise. fieldPointer indicates whether the service attribute is
+// held in a pointer. The functions used to convert arrays and maps are
+// recorded in the service data transform helpers.
+func (sd *ServiceData) primitiveConversions(att, svcAtt *expr.AttributeExpr, fieldPointer bool, svcCtx *codegen.AttributeContext) (conv, fieldConv string) {
+	if !expr.HasPrimitiveUserType(svcAtt.Type) {
+		return "", ""
+	}
+	httpctx := httpContext("", sd.Scope, true, true)
+	conv = sd.primitiveConversion(att, svcAtt, fieldPointer, httpctx, svcCtx)
+	fieldConv = sd.primitiveConversion(svcAtt, att, false, svcCtx, httpctx)
+	return
+}
+
+// primitiveConversion returns the name of the type or function used to
+// convert a value of the source attribute type into a value of the target
+// attribute type. ptr indicates whether the converted value is a pointer.
+func (sd *ServiceData) primitiveConversion(source, target *expr.AttributeExpr, ptr bool, sourceCtx, targetCtx *codegen.AttributeContext) string {
+	tref := targetCtx.Scope.Ref(target, targetCtx.Pkg)
+	if expr.IsPrimitive(source.Type) {
+		if ptr {
+			return "(*" + tref + ")"
+		}
+		return tref
+	}
+	sref := sourceCtx.Scope.Ref(source, sourceCtx.Pkg)
+	code, _, err := codegen.GoTransform(source, target, "v", "res", sourceCtx, targetCtx, "")
+	if err != nil {
+		fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
+		return ""
+	}
+	h := &codegen.TransformFunctionData{
+		Name:          codegen.Goify("convert "+strings.Replace(sref, "[]", "slice ", -1)+" to "+strings.Replace(tref, "[]", "slice ", -1), false),
+		ParamTypeRef:  sref,
+		ResultTypeRef: tref,
+		Code:          "if v == nil {\n\treturn nil\n}\n" + code,
+	}
+	sd.ServerTransformHelpers = codegen.AppendHelpers(sd.ServerTransformHelpers, []*codegen.TransformFunctionData{h})
+	sd.ClientTransformHelpers = codegen.AppendHelpers(sd.ClientTransformHelpers, []*codegen.TransformFunctionData{h})
+	return h.Name
+}
+
+// enumDescription returns the description of the given param or header
+// attribute. The description lists the values of the Enum validation of the
+// attribute or of its elements if conv is not empty, i.e. if the corresponding
+// service attribute holds primitive user types.
+func enumDescription(att *expr.AttributeExpr, conv string) string {
+	val := att.Validation
+	if arr := expr.AsArray(att.Type); arr != nil {
+		val = arr.ElemType.Validation
+	}
+	if conv == "" || val == nil || len(val.Values) == 0 {
+		return att.Description
+	}
+	vals := make([]string, len(val.Values))
+	for i, v := range val.Values {
+		vals[i] = fmt.Sprintf("%#v", v)
+	}
+	desc := fmt.Sprintf("One of %s.", strings.Join(vals, ", "))
+	if att.Description == "" {
+		return desc
+	}
+	return strings.TrimSuffix(att.Description, ".") + ". " + desc
+}
+
+// enumValues returns the values of the enum validation of the given attribute
+// if it is a primitive, nil otherwise.
+func enumValues(att *expr.AttributeExpr) []interface{} {
+	if !expr.IsPrimitive(att.Type) || att.Validation == nil {
+		return nil
 	}
+	return att.Validation.Values
 }
 
 // httpContext returns a context for attributes of types used to marshal and
@@ -2560,6 +2802,8 @@ const (
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
 	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, ", ")
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "time" }}url.PathEscape({{ .Name }}.Format(time.RFC3339))
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "duration" }}int64({{ .Name }})
 	{{- else }}{{ .Name }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -2576,6 +2820,8 @@ const (
 	{{- else if eq . "float64" }} strconv.FormatFloat(v, 'f', -1, 64)
 	{{- else if eq . "boolean" }} strconv.FormatBool(v)
 	{{- else if eq . "bytes" }} url.QueryEscape(string(v))
+	{{- else if eq . "time" }} url.QueryEscape(v.Format(time.RFC3339))
+	{{- else if eq . "duration" }} strconv.FormatInt(int64(v), 10)
 	{{- else }} url.QueryEscape(fmt.Sprintf("%v", v))
 	{{- end }}
 {{- end }}`
@@ -2600,7 +2846,7 @@ const (
 		{{- if .Pointer }}
 		if p{{ if $.HasFields }}.{{ .FieldName }}{{ end }} != nil {
 		{{- end }}
-			{{ .Name }} = {{ if .Pointer }}*{{ end }}p{{ if $.HasFields }}.{{ .FieldName }}{{ end }}
+			{{ .Name }} = {{ with .FieldConv }}{{ . }}({{ end }}{{ if .Pointer }}*{{ end }}p{{ if $.HasFields }}.{{ .FieldName }}{{ end }}{{ if .FieldConv }}){{ end }}
 		{{- if .Pointer }}
 		}
 		{{- end }}
@@ -49,5 +49,156 @@ func NewClient(
 		configurer:                cfn,
 	}
 }
+`
+
+	ClientCredentialsClientStructCode = `// Client lists the ServiceClientCredentials service endpoint HTTP clients.
+type Client struct {
+	// MethodTokenOrKey Doer is the HTTP client used to make requests to the
+	// MethodTokenOrKey endpoint.
+	MethodTokenOrKeyDoer goahttp.Doer
+
+	// MethodAccessToken Doer is the HTTP client used to make requests to the
+	// MethodAccessToken endpoint.
+	MethodAccessTokenDoer goahttp.Doer
+
+	// MethodUnsecure Doer is the HTTP client used to make requests to the
+	// MethodUnsecure endpoint.
+	MethodUnsecureDoer goahttp.Doer
+
+	// RestoreResponseBody controls whether the response bodies are reset after
+	// decoding so they can be read again.
+	RestoreResponseBody bool
+
+	// Credentials provides the credentials of the requests made to the
+	// secured endpoints whose payloads do not set them if not nil.
+	Credentials security.CredentialSource
+
+	scheme  string
+	host    string
+	encoder func(*http.Request) goahttp.Encoder
+	decoder func(*http.Response) goahttp.Decoder
+}
+`
+
+	ClientCredentialsEndpointInitCode = `// MethodTokenOrKey returns an endpoint that makes HTTP requests to the
+// ServiceClientCredentials service MethodTokenOrKey server.
+func (c *Client) MethodTokenOrKey() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodTokenOrKeyRequest(c.encoder)
+		decodeResponse = DecodeMethodTokenOrKeyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		if c.Credentials != nil {
+			var err error
+			if v, err = setMethodTokenOrKeyCredentials(ctx, c.Credentials, v); err != nil {
+				return nil, err
+			}
+		}
+		req, err := c.BuildMethodTokenOrKeyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodTokenOrKeyDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceClientCredentials", "MethodTokenOrKey", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+	ClientCredentialsUnsecureEndpointInitCode = `// MethodUnsecure returns an endpoint that makes HTTP requests to the
+// ServiceClientCredentials service MethodUnsecure server.
+func (c *Client) MethodUnsecure() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodUnsecureResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodUnsecureRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodUnsecureDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceClientCredentials", "MethodUnsecure", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+	ClientCredentialsTokenOrKeyCode = `// setMethodTokenOrKeyCredentials returns a copy of the "MethodTokenOrKey"
+// payload v where the credentials that are not set are obtained from src.
+func setMethodTokenOrKeyCredentials(ctx context.Context, src security.CredentialSource, v interface{}) (interface{}, error) {
+	p, ok := v.(*serviceclientcredentials.MethodTokenOrKeyPayload)
+	if !ok || p == nil {
+		return v, nil
+	}
+	res := *p
+	if res.Token == nil {
+		cred, err := src.Credential(ctx, &security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read"},
+			RequiredScopes: []string{"api:read"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.Token = &cred
+		}
+	}
+	if res.Key == "" {
+		cred, err := src.Credential(ctx, &security.APIKeyScheme{
+			Name:           "api_key",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.Key = cred
+		}
+	}
+	return &res, nil
+}
+`
+
+	ClientCredentialsAccessTokenCode = `// setMethodAccessTokenCredentials returns a copy of the "MethodAccessToken"
+// payload v where the credentials that are not set are obtained from src.
+func setMethodAccessTokenCredentials(ctx context.Context, src security.CredentialSource, v interface{}) (interface{}, error) {
+	p, ok := v.(*serviceclientcredentials.MethodAccessTokenPayload)
+	if !ok || p == nil {
+		return v, nil
+	}
+	res := *p
+	if res.AccessToken == nil {
+		cred, err := src.Credential(ctx, &security.OAuth2Scheme{
+			Name:           "oauth2",
+			Scopes:         []string{"api:read"},
+			RequiredScopes: []string{},
+			Flows: []*security.OAuthFlow{
+				&security.OAuthFlow{
+					Type:     "client_credentials",
+					TokenURL: "http://auth.example.com/token",
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cred != "" {
+			res.AccessToken = &cred
+		}
+	}
+	return &res, nil
+}
 `
 )
@@ -1,9 +1,9 @@
 package testdata
 
 const (
-	NoServerServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	NoServerServerHandleCode = `// handleHTTPServer configures a HTTP server on the given URL and adds it to
+// the group that runs the servers.
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -57,32 +57,23 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		handler = httpmdlwr.RequestID()(handler)
 	}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+		Read:  30 * time.Second,
+		Write: 30 * time.Second,
+		Idle:  2 * time.Minute,
+	})
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start HTTP server in a separate goroutine.
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.HTTP(srv))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 
 // errorHandler returns a function that writes and logs the given error.
@@ -97,9 +88,9 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 }
 `
 
-	ServerHostingServiceWithFileServerHandlerCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	ServerHostingServiceWithFileServerHandlerCode = `// handleHTTPServer configures a HTTP server on the given URL and adds it to
+// the group that runs the servers.
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -153,32 +144,23 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 		handler = httpmdlwr.RequestID()(handler)
 	}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+		Read:  30 * time.Second,
+		Write: 30 * time.Second,
+		Idle:  2 * time.Minute,
+	})
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start HTTP server in a separate goroutine.
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.HTTP(srv))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 
 // errorHandler returns a function that writes and logs the given error.
@@ -193,9 +175,9 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 }
 `
 
-	ServerHostingServiceSubsetServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	ServerHostingServiceSubsetServerHandleCode = `// handleHTTPServer configures a HTTP server on the given URL and adds it to
+// the group that runs the servers.
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -249,32 +231,23 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		handler = httpmdlwr.RequestID()(handler)
 	}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+		Read:  30 * time.Second,
+		Write: 30 * time.Second,
+		Idle:  2 * time.Minute,
+	})
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start HTTP server in a separate goroutine.
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.HTTP(srv))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 
 // errorHandler returns a function that writes and logs the given error.
@@ -289,9 +262,9 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 }
 `
 
-	ServerHostingMultipleServicesServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	ServerHostingMultipleServicesServerHandleCode = `// handleHTTPServer configures a HTTP server on the given URL and adds it to
+// the group that runs the servers.
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anotherservice.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -349,9 +322,13 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		handler = httpmdlwr.RequestID()(handler)
 	}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+		Read:  30 * time.Second,
+		Write: 30 * time.Second,
+		Idle:  2 * time.Minute,
+	})
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -359,25 +336,12 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start HTTP server in a separate goroutine.
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.HTTP(srv))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 
 // errorHandler returns a function that writes and logs the given error.
@@ -392,9 +356,9 @@ func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 }
 `
 
-	StreamingServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
-// URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoints *streamingservicea.Endpoints, streamingServiceBEndpoints *streamingserviceb.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+	StreamingServerHandleCode = `// handleHTTPServer configures a HTTP server on the given URL and adds it to
+// the group that runs the servers.
+func handleHTTPServer(grp *lifecycle.Group, u *url.URL, streamingServiceAEndpoints *streamingservicea.Endpoints, streamingServiceBEndpoints *streamingserviceb.Endpoints, logger *log.Logger, debug bool) {
 
 	// Setup goa log adapter.
 	var (
@@ -453,9 +417,13 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		handler = httpmdlwr.RequestID()(handler)
 	}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	// Configure the HTTP server timeouts, change the code to configure the
+	// server as required by your service.
+	// The read and write timeouts also apply to the websocket connections
+	// and are thus left unset.
+	srv := lifecycle.NewHTTPServer(u.Host, handler, lifecycle.Timeouts{
+		Idle: 2 * time.Minute,
+	})
 	for _, m := range streamingServiceAServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -463,25 +431,12 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
 
-	(*wg).Add(1)
-	go func() {
-		defer (*wg).Done()
-
-		// Start HTTP server in a separate goroutine.
-		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
-		}()
-
-		<-ctx.Done()
+	// Add the server to the group that starts it and shuts it down gracefully.
+	grp.Add(lifecycle.HTTP(srv))
+	grp.BeforeShutdown(func(context.Context) {
 		logger.Printf("shutting down HTTP server at %q", u.Host)
-
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		srv.Shutdown(ctx)
-	}()
+	})
+	logger.Printf("HTTP server listening on %q", u.Host)
 }
 
 // errorHandler returns a function that writes and logs the given error.
@@ -524,6 +479,10 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
 `
 
 	StreamingExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
@@ -563,6 +522,10 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
 `
 
 	StreamingMultipleServicesExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
@@ -603,5 +566,9 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+
+func httpCommands() []*goacli.Command {
+	return cli.Commands()
+}
 `
 )
@@ -19,6 +19,7 @@ func NewMethodNoPayloadNoResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadNoResult")
+		middleware.SetServiceMethod(ctx)
 		var err error
 
 		res, err := endpoint(ctx, nil)
@@ -56,6 +57,7 @@ func NewMethodPayloadNoResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadNoResult")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -98,6 +100,7 @@ func NewMethodNoPayloadResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadResult")
+		middleware.SetServiceMethod(ctx)
 		var err error
 
 		res, err := endpoint(ctx, nil)
@@ -135,6 +138,7 @@ func NewMethodPayloadResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResult")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -178,6 +182,7 @@ func NewMethodPayloadResultErrorHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResultError")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResultError")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -200,3 +205,61 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerPayloadResultLimitsHandlerConstructorCode = `// NewMethodPayloadResultLimitsHandler creates a HTTP handler which loads the
+// HTTP request and calls the "ServicePayloadResultLimits" service
+// "MethodPayloadResultLimits" endpoint.
+func NewMethodPayloadResultLimitsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodPayloadResultLimitsRequest(mux, decoder)
+		encodeResponse = EncodeMethodPayloadResultLimitsResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResultLimits")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResultLimits")
+		middleware.SetServiceMethod(ctx)
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		if err := goahttp.LimitRequestBody(r, 1024); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if goahttp.RequestBodyLimitExceeded(r) {
+				err = goahttp.ErrRequestBodyTooLarge(1024)
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				err = goahttp.ErrDeadlineExceeded(30 * time.Second)
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,44 @@
+package testdata
+
+var ServerNoPayloadNoResultMountHandlerCode = `// MountMethodNoPayloadNoResultHandler configures the mux to serve the
+// "ServiceNoPayloadNoResult" service "MethodNoPayloadNoResult" endpoint.
+func MountMethodNoPayloadNoResultHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/", f)
+}
+`
+
+var ServerCORSMountHandlerCode = `// MountMethodCORSHandler configures the mux to serve the "ServiceCORS" service
+// "MethodCORS" endpoint.
+func MountMethodCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	cors := goahttp.NewCORSPolicy(
+		&goahttp.CORSOrigin{
+			Origin:         "https://*.goa.design",
+			Methods:        []string{"GET", "PUT"},
+			Headers:        []string{"Authorization"},
+			ExposedHeaders: []string{"X-Request-Id"},
+			MaxAge:         600,
+			Credentials:    true,
+		},
+		&goahttp.CORSOrigin{
+			Origin: "/^http://localhost:[0-9]+$/",
+		},
+	)
+	f = cors.Handler(f)
+	mux.Handle("GET", "/{id}", f)
+	goahttp.MountCORSPreflight(mux, "/{id}", "GET", cors)
+	mux.Handle("PUT", "/{id}", f)
+	goahttp.MountCORSPreflight(mux, "/{id}", "PUT", cors)
+}
+`
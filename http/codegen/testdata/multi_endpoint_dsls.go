@@ -137,3 +137,45 @@ var MultiDSL = func() {
 		})
 	})
 }
+
+var ClientCredentialsDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read", "Read-only access")
+	})
+	var APIKeyAuth = APIKeySecurity("api_key")
+	var OAuth2Auth = OAuth2Security("oauth2", func() {
+		ClientCredentialsFlow("http://auth.example.com/token", "")
+		Scope("api:read", "Read-only access")
+	})
+	Service("ServiceClientCredentials", func() {
+		Method("MethodTokenOrKey", func() {
+			Security(JWTAuth, func() {
+				Scope("api:read")
+			})
+			Security(APIKeyAuth)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+				Required("key")
+			})
+			HTTP(func() {
+				GET("/token")
+				Header("key:X-API-Key")
+			})
+		})
+		Method("MethodAccessToken", func() {
+			Security(OAuth2Auth)
+			Payload(func() {
+				AccessToken("access_token", String)
+			})
+			HTTP(func() {
+				GET("/access_token")
+			})
+		})
+		Method("MethodUnsecure", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
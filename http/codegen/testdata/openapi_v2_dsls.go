@@ -314,6 +314,27 @@ var ExtensionDSL = func() {
 	})
 }
 
+var NullableDSL = func() {
+	var PayloadT = Type("Payload", func() {
+		Attribute("string", String, func() {
+			Example("")
+			Nullable()
+		})
+		Attribute("int", Int, func() {
+			Example(1)
+			Nullable()
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(PayloadT)
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
 var SecurityDSL = func() {
 	var JWTAuth = JWTSecurity("jwt", func() {
 		Description(`Secures endpoint by requiring a valid JWT token retrieved via the signin endpoint. Supports scopes "api:read" and "api:write".`)
@@ -12,15 +12,15 @@ func ParseEndpoint(
 	var (
 		serviceMultiNoPayload1Flags = flag.NewFlagSet("service-multi-no-payload1", flag.ContinueOnError)
 
-		serviceMultiNoPayload1MethodServiceNoPayload11Flags = flag.NewFlagSet("method-service-no-payload11", flag.ExitOnError)
+		serviceMultiNoPayload1MethodServiceNoPayload11Flags = flag.NewFlagSet("method-service-no-payload11", flag.CommandLine.ErrorHandling())
 
-		serviceMultiNoPayload1MethodServiceNoPayload12Flags = flag.NewFlagSet("method-service-no-payload12", flag.ExitOnError)
+		serviceMultiNoPayload1MethodServiceNoPayload12Flags = flag.NewFlagSet("method-service-no-payload12", flag.CommandLine.ErrorHandling())
 
 		serviceMultiNoPayload2Flags = flag.NewFlagSet("service-multi-no-payload2", flag.ContinueOnError)
 
-		serviceMultiNoPayload2MethodServiceNoPayload21Flags = flag.NewFlagSet("method-service-no-payload21", flag.ExitOnError)
+		serviceMultiNoPayload2MethodServiceNoPayload21Flags = flag.NewFlagSet("method-service-no-payload21", flag.CommandLine.ErrorHandling())
 
-		serviceMultiNoPayload2MethodServiceNoPayload22Flags = flag.NewFlagSet("method-service-no-payload22", flag.ExitOnError)
+		serviceMultiNoPayload2MethodServiceNoPayload22Flags = flag.NewFlagSet("method-service-no-payload22", flag.CommandLine.ErrorHandling())
 	)
 	serviceMultiNoPayload1Flags.Usage = serviceMultiNoPayload1Usage
 	serviceMultiNoPayload1MethodServiceNoPayload11Flags.Usage = serviceMultiNoPayload1MethodServiceNoPayload11Usage
@@ -146,16 +146,16 @@ func ParseEndpoint(
 	var (
 		serviceMultiSimple1Flags = flag.NewFlagSet("service-multi-simple1", flag.ContinueOnError)
 
-		serviceMultiSimple1MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.ExitOnError)
+		serviceMultiSimple1MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.CommandLine.ErrorHandling())
 
-		serviceMultiSimple1MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.ExitOnError)
+		serviceMultiSimple1MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.CommandLine.ErrorHandling())
 		serviceMultiSimple1MethodMultiSimplePayloadBodyFlag = serviceMultiSimple1MethodMultiSimplePayloadFlags.String("body", "REQUIRED", "")
 
 		serviceMultiSimple2Flags = flag.NewFlagSet("service-multi-simple2", flag.ContinueOnError)
 
-		serviceMultiSimple2MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.ExitOnError)
+		serviceMultiSimple2MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.CommandLine.ErrorHandling())
 
-		serviceMultiSimple2MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.ExitOnError)
+		serviceMultiSimple2MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.CommandLine.ErrorHandling())
 		serviceMultiSimple2MethodMultiSimplePayloadBodyFlag = serviceMultiSimple2MethodMultiSimplePayloadFlags.String("body", "REQUIRED", "")
 	)
 	serviceMultiSimple1Flags.Usage = serviceMultiSimple1Usage
@@ -282,14 +282,14 @@ func ParseEndpoint(
 	var (
 		serviceMultiRequired1Flags = flag.NewFlagSet("service-multi-required1", flag.ContinueOnError)
 
-		serviceMultiRequired1MethodMultiRequiredPayloadFlags    = flag.NewFlagSet("method-multi-required-payload", flag.ExitOnError)
+		serviceMultiRequired1MethodMultiRequiredPayloadFlags    = flag.NewFlagSet("method-multi-required-payload", flag.CommandLine.ErrorHandling())
 		serviceMultiRequired1MethodMultiRequiredPayloadBodyFlag = serviceMultiRequired1MethodMultiRequiredPayloadFlags.String("body", "REQUIRED", "")
 
 		serviceMultiRequired2Flags = flag.NewFlagSet("service-multi-required2", flag.ContinueOnError)
 
-		serviceMultiRequired2MethodMultiRequiredNoPayloadFlags = flag.NewFlagSet("method-multi-required-no-payload", flag.ExitOnError)
+		serviceMultiRequired2MethodMultiRequiredNoPayloadFlags = flag.NewFlagSet("method-multi-required-no-payload", flag.CommandLine.ErrorHandling())
 
-		serviceMultiRequired2MethodMultiRequiredPayloadFlags = flag.NewFlagSet("method-multi-required-payload", flag.ExitOnError)
+		serviceMultiRequired2MethodMultiRequiredPayloadFlags = flag.NewFlagSet("method-multi-required-payload", flag.CommandLine.ErrorHandling())
 		serviceMultiRequired2MethodMultiRequiredPayloadAFlag = serviceMultiRequired2MethodMultiRequiredPayloadFlags.String("a", "REQUIRED", "")
 	)
 	serviceMultiRequired1Flags.Usage = serviceMultiRequired1Usage
@@ -409,9 +409,9 @@ func ParseEndpoint(
 	var (
 		serviceMultiFlags = flag.NewFlagSet("service-multi", flag.ContinueOnError)
 
-		serviceMultiMethodMultiNoPayloadFlags = flag.NewFlagSet("method-multi-no-payload", flag.ExitOnError)
+		serviceMultiMethodMultiNoPayloadFlags = flag.NewFlagSet("method-multi-no-payload", flag.CommandLine.ErrorHandling())
 
-		serviceMultiMethodMultiPayloadFlags    = flag.NewFlagSet("method-multi-payload", flag.ExitOnError)
+		serviceMultiMethodMultiPayloadFlags    = flag.NewFlagSet("method-multi-payload", flag.CommandLine.ErrorHandling())
 		serviceMultiMethodMultiPayloadBodyFlag = serviceMultiMethodMultiPayloadFlags.String("body", "REQUIRED", "")
 		serviceMultiMethodMultiPayloadBFlag    = serviceMultiMethodMultiPayloadFlags.String("b", "", "")
 		serviceMultiMethodMultiPayloadAFlag    = serviceMultiMethodMultiPayloadFlags.String("a", "", "")
@@ -502,6 +502,54 @@ func ParseEndpoint(
 }
 `
 
+var MultiCommandsCode = `// Commands returns the commands and sub-commands supported by the CLI tool
+// along with their flags.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+		{
+			Name:        "service-multi",
+			Description: "Service is the ServiceMulti service interface.",
+			Subcommands: []*goacli.Command{
+				{
+					Name:        "method-multi-no-payload",
+					Description: "MethodMultiNoPayload implements MethodMultiNoPayload.",
+				},
+				{
+					Name:        "method-multi-payload",
+					Description: "MethodMultiPayload implements MethodMultiPayload.",
+					Flags: []*goacli.Flag{
+						{Name: "body", File: true},
+						{Name: "b"},
+						{Name: "a"},
+					},
+				},
+			},
+		},
+	}
+}
+`
+
+var QueryStringValidateCommandsCode = `// Commands returns the commands and sub-commands supported by the CLI tool
+// along with their flags.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+		{
+			Name:        "service-query-string-validate",
+			Description: "Service is the ServiceQueryStringValidate service interface.",
+			Subcommands: []*goacli.Command{
+				{
+					Name:        "method-query-string-validate",
+					Description: "MethodQueryStringValidate implements MethodQueryStringValidate.",
+					Flags: []*goacli.Flag{
+						{Name: "q", Values: []string{"val"}},
+					},
+				},
+			},
+		},
+	}
+}
+`
+
 var StreamingParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
 // line.
 func ParseEndpoint(
@@ -517,11 +565,11 @@ func ParseEndpoint(
 	var (
 		streamingServiceAFlags = flag.NewFlagSet("streaming-service-a", flag.ContinueOnError)
 
-		streamingServiceAMethodFlags = flag.NewFlagSet("method", flag.ExitOnError)
+		streamingServiceAMethodFlags = flag.NewFlagSet("method", flag.CommandLine.ErrorHandling())
 
 		streamingServiceBFlags = flag.NewFlagSet("streaming-service-b", flag.ContinueOnError)
 
-		streamingServiceBMethodFlags = flag.NewFlagSet("method", flag.ExitOnError)
+		streamingServiceBMethodFlags = flag.NewFlagSet("method", flag.CommandLine.ErrorHandling())
 	)
 	streamingServiceAFlags.Usage = streamingServiceAUsage
 	streamingServiceAMethodFlags.Usage = streamingServiceAMethodUsage
@@ -741,7 +789,7 @@ func ParseEndpoint(
 	var (
 		serviceBodyPrimitiveBoolValidateFlags = flag.NewFlagSet("service-body-primitive-bool-validate", flag.ContinueOnError)
 
-		serviceBodyPrimitiveBoolValidateMethodBodyPrimitiveBoolValidateFlags = flag.NewFlagSet("method-body-primitive-bool-validate", flag.ExitOnError)
+		serviceBodyPrimitiveBoolValidateMethodBodyPrimitiveBoolValidateFlags = flag.NewFlagSet("method-body-primitive-bool-validate", flag.CommandLine.ErrorHandling())
 		serviceBodyPrimitiveBoolValidateMethodBodyPrimitiveBoolValidatePFlag = serviceBodyPrimitiveBoolValidateMethodBodyPrimitiveBoolValidateFlags.String("p", "REQUIRED", "bool is the payload type of the ServiceBodyPrimitiveBoolValidate service MethodBodyPrimitiveBoolValidate method.")
 	)
 	serviceBodyPrimitiveBoolValidateFlags.Usage = serviceBodyPrimitiveBoolValidateUsage
@@ -839,7 +887,7 @@ func ParseEndpoint(
 	var (
 		serviceBodyPrimitiveArrayStringValidateFlags = flag.NewFlagSet("service-body-primitive-array-string-validate", flag.ContinueOnError)
 
-		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags = flag.NewFlagSet("method-body-primitive-array-string-validate", flag.ExitOnError)
+		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags = flag.NewFlagSet("method-body-primitive-array-string-validate", flag.CommandLine.ErrorHandling())
 		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidatePFlag = serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags.String("p", "REQUIRED", "[]string is the payload type of the ServiceBodyPrimitiveArrayStringValidate service MethodBodyPrimitiveArrayStringValidate method.")
 	)
 	serviceBodyPrimitiveArrayStringValidateFlags.Usage = serviceBodyPrimitiveArrayStringValidateUsage
@@ -988,7 +1036,7 @@ func ParseEndpoint(
 	var (
 		serviceMapQueryPrimitiveArrayFlags = flag.NewFlagSet("service-map-query-primitive-array", flag.ContinueOnError)
 
-		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags = flag.NewFlagSet("map-query-primitive-array", flag.ExitOnError)
+		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags = flag.NewFlagSet("map-query-primitive-array", flag.CommandLine.ErrorHandling())
 		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayPFlag = serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags.String("p", "REQUIRED", "map[string][]uint is the payload type of the ServiceMapQueryPrimitiveArray service MapQueryPrimitiveArray method.")
 	)
 	serviceMapQueryPrimitiveArrayFlags.Usage = serviceMapQueryPrimitiveArrayUsage
@@ -1291,3 +1339,36 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	return v, nil
 }
 `
+
+var ClientCredentialsCommandsCode = `// Commands returns the commands and sub-commands supported by the CLI tool
+// along with their flags.
+func Commands() []*goacli.Command {
+	return []*goacli.Command{
+		{
+			Name:        "service-client-credentials",
+			Description: "Service is the ServiceClientCredentials service interface.",
+			Subcommands: []*goacli.Command{
+				{
+					Name:        "method-token-or-key",
+					Description: "MethodTokenOrKey implements MethodTokenOrKey.",
+					Flags: []*goacli.Flag{
+						{Name: "key", Credential: "api-key"},
+						{Name: "token", Credential: "token"},
+					},
+				},
+				{
+					Name:        "method-access-token",
+					Description: "MethodAccessToken implements MethodAccessToken.",
+					Flags: []*goacli.Flag{
+						{Name: "access-token", Credential: "token"},
+					},
+				},
+				{
+					Name:        "method-unsecure",
+					Description: "MethodUnsecure implements MethodUnsecure.",
+				},
+			},
+		},
+	}
+}
+`
@@ -210,6 +210,75 @@ func DecodeMethodQueryInt64Request(mux goahttp.Muxer, decoder func(*http.Request
 }
 `
 
+var PayloadQueryFieldsDecodeCode = `// DecodeMethodQueryFieldsRequest returns a decoder for requests sent to the
+// ServiceQueryFields MethodQueryFields endpoint.
+func DecodeMethodQueryFieldsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			fields []string
+			err    error
+		)
+		for _, v := range r.URL.Query()["fields"] {
+			fields = append(fields, strings.Split(v, ",")...)
+		}
+		for _, e := range fields {
+			if !(e == "id" || e == "name") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("fields[*]", e, []interface{}{"id", "name"}))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryFieldsPayload(fields)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryDurationDecodeCode = `// DecodeMethodQueryDurationRequest returns a decoder for requests sent to the
+// ServiceQueryDuration MethodQueryDuration endpoint.
+func DecodeMethodQueryDurationRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   *time.Duration
+			r   []time.Duration
+			err error
+		)
+		{
+			qRaw := r.URL.Query().Get("q")
+			if qRaw != "" {
+				v, err2 := strconv.ParseInt(qRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "duration"))
+				}
+				pv := time.Duration(v)
+				q = &pv
+			}
+		}
+		{
+			rRaw := r.URL.Query()["r"]
+			if rRaw != nil {
+				r = make([]time.Duration, len(rRaw))
+				for i, rv := range rRaw {
+					v, err2 := strconv.ParseInt(rv, 10, 64)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("r", rRaw, "array of durations"))
+					}
+					r[i] = time.Duration(v)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryDurationPayload(q, r)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryInt64ValidateDecodeCode = `// DecodeMethodQueryInt64ValidateRequest returns a decoder for requests sent to
 // the ServiceQueryInt64Validate MethodQueryInt64Validate endpoint.
 func DecodeMethodQueryInt64ValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
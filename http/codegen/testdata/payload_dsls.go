@@ -117,6 +117,57 @@ var PayloadQueryInt64DSL = func() {
 	})
 }
 
+var PayloadQueryDurationDSL = func() {
+	Service("ServiceQueryDuration", func() {
+		Method("MethodQueryDuration", func() {
+			Payload(func() {
+				Attribute("q", Duration)
+				Attribute("r", ArrayOf(Duration))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+				Param("r")
+			})
+		})
+	})
+}
+
+var PayloadQueryTimeDSL = func() {
+	Service("ServiceQueryTime", func() {
+		Method("MethodQueryTime", func() {
+			Payload(func() {
+				Attribute("q", Time)
+				Attribute("r", Time)
+				Required("r")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+				Param("r")
+			})
+		})
+	})
+}
+
+var PayloadQueryFieldsDSL = func() {
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+		})
+	})
+	Service("ServiceQueryFields", func() {
+		Method("MethodQueryFields", func() {
+			Result(Account)
+			SparseFieldsets()
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var PayloadQueryInt64ValidateDSL = func() {
 	Service("ServiceQueryInt64Validate", func() {
 		Method("MethodQueryInt64Validate", func() {
@@ -1355,6 +1406,47 @@ var PayloadExtendedQueryStringDSL = func() {
 	})
 }
 
+var PayloadNullableDSL = func() {
+	Service("ServiceNullable", func() {
+		Method("MethodNullable", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("nickname", String, func() {
+					Nullable()
+				})
+				Attribute("age", Int, func() {
+					Nullable()
+				})
+				Required("age")
+			})
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
+var PayloadStructTagsDSL = func() {
+	Service("ServiceStructTags", func() {
+		Method("MethodStructTags", func() {
+			Payload(func() {
+				StructTagNaming("snake_case", "db")
+				Attribute("userID", String)
+				Attribute("ssn", String, func() {
+					Meta("struct:tag:json", "SSN,omitempty")
+					StructTag("db", "ssn")
+				})
+				Attribute("ownerName", String, func() {
+					StructTag("bson", "owner")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadExtendedValidateDSL = func() {
 	var UT = Type("UserType", func() {
 		Attribute("q", String)
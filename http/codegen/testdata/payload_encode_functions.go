@@ -120,6 +120,47 @@ func EncodeMethodQueryInt64Request(encoder func(*http.Request) goahttp.Encoder)
 }
 `
 
+var PayloadQueryDurationEncodeCode = `// EncodeMethodQueryDurationRequest returns an encoder for requests sent to the
+// ServiceQueryDuration MethodQueryDuration server.
+func EncodeMethodQueryDurationRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryduration.MethodQueryDurationPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryDuration", "MethodQueryDuration", "*servicequeryduration.MethodQueryDurationPayload", v)
+		}
+		values := req.URL.Query()
+		if p.Q != nil {
+			values.Add("q", strconv.FormatInt(int64(*p.Q), 10))
+		}
+		for _, value := range p.R {
+			valueStr := strconv.FormatInt(int64(value), 10)
+			values.Add("r", valueStr)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQueryTimeEncodeCode = `// EncodeMethodQueryTimeRequest returns an encoder for requests sent to the
+// ServiceQueryTime MethodQueryTime server.
+func EncodeMethodQueryTimeRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequerytime.MethodQueryTimePayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryTime", "MethodQueryTime", "*servicequerytime.MethodQueryTimePayload", v)
+		}
+		values := req.URL.Query()
+		if p.Q != nil {
+			values.Add("q", (*p.Q).Format(time.RFC3339))
+		}
+		values.Add("r", p.R.Format(time.RFC3339))
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
 var PayloadQueryInt64ValidateEncodeCode = `// EncodeMethodQueryInt64ValidateRequest returns an encoder for requests sent
 // to the ServiceQueryInt64Validate MethodQueryInt64Validate server.
 func EncodeMethodQueryInt64ValidateRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
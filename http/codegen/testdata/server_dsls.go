@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/v3/dsl"
 )
 
@@ -81,6 +83,50 @@ var ServerPayloadResultErrorDSL = func() {
 	})
 }
 
+var ServerPayloadResultLimitsDSL = func() {
+	Service("ServicePayloadResultLimits", func() {
+		RequestTimeout(30 * time.Second)
+		Method("MethodPayloadResultLimits", func() {
+			RequestBodyLimit(1024)
+			Payload(func() {
+				Attribute("a", Boolean)
+			})
+			Result(func() {
+				Attribute("b", Boolean)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ServerCORSDSL = func() {
+	API("ServerCORS", func() {
+		Origin("*")
+	})
+	Service("ServiceCORS", func() {
+		Origin("https://*.goa.design", func() {
+			AllowMethods("GET", "PUT")
+			AllowHeaders("Authorization")
+			ExposeHeaders("X-Request-Id")
+			MaxAge(600)
+			AllowCredentials()
+		})
+		Origin("/^http://localhost:[0-9]+$/")
+		Method("MethodCORS", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				PUT("/{id}")
+			})
+		})
+	})
+}
+
 var ServerMultiBasesDSL = func() {
 	Service("ServiceMultiBases", func() {
 		HTTP(func() {
@@ -37,6 +37,7 @@ func NewStreamingResultMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultService")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -183,6 +184,7 @@ func NewStreamingResultNoPayloadMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNoPayloadService")
+		middleware.SetServiceMethod(ctx)
 		var err error
 
 		var cancel context.CancelFunc
@@ -956,6 +958,7 @@ func NewStreamingPayloadMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadService")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -1131,6 +1134,7 @@ func NewStreamingPayloadNoPayloadMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadNoPayloadService")
+		middleware.SetServiceMethod(ctx)
 		var err error
 
 		var cancel context.CancelFunc
@@ -2125,6 +2129,7 @@ func NewBidirectionalStreamingMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingService")
+		middleware.SetServiceMethod(ctx)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
@@ -2341,6 +2346,7 @@ func NewBidirectionalStreamingNoPayloadMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingNoPayloadService")
+		middleware.SetServiceMethod(ctx)
 		var err error
 
 		var cancel context.CancelFunc
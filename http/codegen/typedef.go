@@ -29,6 +29,9 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 		if t, _ := codegen.GetMetaType(att); t != "" {
 			return t
 		}
+		if att.IsNullable() {
+			return codegen.GoNullableTypeName(actual)
+		}
 		return codegen.GoNativeTypeName(actual)
 	case *expr.Array:
 		d := goTypeDef(scope, actual.ElemType, ptr, useDefault)
@@ -62,7 +65,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 				fn = codegen.GoifyAtt(at, name, true)
 				tdef = goTypeDef(scope, at, ptr, useDefault)
 				if expr.IsPrimitive(at.Type) {
-					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any {
+					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any && !at.IsNullable() {
 						tdef = "*" + tdef
 					}
 				} else if expr.IsObject(at.Type) {
@@ -71,7 +74,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 				if at.Description != "" {
 					desc = codegen.Comment(at.Description) + "\n\t"
 				}
-				tags = attributeTags(mat, at, elem, ptr || !ma.IsRequired(name))
+				tags = attributeTags(at, elem, ptr || !ma.IsRequired(name))
 			}
 			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, tags))
 			return nil
@@ -85,10 +88,20 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 	}
 }
 
-// attributeTags computes the struct field tags.
-func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) string {
-	if tags := codegen.AttributeTags(parent, att); tags != "" {
-		return tags
+// attributeTags computes the struct field tags of the transport types. The
+// encoding tags (form, json and xml) set explicitly on the attribute override
+// the tags goa would otherwise set. The other tags (e.g. db or bson) as well as
+// the naming strategies defined on parent attributes only apply to the service
+// types.
+func attributeTags(att *expr.AttributeExpr, t string, optional bool) string {
+	explicit := &expr.AttributeExpr{Meta: expr.MetaExpr{}}
+	for _, tag := range encodingTags {
+		if v, ok := att.Meta["struct:tag:"+tag]; ok {
+			explicit.Meta["struct:tag:"+tag] = v
+		}
+	}
+	if len(explicit.Meta) > 0 {
+		return codegen.AttributeTags(nil, explicit)
 	}
 	var o string
 	if optional {
@@ -96,3 +109,24 @@ func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) str
 	}
 	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
 }
+
+// hasNullableFields returns true if att is an object with fields that hold goa
+// Nullable values.
+func hasNullableFields(att *expr.AttributeExpr) bool {
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return false
+	}
+	for _, nat := range *obj {
+		if !expr.IsPrimitive(nat.Attribute.Type) || !nat.Attribute.IsNullable() {
+			continue
+		}
+		if t, _ := codegen.GetMetaType(nat.Attribute); t == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// encodingTags lists the struct tags used by the transport encoders.
+var encodingTags = []string{"form", "json", "xml"}
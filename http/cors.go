@@ -0,0 +1,242 @@
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// CORSOrigin describes the CORS policy that applies to the requests
+	// made from a given origin.
+	CORSOrigin struct {
+		// Origin is the allowed origin. It is either "*", an exact
+		// origin, an origin containing "*" wildcards or a regular
+		// expression delimited by slashes.
+		Origin string
+		// Methods lists the HTTP methods allowed in actual requests,
+		// empty means the methods of the routes the policy applies to.
+		Methods []string
+		// Headers lists the request headers allowed in actual requests,
+		// "*" allows any header.
+		Headers []string
+		// ExposedHeaders lists the response headers exposed to the
+		// browsers.
+		ExposedHeaders []string
+		// MaxAge is the number of seconds the browsers may cache the
+		// results of preflight requests, zero means no caching.
+		MaxAge uint
+		// Credentials indicates whether the browsers may send
+		// credentials with the requests.
+		Credentials bool
+	}
+
+	// CORSPolicy is a list of CORS origin policies. The first origin policy
+	// that matches the origin of a request applies.
+	CORSPolicy struct {
+		origins []*CORSOrigin
+		// matchers contains the compiled origins indexed by origin
+		// policy, nil for exact origins and "*".
+		matchers []*regexp.Regexp
+	}
+
+	// preflight handles the CORS preflight requests sent to a given path.
+	preflight struct {
+		mu sync.RWMutex
+		// policies contains the CORS policies indexed by HTTP method.
+		policies map[string]*CORSPolicy
+	}
+
+	// preflights contains the preflight handlers mounted on a muxer
+	// indexed by path. The muxers returned by NewMuxer and NewStdMuxer
+	// embed it.
+	preflights struct {
+		mu       sync.Mutex
+		handlers map[string]*preflight
+	}
+
+	// preflightMuxer is the interface implemented by the muxers that keep
+	// track of the preflight handlers mounted on them.
+	preflightMuxer interface {
+		// preflight returns the preflight handler mounted on the given
+		// path, created is true if the handler must be mounted.
+		preflight(path string) (pf *preflight, created bool)
+	}
+)
+
+// NewCORSPolicy returns a CORS policy that applies the given origin policies.
+func NewCORSPolicy(origins ...*CORSOrigin) *CORSPolicy {
+	matchers := make([]*regexp.Regexp, len(origins))
+	for i, o := range origins {
+		switch {
+		case len(o.Origin) > 1 && o.Origin[0] == '/' && o.Origin[len(o.Origin)-1] == '/':
+			matchers[i] = regexp.MustCompile(o.Origin[1 : len(o.Origin)-1])
+		case o.Origin != "*" && strings.Contains(o.Origin, "*"):
+			pattern := strings.Replace(regexp.QuoteMeta(o.Origin), `\*`, ".*", -1)
+			matchers[i] = regexp.MustCompile("^" + pattern + "$")
+		}
+	}
+	return &CORSPolicy{origins: origins, matchers: matchers}
+}
+
+// Match returns the origin policy that applies to the given origin, nil if
+// the origin is not allowed.
+func (p *CORSPolicy) Match(origin string) *CORSOrigin {
+	if origin == "" {
+		return nil
+	}
+	for i, o := range p.origins {
+		if m := p.matchers[i]; m != nil {
+			if m.MatchString(origin) {
+				return o
+			}
+			continue
+		}
+		if o.Origin == "*" || o.Origin == origin {
+			return o
+		}
+	}
+	return nil
+}
+
+// Handler returns a HTTP handler that adds the CORS response headers to the
+// responses of the requests whose origin is allowed before calling h.
+func (p *CORSPolicy) Handler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		o := p.Match(origin)
+		if o == nil || o.Origin != "*" || o.Credentials {
+			// The response depends on the origin unless all origins
+			// are allowed.
+			w.Header().Add("Vary", "Origin")
+		}
+		if o != nil {
+			setAllowOrigin(w, o, origin)
+			if len(o.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(o.ExposedHeaders, ", "))
+			}
+		}
+		h(w, r)
+	}
+}
+
+// MountCORSPreflight mounts a handler on mux that replies to the CORS
+// preflight requests sent to path for actual requests using the HTTP method
+// verb. The muxers returned by NewMuxer and NewStdMuxer mount the handler once
+// per path, subsequent calls for the same path register additional methods.
+// Other muxers get one handler mounted per call, so they must accept multiple
+// OPTIONS handlers for the same path if it is used by several CORS enabled
+// methods.
+func MountCORSPreflight(mux Muxer, path, verb string, p *CORSPolicy) {
+	var (
+		pf      *preflight
+		created = true
+	)
+	if pm, ok := mux.(preflightMuxer); ok {
+		pf, created = pm.preflight(path)
+	} else {
+		pf = newPreflight()
+	}
+	pf.mu.Lock()
+	pf.policies[verb] = p
+	pf.mu.Unlock()
+	if created {
+		mux.Handle("OPTIONS", path, pf.handle)
+	}
+}
+
+// preflight implements preflightMuxer.
+func (ps *preflights) preflight(path string) (*preflight, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if pf, ok := ps.handlers[path]; ok {
+		return pf, false
+	}
+	if ps.handlers == nil {
+		ps.handlers = make(map[string]*preflight)
+	}
+	pf := newPreflight()
+	ps.handlers[path] = pf
+	return pf, true
+}
+
+// newPreflight returns a preflight handler with no policy.
+func newPreflight() *preflight {
+	return &preflight{policies: make(map[string]*CORSPolicy)}
+}
+
+// handle replies to a CORS preflight request.
+func (pf *preflight) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Origin")
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+	var (
+		origin = r.Header.Get("Origin")
+		method = r.Header.Get("Access-Control-Request-Method")
+	)
+	pf.mu.RLock()
+	p := pf.policies[method]
+	pf.mu.RUnlock()
+	if p == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	o := p.Match(origin)
+	if o == nil || !o.allowsMethod(method) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	setAllowOrigin(w, o, origin)
+	methods := o.Methods
+	if len(methods) == 0 {
+		methods = []string{method}
+	}
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if hs := o.allowedHeaders(r.Header.Get("Access-Control-Request-Headers")); hs != "" {
+		w.Header().Set("Access-Control-Allow-Headers", hs)
+	}
+	if o.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.FormatUint(uint64(o.MaxAge), 10))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowsMethod returns true if the origin policy allows actual requests using
+// the given HTTP method.
+func (o *CORSOrigin) allowsMethod(method string) bool {
+	if len(o.Methods) == 0 {
+		return true
+	}
+	for _, m := range o.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedHeaders returns the value of the Access-Control-Allow-Headers
+// header given the value of the Access-Control-Request-Headers header.
+func (o *CORSOrigin) allowedHeaders(requested string) string {
+	for _, h := range o.Headers {
+		if h == "*" {
+			return requested
+		}
+	}
+	return strings.Join(o.Headers, ", ")
+}
+
+// setAllowOrigin sets the Access-Control-Allow-Origin and
+// Access-Control-Allow-Credentials response headers.
+func setAllowOrigin(w http.ResponseWriter, o *CORSOrigin, origin string) {
+	if o.Origin == "*" && !o.Credentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if o.Credentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
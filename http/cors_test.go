@@ -0,0 +1,138 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSPolicyMatch(t *testing.T) {
+	p := NewCORSPolicy(
+		&CORSOrigin{Origin: "https://goa.design"},
+		&CORSOrigin{Origin: "https://*.example.com"},
+		&CORSOrigin{Origin: "/^http://localhost:[0-9]+$/"},
+	)
+	cases := map[string]struct {
+		Origin   string
+		Expected string
+	}{
+		"exact":          {"https://goa.design", "https://goa.design"},
+		"wildcard":       {"https://api.example.com", "https://*.example.com"},
+		"wildcard-quote": {"https://apiXexample.com", ""},
+		"regexp":         {"http://localhost:8080", "/^http://localhost:[0-9]+$/"},
+		"no-match":       {"https://evil.com", ""},
+		"empty":          {"", ""},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			o := p.Match(tc.Origin)
+			if tc.Expected == "" {
+				if o != nil {
+					t.Errorf("got match %q, expected none", o.Origin)
+				}
+				return
+			}
+			if o == nil || o.Origin != tc.Expected {
+				t.Errorf("got match %v, expected %q", o, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestCORSPolicyHandler(t *testing.T) {
+	p := NewCORSPolicy(&CORSOrigin{Origin: "https://goa.design", ExposedHeaders: []string{"X-Request-Id"}, Credentials: true})
+	h := p.Handler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	cases := map[string]struct {
+		Origin      string
+		AllowOrigin string
+	}{
+		"allowed":     {"https://goa.design", "https://goa.design"},
+		"not-allowed": {"https://evil.com", ""},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Origin", tc.Origin)
+			w := httptest.NewRecorder()
+
+			h(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			if o := w.Header().Get("Access-Control-Allow-Origin"); o != tc.AllowOrigin {
+				t.Errorf("got allowed origin %q, expected %q", o, tc.AllowOrigin)
+			}
+			if v := w.Header().Get("Vary"); v != "Origin" {
+				t.Errorf("got vary %q, expected %q", v, "Origin")
+			}
+			if tc.AllowOrigin == "" {
+				return
+			}
+			if c := w.Header().Get("Access-Control-Allow-Credentials"); c != "true" {
+				t.Errorf("got allow credentials %q, expected %q", c, "true")
+			}
+			if e := w.Header().Get("Access-Control-Expose-Headers"); e != "X-Request-Id" {
+				t.Errorf("got exposed headers %q, expected %q", e, "X-Request-Id")
+			}
+		})
+	}
+}
+
+func TestMountCORSPreflight(t *testing.T) {
+	var (
+		public = NewCORSPolicy(&CORSOrigin{Origin: "*", Headers: []string{"*"}, MaxAge: 600})
+		site   = NewCORSPolicy(&CORSOrigin{Origin: "https://goa.design", Methods: []string{"PUT"}, Headers: []string{"Authorization"}})
+		muxes  = map[string]Muxer{"treemux": NewMuxer(), "std": NewStdMuxer()}
+	)
+	for _, mux := range muxes {
+		mux.Handle("GET", "/items/{id}", func(http.ResponseWriter, *http.Request) {})
+		MountCORSPreflight(mux, "/items/{id}", "GET", public)
+		MountCORSPreflight(mux, "/items/{id}", "PUT", site)
+	}
+	cases := map[string]struct {
+		Origin         string
+		Method         string
+		Headers        string
+		AllowOrigin    string
+		AllowMethods   string
+		AllowHeaders   string
+		MaxAge         string
+		ExpectedStatus int
+	}{
+		"any-origin":        {"https://foo.com", "GET", "X-Foo", "*", "GET", "X-Foo", "600", http.StatusNoContent},
+		"site-origin":       {"https://goa.design", "PUT", "", "https://goa.design", "PUT", "Authorization", "", http.StatusNoContent},
+		"site-not-allowed":  {"https://foo.com", "PUT", "", "", "", "", "", http.StatusNoContent},
+		"method-not-routed": {"https://foo.com", "DELETE", "", "", "", "", "", http.StatusNoContent},
+	}
+	for k, tc := range cases {
+		for n, mux := range muxes {
+			t.Run(n+"/"+k, func(t *testing.T) {
+				r := httptest.NewRequest("OPTIONS", "/items/1", nil)
+				r.Header.Set("Origin", tc.Origin)
+				r.Header.Set("Access-Control-Request-Method", tc.Method)
+				if tc.Headers != "" {
+					r.Header.Set("Access-Control-Request-Headers", tc.Headers)
+				}
+				w := httptest.NewRecorder()
+
+				mux.ServeHTTP(w, r)
+
+				if w.Code != tc.ExpectedStatus {
+					t.Errorf("got status %d, expected %d", w.Code, tc.ExpectedStatus)
+				}
+				expected := map[string]string{
+					"Access-Control-Allow-Origin":  tc.AllowOrigin,
+					"Access-Control-Allow-Methods": tc.AllowMethods,
+					"Access-Control-Allow-Headers": tc.AllowHeaders,
+					"Access-Control-Max-Age":       tc.MaxAge,
+				}
+				for h, v := range expected {
+					if got := w.Header().Get(h); got != v {
+						t.Errorf("got %s %q, expected %q", h, got, v)
+					}
+				}
+			})
+		}
+	}
+}
@@ -1,7 +1,8 @@
 /*
 Package http contains HTTP specific constructs that complement the code
 generated by Goa. The constructs include a composable HTTP client, default
-encodings, a mux and a websocket implementation that relies on the Gorilla
+encodings, two muxers (one based on httptreemux and one that only depends on
+the standard library) and a websocket implementation that relies on the Gorilla
 websocket package.
 */
 package http
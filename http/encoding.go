@@ -12,6 +12,8 @@ import (
 	"mime"
 	"net/http"
 	"strings"
+
+	"goa.design/goa/v3/middleware"
 )
 
 const (
@@ -23,6 +25,10 @@ const (
 	// response Content-Type header when explicitly set in the DSL. The value
 	// may be used by encoders to set the header appropriately.
 	ContentTypeKey
+	// RequestURLKey is the context key used to store the URL of the HTTP
+	// request handled by a paginated endpoint. The value is used by the
+	// response encoder to compute the URL of the next page.
+	RequestURLKey
 )
 
 type (
@@ -209,9 +215,14 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // and if so uses the error temporary and timeout fields to infer a proper HTTP
 // status code and marshals the error struct to the body using the provided
 // encoder. If the error is not a goa ServiceError struct then it is encoded
-// as a permanent internal server error.
+// as a permanent internal server error. The encoder records the name of the
+// error in the request information so that it may be used by the log and
+// metrics middlewares.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		if ri := middleware.ContextRequestInfo(ctx); ri != nil {
+			ri.ErrorName = middleware.ErrorName(err)
+		}
 		enc := encoder(ctx, w)
 		if formatter == nil {
 			formatter = NewErrorResponse
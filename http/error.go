@@ -23,6 +23,9 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+
+		// status is the response status code if set by the error.
+		status int
 	}
 
 	// Statuser is implemented by error response object to provide the response
@@ -36,6 +39,11 @@ type (
 
 // NewErrorResponse creates a HTTP response from the given error.
 func NewErrorResponse(err error) Statuser {
+	if lerr, ok := err.(*LimitError); ok {
+		resp := NewErrorResponse(lerr.ServiceError).(*ErrorResponse)
+		resp.status = lerr.Status
+		return resp
+	}
 	if gerr, ok := err.(*goa.ServiceError); ok {
 		return &ErrorResponse{
 			Name:      gerr.Name,
@@ -51,9 +59,14 @@ func NewErrorResponse(err error) Statuser {
 
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
-// error. This method is used by the generated server code when the error is not
-// described explicitly in the design.
+// error. The errors produced by the generated code when a request exceeds the
+// body size limit or the timeout defined in the design (see LimitError) use
+// their own status code. This method is used by the generated server code when
+// the error is not described explicitly in the design.
 func (resp *ErrorResponse) StatusCode() int {
+	if resp.status != 0 {
+		return resp.status
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
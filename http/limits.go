@@ -0,0 +1,91 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// LimitError is the error produced by LimitRequestBody and by the generated
+// servers when a request exceeds the body size limit or the timeout defined
+// in the design. The error response uses its HTTP status code.
+type LimitError struct {
+	*goa.ServiceError
+	// Status is the HTTP status code of the error response.
+	Status int
+}
+
+// limitedBody is a request body that fails once more than n bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	n        int64
+	limit    int64
+	exceeded bool
+}
+
+// LimitRequestBody limits the size of the body of the given request to n
+// bytes. It returns the ErrRequestBodyTooLarge error if the request
+// Content-Length header exceeds n. Otherwise it replaces the request body with a reader that
+// fails once more than n bytes are read, RequestBodyLimitExceeded reports
+// whether that happened.
+func LimitRequestBody(r *http.Request, n int64) error {
+	if r.ContentLength > n {
+		return ErrRequestBodyTooLarge(n)
+	}
+	if r.Body != nil {
+		r.Body = &limitedBody{ReadCloser: r.Body, n: n, limit: n}
+	}
+	return nil
+}
+
+// RequestBodyLimitExceeded returns true if reading the body of the given
+// request failed because its size exceeded the limit set with
+// LimitRequestBody.
+func RequestBodyLimitExceeded(r *http.Request) bool {
+	b, ok := r.Body.(*limitedBody)
+	return ok && b.exceeded
+}
+
+// Read reads from the underlying body and fails once more than the limit is
+// read.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrRequestBodyTooLarge(b.limit)
+	}
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.n {
+		b.n -= int64(n)
+		return n, err
+	}
+	b.exceeded = true
+	return int(b.n), ErrRequestBodyTooLarge(b.limit)
+}
+
+// ErrRequestBodyTooLarge returns the error produced when the size of a request
+// body exceeds limit. The error response uses the 413 Request Entity Too Large
+// status code.
+func ErrRequestBodyTooLarge(limit int64) error {
+	return &LimitError{
+		ServiceError: goa.RequestBodyTooLargeError(limit).(*goa.ServiceError),
+		Status:       http.StatusRequestEntityTooLarge,
+	}
+}
+
+// ErrDeadlineExceeded returns the error produced when a request is not handled
+// within timeout. The error response uses the 504 Gateway Timeout status code.
+func ErrDeadlineExceeded(timeout time.Duration) error {
+	return &LimitError{
+		ServiceError: goa.DeadlineExceededError(timeout).(*goa.ServiceError),
+		Status:       http.StatusGatewayTimeout,
+	}
+}
+
+// Unwrap returns the goa service error.
+func (e *LimitError) Unwrap() error {
+	return e.ServiceError
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLimitRequestBody(t *testing.T) {
+	cases := map[string]struct {
+		Body          string
+		ContentLength int64
+		Limit         int64
+		LimitErr      bool
+		ReadErr       bool
+	}{
+		"under-limit":             {Body: "abc", ContentLength: 3, Limit: 4},
+		"at-limit":                {Body: "abcd", ContentLength: 4, Limit: 4},
+		"content-length-exceeded": {Body: "abcde", ContentLength: 5, Limit: 4, LimitErr: true},
+		"body-exceeded":           {Body: "abcde", ContentLength: -1, Limit: 4, ReadErr: true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tc.Body))
+			r.ContentLength = tc.ContentLength
+
+			err := LimitRequestBody(r, tc.Limit)
+
+			if tc.LimitErr {
+				if lerr, ok := err.(*LimitError); !ok || lerr.Name != "request_body_too_large" {
+					t.Errorf("got error %v, expected request_body_too_large", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			b, err := ioutil.ReadAll(r.Body)
+			if tc.ReadErr {
+				if err == nil {
+					t.Error("got nil read error, expected an error")
+				}
+				if int64(len(b)) != tc.Limit {
+					t.Errorf("got %d bytes, expected %d", len(b), tc.Limit)
+				}
+				if !RequestBodyLimitExceeded(r) {
+					t.Error("got limit not exceeded, expected exceeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got read error %v, expected nil", err)
+			}
+			if string(b) != tc.Body {
+				t.Errorf("got body %q, expected %q", string(b), tc.Body)
+			}
+			if RequestBodyLimitExceeded(r) {
+				t.Error("got limit exceeded, expected not exceeded")
+			}
+		})
+	}
+}
+
+func TestErrorResponseStatusCode(t *testing.T) {
+	cases := map[string]struct {
+		Error  error
+		Status int
+	}{
+		"body-too-large":    {ErrRequestBodyTooLarge(4), http.StatusRequestEntityTooLarge},
+		"deadline-exceeded": {ErrDeadlineExceeded(0), http.StatusGatewayTimeout},
+		"same-name":         {goa.PermanentError("request_body_too_large", "error"), http.StatusBadRequest},
+		"timeout":           {goa.TemporaryTimeoutError("timeout", "error"), http.StatusGatewayTimeout},
+		"fault":             {goa.Fault("error"), http.StatusInternalServerError},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			if s := NewErrorResponse(tc.Error).StatusCode(); s != tc.Status {
+				t.Errorf("got status %d, expected %d", s, tc.Status)
+			}
+		})
+	}
+}
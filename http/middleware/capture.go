@@ -33,6 +33,20 @@ func (w *ResponseCapture) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Flush supports the http.Flusher interface. Flushing sends the response
+// headers so Flush records the implicit 200 status code if no status code was
+// written yet.
+func (w *ResponseCapture) Flush() {
+	f, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+	if w.StatusCode == 0 {
+		w.StatusCode = http.StatusOK
+	}
+	f.Flush()
+}
+
 // Hijack supports the http.Hijacker interface.
 func (w *ResponseCapture) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
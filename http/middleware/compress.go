@@ -0,0 +1,441 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// CompressOption configures the compression middleware and client
+	// Doer.
+	CompressOption func(*compressOptions)
+
+	// compressOptions contains the compression settings.
+	compressOptions struct {
+		minSize  int
+		level    int
+		requests bool
+	}
+
+	// compressor is implemented by the gzip and zlib writers.
+	compressor interface {
+		io.WriteCloser
+		Flush() error
+		Reset(io.Writer)
+	}
+
+	// compressPools contains the pools of compressors indexed by encoding.
+	compressPools map[string]*sync.Pool
+
+	// compressWriter is a http.ResponseWriter that buffers the response
+	// body until it reaches the minimum size and then compresses it.
+	compressWriter struct {
+		http.ResponseWriter
+		encoding  string
+		minSize   int
+		pool      *sync.Pool
+		code      int
+		buf       []byte
+		enc       compressor
+		committed bool
+	}
+
+	// decompressBody is a body that decompresses the underlying body on
+	// the fly. The decompressor is created on the first read so that empty
+	// bodies are not read eagerly.
+	decompressBody struct {
+		io.ReadCloser
+		encoding string
+		r        io.ReadCloser
+	}
+
+	// compressDoer is a client Doer that negotiates response compression
+	// and optionally compresses the request bodies.
+	compressDoer struct {
+		Doer
+		opts  *compressOptions
+		pools compressPools
+	}
+)
+
+const (
+	// DefaultCompressMinSize is the default minimum size in bytes of the
+	// bodies being compressed.
+	DefaultCompressMinSize = 1024
+
+	// acceptEncodings is the value of the Accept-Encoding header set by
+	// the client Doer.
+	acceptEncodings = "gzip, deflate"
+)
+
+// Compress returns a middleware that compresses the response bodies using the
+// gzip or deflate encoding negotiated with the request Accept-Encoding header.
+// Only bodies whose size exceeds a minimum (DefaultCompressMinSize by default)
+// are compressed, smaller bodies and responses that already define a
+// Content-Encoding header are written as is. The response writer supports
+// hijacking so that the middleware may wrap websocket handlers.
+//
+// Compress panics if the compression level given with CompressLevel is
+// invalid.
+func Compress(opts ...CompressOption) func(http.Handler) http.Handler {
+	o := newCompressOptions(opts)
+	pools := newCompressPools(o.level)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == "HEAD" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        o.minSize,
+				pool:           pools[encoding],
+			}
+			defer cw.close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// Decompress returns a middleware that decompresses the request bodies
+// encoded with gzip or deflate as indicated by the Content-Encoding header.
+// The middleware removes the Content-Encoding and Content-Length headers of
+// the decompressed requests. Reading an invalid compressed body fails.
+//
+// Any request body limit set by the generated code applies to the
+// decompressed body.
+func Decompress() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+			if r.Body != nil && (encoding == "gzip" || encoding == "deflate") {
+				r.Body = &decompressBody{ReadCloser: r.Body, encoding: encoding}
+				r.Header.Del("Content-Encoding")
+				r.Header.Del("Content-Length")
+				r.ContentLength = -1
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CompressDoer wraps a goa client Doer so that it requests compressed
+// responses and decompresses them transparently. If the CompressRequests
+// option is given the Doer also compresses the request bodies using gzip.
+//
+// CompressDoer panics if the compression level given with CompressLevel is
+// invalid.
+func CompressDoer(doer Doer, opts ...CompressOption) Doer {
+	o := newCompressOptions(opts)
+	return &compressDoer{Doer: doer, opts: o, pools: newCompressPools(o.level)}
+}
+
+// CompressMinSize sets the minimum size in bytes of the bodies being
+// compressed.
+func CompressMinSize(n int) CompressOption {
+	return func(o *compressOptions) {
+		o.minSize = n
+	}
+}
+
+// CompressLevel sets the compression level, see the compress/flate package
+// for the list of valid levels.
+func CompressLevel(level int) CompressOption {
+	return func(o *compressOptions) {
+		o.level = level
+	}
+}
+
+// CompressRequests makes the client Doer created with CompressDoer compress
+// the request bodies whose size is known and exceeds the minimum size. The
+// server must decompress the requests, for example using the Decompress
+// middleware.
+func CompressRequests() CompressOption {
+	return func(o *compressOptions) {
+		o.requests = true
+	}
+}
+
+// Do compresses the request body if needed, makes the request and
+// decompresses the response body.
+func (d *compressDoer) Do(r *http.Request) (*http.Response, error) {
+	if d.opts.requests && r.Body != nil && r.ContentLength >= int64(d.opts.minSize) && r.Header.Get("Content-Encoding") == "" {
+		if err := d.compressRequest(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.Header.Get("Accept-Encoding") != "" {
+		// Let the caller handle the encodings it asked for.
+		return d.Doer.Do(r)
+	}
+	r.Header.Set("Accept-Encoding", acceptEncodings)
+	resp, err := d.Doer.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "deflate" {
+		return resp, nil
+	}
+	resp.Body = &decompressBody{ReadCloser: resp.Body, encoding: encoding}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// compressRequest replaces the body of r with its gzip encoding.
+func (d *compressDoer) compressRequest(r *http.Request) error {
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := d.pools["gzip"].Get().(compressor)
+	defer d.pools["gzip"].Put(enc)
+	enc.Reset(&buf)
+	if _, err := enc.Write(body); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	compressed := buf.Bytes()
+	r.Body = ioutil.NopCloser(bytes.NewReader(compressed))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	r.ContentLength = int64(len(compressed))
+	r.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// WriteHeader records the status code, the header is written once the
+// middleware knows whether the body is compressed.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.committed {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code < 200 {
+		// Informational responses are written as is.
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.code != 0 {
+		return
+	}
+	w.code = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		w.commit(false)
+	}
+}
+
+// Write buffers b until the body size exceeds the minimum size and then
+// compresses it.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.committed {
+		if w.code == 0 {
+			w.code = http.StatusOK
+		}
+		if len(w.buf)+len(b) < w.minSize {
+			w.buf = append(w.buf, b...)
+			return len(b), nil
+		}
+		if err := w.commit(true); err != nil {
+			return 0, err
+		}
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush writes the buffered body uncompressed if the response is not
+// compressed yet and flushes the compressor and the underlying writer.
+func (w *compressWriter) Flush() {
+	if !w.committed {
+		w.commit(false)
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports the http.Hijacker interface.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.committed = true
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// commit writes the response header and the buffered body. The body is
+// compressed if compress is true and the handler did not encode it already.
+// The content type of compressed bodies is detected from the uncompressed
+// buffer if the handler did not set it, net/http would otherwise detect it
+// from the compressed bytes.
+func (w *compressWriter) commit(compress bool) error {
+	w.committed = true
+	h := w.Header()
+	if compress && h.Get("Content-Encoding") == "" {
+		if _, ok := h["Content-Type"]; !ok {
+			h.Set("Content-Type", http.DetectContentType(w.buf))
+		}
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		w.enc = w.pool.Get().(compressor)
+		w.enc.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.code)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	return err
+}
+
+// close writes any buffered body and flushes the compressor.
+func (w *compressWriter) close() {
+	if !w.committed && w.code != 0 {
+		w.commit(false)
+	}
+	if w.enc != nil {
+		w.enc.Close()
+		w.pool.Put(w.enc)
+		w.enc = nil
+	}
+}
+
+// Read decompresses the underlying body.
+func (b *decompressBody) Read(p []byte) (int, error) {
+	if b.r == nil {
+		switch b.encoding {
+		case "gzip":
+			r, err := gzip.NewReader(b.ReadCloser)
+			if err != nil {
+				return 0, err
+			}
+			b.r = r
+		default:
+			// The HTTP "deflate" encoding is the zlib format (RFC
+			// 1950), not raw deflate.
+			r, err := zlib.NewReader(b.ReadCloser)
+			if err != nil {
+				return 0, err
+			}
+			b.r = r
+		}
+	}
+	return b.r.Read(p)
+}
+
+// Close closes the decompressor and the underlying body.
+func (b *decompressBody) Close() error {
+	var err error
+	if b.r != nil {
+		err = b.r.Close()
+	}
+	if cerr := b.ReadCloser.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// negotiateEncoding returns the encoding to use given the value of the
+// Accept-Encoding header, "gzip" is preferred over "deflate" for equal
+// weights. It returns an empty string if the response must not be encoded.
+func negotiateEncoding(accept string) string {
+	var (
+		best    string
+		bestQ   float64
+		anyQ    = -1.0
+		weights = make(map[string]float64)
+	)
+	for _, part := range strings.Split(accept, ",") {
+		elems := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(elems[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range elems[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if name == "*" {
+			anyQ = q
+			continue
+		}
+		weights[name] = q
+	}
+	for _, enc := range []string{"gzip", "deflate"} {
+		q, ok := weights[enc]
+		if !ok {
+			q = anyQ
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// newCompressOptions applies opts to the default options.
+func newCompressOptions(opts []CompressOption) *compressOptions {
+	o := &compressOptions{minSize: DefaultCompressMinSize, level: gzip.DefaultCompression}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// newCompressPools returns the pools of gzip and deflate compressors using
+// the given compression level. The deflate compressors produce the zlib
+// format (RFC 1950) as required by the HTTP "deflate" encoding. It panics if
+// the level is invalid.
+func newCompressPools(level int) compressPools {
+	if _, err := zlib.NewWriterLevel(ioutil.Discard, level); err != nil {
+		panic(err)
+	}
+	return compressPools{
+		"gzip": {New: func() interface{} {
+			w, _ := gzip.NewWriterLevel(ioutil.Discard, level)
+			return w
+		}},
+		"deflate": {New: func() interface{} {
+			w, _ := zlib.NewWriterLevel(ioutil.Discard, level)
+			return w
+		}},
+	}
+}
@@ -0,0 +1,285 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]struct {
+		Accept   string
+		Expected string
+	}{
+		"empty":          {"", ""},
+		"gzip":           {"gzip", "gzip"},
+		"deflate":        {"deflate", "deflate"},
+		"prefer-gzip":    {"deflate, gzip", "gzip"},
+		"weights":        {"gzip;q=0.5, deflate;q=0.8", "deflate"},
+		"refused":        {"gzip;q=0", ""},
+		"any":            {"*", "gzip"},
+		"any-but-gzip":   {"*, gzip;q=0", "deflate"},
+		"identity":       {"identity", ""},
+		"unknown":        {"br", ""},
+		"case-and-space": {" GZIP ; q=1", "gzip"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			if enc := negotiateEncoding(tc.Accept); enc != tc.Expected {
+				t.Errorf("got encoding %q, expected %q", enc, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	var (
+		small = "small body"
+		large = strings.Repeat("large body ", 200)
+	)
+	cases := map[string]struct {
+		Accept      string
+		Body        string
+		HandlerEnc  string
+		ContentType string
+		Compressed  bool
+	}{
+		"no-accept":       {Body: large},
+		"below-min-size":  {Accept: "gzip", Body: small},
+		"gzip":            {Accept: "gzip", Body: large, Compressed: true},
+		"deflate":         {Accept: "deflate", Body: large, Compressed: true},
+		"content-type":    {Accept: "gzip", Body: large, ContentType: "application/json", Compressed: true},
+		"already-encoded": {Accept: "gzip", Body: large, HandlerEnc: "br"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.HandlerEnc != "" {
+					w.Header().Set("Content-Encoding", tc.HandlerEnc)
+				}
+				if tc.ContentType != "" {
+					w.Header().Set("Content-Type", tc.ContentType)
+				}
+				w.WriteHeader(http.StatusCreated)
+				// Write in chunks to exercise buffering.
+				for i := 0; i < len(tc.Body); i += 100 {
+					end := i + 100
+					if end > len(tc.Body) {
+						end = len(tc.Body)
+					}
+					w.Write([]byte(tc.Body[i:end]))
+				}
+			}))
+			r := httptest.NewRequest("GET", "/", nil)
+			if tc.Accept != "" {
+				r.Header.Set("Accept-Encoding", tc.Accept)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusCreated)
+			}
+			if v := w.Header().Get("Vary"); v != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected %q", v, "Accept-Encoding")
+			}
+			enc := w.Header().Get("Content-Encoding")
+			if !tc.Compressed {
+				if enc != tc.HandlerEnc {
+					t.Errorf("got Content-Encoding %q, expected %q", enc, tc.HandlerEnc)
+				}
+				if w.Body.String() != tc.Body {
+					t.Errorf("got body %q, expected %q", w.Body.String(), tc.Body)
+				}
+				return
+			}
+			if enc != tc.Accept {
+				t.Errorf("got Content-Encoding %q, expected %q", enc, tc.Accept)
+			}
+			// The content type is detected from the uncompressed body.
+			ct := tc.ContentType
+			if ct == "" {
+				ct = "text/plain; charset=utf-8"
+			}
+			if v := w.Header().Get("Content-Type"); v != ct {
+				t.Errorf("got Content-Type %q, expected %q", v, ct)
+			}
+			if w.Body.Len() >= len(tc.Body) {
+				t.Errorf("got body of %d bytes, expected less than %d", w.Body.Len(), len(tc.Body))
+			}
+			var (
+				dr  io.Reader
+				err error
+			)
+			if enc == "deflate" {
+				// Make sure the body is in the zlib format.
+				dr, err = zlib.NewReader(w.Body)
+			} else {
+				dr, err = gzip.NewReader(w.Body)
+			}
+			if err != nil {
+				t.Fatalf("failed to create reader: %s", err)
+			}
+			b, err := ioutil.ReadAll(dr)
+			if err != nil {
+				t.Fatalf("failed to decompress body: %s", err)
+			}
+			if string(b) != tc.Body {
+				t.Errorf("got decompressed body %q, expected %q", string(b), tc.Body)
+			}
+		})
+	}
+}
+
+func TestCompressHijack(t *testing.T) {
+	var hijacked bool
+	h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("response writer is not a http.Hijacker")
+		}
+		if _, _, err := hj.Hijack(); err != nil {
+			t.Fatalf("failed to hijack: %s", err)
+		}
+		hijacked = true
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	h.ServeHTTP(&hijackRecorder{httptest.NewRecorder()}, r)
+
+	if !hijacked {
+		t.Error("handler did not hijack the connection")
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	expected := strings.Repeat("request body ", 100)
+	cases := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	}
+	for enc, newWriter := range cases {
+		t.Run(enc, func(t *testing.T) {
+			var buf bytes.Buffer
+			cw := newWriter(&buf)
+			cw.Write([]byte(expected))
+			cw.Close()
+			var got string
+			body := &closeRecorder{Reader: &buf}
+			h := Decompress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if enc := r.Header.Get("Content-Encoding"); enc != "" {
+					t.Errorf("got Content-Encoding %q, expected none", enc)
+				}
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read body: %s", err)
+				}
+				got = string(b)
+				if err := r.Body.Close(); err != nil {
+					t.Errorf("failed to close body: %s", err)
+				}
+			}))
+			r := httptest.NewRequest("POST", "/", nil)
+			r.Body = body
+			r.Header.Set("Content-Encoding", enc)
+
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != expected {
+				t.Errorf("got body %q, expected %q", got, expected)
+			}
+			if !body.closed {
+				t.Error("request body was not closed")
+			}
+		})
+	}
+}
+
+func TestCompressDoer(t *testing.T) {
+	body := strings.Repeat("payload ", 300)
+	var received string
+	srv := httptest.NewServer(Decompress()(Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received = string(b)
+		w.Write(b)
+	}))))
+	defer srv.Close()
+	var (
+		transport = &encodingRecorder{RoundTripper: http.DefaultTransport}
+		doer      = CompressDoer(&http.Client{Transport: transport}, CompressRequests())
+	)
+	r, _ := http.NewRequest("POST", srv.URL, strings.NewReader(body))
+
+	resp, err := doer.Do(r)
+
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+	if transport.requestEncoding != "gzip" {
+		t.Errorf("got request Content-Encoding %q, expected %q", transport.requestEncoding, "gzip")
+	}
+	if transport.responseEncoding != "gzip" {
+		t.Errorf("got response Content-Encoding %q, expected %q", transport.responseEncoding, "gzip")
+	}
+	if received != body {
+		t.Errorf("server got body %q, expected %q", received, body)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %s", err)
+	}
+	if string(b) != body {
+		t.Errorf("got response body %q, expected %q", string(b), body)
+	}
+	if enc := resp.Header.Get("Content-Encoding"); enc != "" {
+		t.Errorf("got Content-Encoding %q, expected none", enc)
+	}
+}
+
+// hijackRecorder is a response recorder that supports hijacking.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+// closeRecorder is a request body that records whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+// encodingRecorder records the content encodings of the requests and
+// responses going through the transport.
+type encodingRecorder struct {
+	http.RoundTripper
+	requestEncoding  string
+	responseEncoding string
+}
+
+func (e *encodingRecorder) RoundTrip(r *http.Request) (*http.Response, error) {
+	e.requestEncoding = r.Header.Get("Content-Encoding")
+	resp, err := e.RoundTripper.RoundTrip(r)
+	if err == nil {
+		e.responseEncoding = resp.Header.Get("Content-Encoding")
+	}
+	return resp, err
+}
@@ -1,23 +1,30 @@
-/*Package middleware contains HTTP middlewares that wrap a HTTP handler to
+/*
+Package middleware contains HTTP middlewares that wrap a HTTP handler to
 provide additional functionality.
 
 The package contains the following middlewares:
 
-  * Logging server middleware for logging requests and responses.
-  * Request ID server middleware to include a unique request ID on receiving
+  - Compression server middleware that compresses the response bodies and
+    decompresses the request bodies, and matching client Doer.
+  - Logging server middleware for logging requests and responses.
+  - Metrics server middleware and handler exposing the metrics using the
+    Prometheus text exposition format.
+  - Panic recovery server middleware.
+  - Request ID server middleware to include a unique request ID on receiving
     a HTTP request.
-  * Tracing middleware for server and client.
-  * AWS X-Ray middleware for server and client that produce X-Ray segments.
+  - Tracing middleware for server and client.
+  - AWS X-Ray middleware for server and client that produce X-Ray segments.
 
 Example to use the server middleware:
 
-    var handler http.Handler = goahttp.NewMuxer()
-    handler = middleware.RequestID()(handler)
+	var handler http.Handler = goahttp.NewMuxer()
+	handler = middleware.RequestID()(handler)
+	handler = middleware.Compress()(handler)
 
 Example to use the client middleware:
 
-    var doer goahttp.Doer = &http.Client{}
-    doer = xray.WrapDoer(doer)
-
+	var doer goahttp.Doer = &http.Client{}
+	doer = xray.WrapDoer(doer)
+	doer = middleware.CompressDoer(doer)
 */
 package middleware
@@ -17,8 +17,22 @@ import (
 // originator of the request. The originator is computed by looking at the
 // X-Forwarded-For HTTP header or - absent of that - the originating IP. The
 // middleware also logs the response HTTP status code, body length (in bytes) and
-// timing information.
-func Log(l middleware.Logger) func(h http.Handler) http.Handler {
+// timing information. Use middleware.LogFieldsOption to log additional fields.
+//
+// If l implements middleware.LeveledLogger then responses with a 4xx status
+// code are logged with the warn level, responses with a 5xx status code with
+// the error level and all other entries with the info level. The middleware
+// also stores a logger that logs the request ID with each entry in the request
+// context, use middleware.ContextLogger to retrieve it.
+//
+// If a sampler is given via middleware.LogSamplerOption then the entries of
+// successful requests are only logged when sampled. Failed requests are always
+// logged.
+func Log(l middleware.Logger, options ...middleware.LogOption) func(h http.Handler) http.Handler {
+	var (
+		ll = middleware.AsLeveledLogger(l)
+		o  = middleware.NewLogOptions(options...)
+	)
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			reqID := r.Context().Value(middleware.RequestIDKey)
@@ -26,18 +40,60 @@ func Log(l middleware.Logger) func(h http.Handler) http.Handler {
 				reqID = shortID()
 			}
 			started := time.Now()
+			rl := ll.With("id", reqID)
+			sampled := o.Sample()
 
-			l.Log("id", reqID,
-				"req", r.Method+" "+r.URL.String(),
-				"from", from(r))
+			reqKeyvals := []interface{}{
+				"req", r.Method + " " + r.URL.String(),
+				"from", from(r),
+			}
+			if o.Logs(middleware.LogRequestSize) {
+				reqKeyvals = append(reqKeyvals, "req_bytes", r.ContentLength)
+			}
+			if o.Logs(middleware.LogUserAgent) {
+				reqKeyvals = append(reqKeyvals, "user_agent", r.UserAgent())
+			}
+			if sampled {
+				rl.Info(reqKeyvals...)
+			}
 
+			ctx := middleware.WithLogger(r.Context(), rl)
+			ri := middleware.ContextRequestInfo(ctx)
+			if ri == nil {
+				ctx, ri = middleware.WithRequestInfo(ctx)
+			}
 			rw := CaptureResponse(w)
-			h.ServeHTTP(rw, r)
+			h.ServeHTTP(rw, r.WithContext(ctx))
 
-			l.Log("id", reqID,
-				"status", rw.StatusCode,
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if !sampled && status < 400 {
+				return
+			}
+			var keyvals []interface{}
+			if !sampled {
+				keyvals = append(keyvals, reqKeyvals...)
+			}
+			keyvals = append(keyvals,
+				"status", status,
 				"bytes", rw.ContentLength,
 				"time", time.Since(started).String())
+			if o.Logs(middleware.LogRoute) && ri.Route != "" {
+				keyvals = append(keyvals, "route", ri.Route)
+			}
+			if o.Logs(middleware.LogErrorName) && ri.ErrorName != "" {
+				keyvals = append(keyvals, "error", ri.ErrorName)
+			}
+			switch {
+			case status >= 500:
+				rl.Error(keyvals...)
+			case status >= 400:
+				rl.Warn(keyvals...)
+			default:
+				rl.Info(keyvals...)
+			}
 		})
 	}
 }
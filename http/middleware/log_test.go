@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLog(t *testing.T) {
+	mux := goahttp.NewMuxer()
+	mux.Handle("GET", "/ok/{id}", func(w http.ResponseWriter, r *http.Request) {
+		middleware.ContextLogger(r.Context()).Info("msg", "handled")
+		w.Write([]byte("ok"))
+	})
+	mux.Handle("GET", "/error", func(w http.ResponseWriter, r *http.Request) {
+		enc := goahttp.ErrorEncoder(goahttp.ResponseEncoder, nil)
+		enc(r.Context(), w, goa.PermanentError("not_found", "not found"))
+	})
+
+	cases := []struct {
+		Name     string
+		Options  []middleware.LogOption
+		Path     string
+		Expected []string
+		Entries  int
+	}{
+		{"default", nil, "/ok/1", []string{
+			"id=abc req=GET /ok/1 from=127.0.0.1\n",
+			"id=abc msg=handled\n",
+			"id=abc status=200 bytes=2 time=",
+		}, 3},
+		{"fields", []middleware.LogOption{middleware.LogFieldsOption(middleware.LogRequestSize | middleware.LogUserAgent | middleware.LogRoute | middleware.LogErrorName)}, "/error", []string{
+			"id=abc req=GET /error from=127.0.0.1 req_bytes=0 user_agent=test\n",
+			"id=abc status=400",
+			"route=/error error=not_found\n",
+		}, 2},
+		{"sampled-out-success", []middleware.LogOption{middleware.LogSamplerOption(middleware.NewFixedSampler(0))}, "/ok/1", []string{
+			"id=abc msg=handled\n",
+		}, 1},
+		{"sampled-out-error", []middleware.LogOption{middleware.LogSamplerOption(middleware.NewFixedSampler(0))}, "/error", []string{
+			"id=abc req=GET /error from=127.0.0.1 status=400",
+		}, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var b bytes.Buffer
+			h := httpm.Log(middleware.NewLogger(log.New(&b, "", 0)), c.Options...)(mux)
+			r := httptest.NewRequest("GET", c.Path, nil)
+			r.RemoteAddr = "127.0.0.1:1234"
+			r.Header.Set("User-Agent", "test")
+			r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, "abc"))
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+			if len(lines) != strings.Count(b.String(), "\n") || len(lines) == 0 {
+				t.Fatalf("invalid log output %q", b.String())
+			}
+			for _, e := range c.Expected {
+				if !strings.Contains(b.String(), e) {
+					t.Errorf("log output %q does not contain %q", b.String(), e)
+				}
+			}
+			if len(lines) != c.Entries {
+				t.Errorf("got %d log entries, expected %d", len(lines), c.Entries)
+			}
+			if strings.Contains(b.String(), "level=") {
+				t.Errorf("log output %q contains the entry levels", b.String())
+			}
+		})
+	}
+}
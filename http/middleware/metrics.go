@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+)
+
+// Metrics returns a middleware that records the metrics of the HTTP requests
+// handled by the wrapped handler using the "http" subsystem. The metrics are
+// labeled with the service and method names as recorded by the generated code
+// and the name of the error returned by the method if any.
+func Metrics(m *middleware.Metrics) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			ctx := r.Context()
+			ri := middleware.ContextRequestInfo(ctx)
+			if ri == nil {
+				ctx, ri = middleware.WithRequestInfo(ctx)
+			}
+			completed := m.TrackRequest("http", ri)
+			rw := CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			completed(ri.ErrorName, time.Since(started), r.ContentLength, int64(rw.ContentLength))
+		})
+	}
+}
+
+// MetricsHandler returns a HTTP handler that renders the given metrics using
+// the Prometheus text exposition format. The handler may be mounted on the
+// generated server muxer, for example:
+//
+//	mux.Handle("GET", "/metrics", middleware.MetricsHandler(metrics).ServeHTTP)
+func MetricsHandler(m *middleware.Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WriteText(w)
+	})
+}
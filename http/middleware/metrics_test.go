@@ -0,0 +1,40 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestMetrics(t *testing.T) {
+	m := middleware.NewMetrics("")
+	h := httpm.Metrics(m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goa.ServiceKey, "svc")
+		ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+		middleware.SetServiceMethod(ctx)
+		w.Write([]byte("ok"))
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	w := httptest.NewRecorder()
+	httpm.MetricsHandler(m).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("got content type %q, expected text/plain", ct)
+	}
+	expected := []string{
+		`http_requests_in_flight{service="svc",method="meth"} 0` + "\n",
+		`http_requests_total{service="svc",method="meth",error=""} 1` + "\n",
+		`http_response_size_bytes_sum{service="svc",method="meth",error=""} 2` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(w.Body.String(), e) {
+			t.Errorf("metrics:\n%s\ndo not contain:\n%s", w.Body.String(), e)
+		}
+	}
+}
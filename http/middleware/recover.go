@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+// Recover returns a middleware that recovers from panics raised by the
+// wrapped handler. The middleware logs the panic value and stack trace using
+// l and writes an internal server error response created by the goa error
+// encoder. The response includes the ID of the error which is also logged
+// so that both may be correlated. Nothing is written if the handler has
+// already started writing the response.
+//
+// Use the middleware.Recover endpoint middleware instead to have the error
+// rendered by the error encoders generated for the method.
+func Recover(l middleware.Logger) func(h http.Handler) http.Handler {
+	encodeError := goahttp.ErrorEncoder(goahttp.ResponseEncoder, nil)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := CaptureResponse(w)
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					// Let the server abort the response.
+					panic(rec)
+				}
+				err := middleware.PanicError(r.Context(), l, rec)
+				if rw.StatusCode != 0 || rw.ContentLength > 0 {
+					// The response has already been started.
+					return
+				}
+				encodeError(r.Context(), rw, err)
+			}()
+			h.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+)
+
+func TestRecover(t *testing.T) {
+	var b bytes.Buffer
+	h := httpm.Recover(middleware.NewLogger(log.New(&b, "", 0)))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(w.Body.String(), `"fault":true`) {
+		t.Errorf("got body %q, expected fault error", w.Body.String())
+	}
+	if !strings.Contains(b.String(), "panic=boom") {
+		t.Errorf("log %q does not contain panic value", b.String())
+	}
+}
+
+func TestRecoverStartedResponse(t *testing.T) {
+	cases := map[string]func(w http.ResponseWriter){
+		"write-header": func(w http.ResponseWriter) { w.WriteHeader(http.StatusAccepted) },
+		"write":        func(w http.ResponseWriter) { w.Write([]byte("partial")) },
+		"flush":        func(w http.ResponseWriter) { w.(http.Flusher).Flush() },
+	}
+	for k, start := range cases {
+		t.Run(k, func(t *testing.T) {
+			var b bytes.Buffer
+			h := httpm.Recover(middleware.NewLogger(log.New(&b, "", 0)))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				start(w)
+				panic("boom")
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			if w.Code == http.StatusInternalServerError {
+				t.Errorf("got status %d, expected the status written by the handler", w.Code)
+			}
+			if strings.Contains(w.Body.String(), "fault") {
+				t.Errorf("got body %q, expected no error response", w.Body.String())
+			}
+			if !strings.Contains(b.String(), "panic=boom") {
+				t.Errorf("log %q does not contain panic value", b.String())
+			}
+		})
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/dimfeld/httptreemux/v5"
+	"goa.design/goa/v3/middleware"
 )
 
 type (
@@ -50,31 +53,142 @@ type (
 		Vars(*http.Request) map[string]string
 	}
 
+	// RouteMuxer is the interface implemented by the muxers returned by
+	// NewMuxer and NewStdMuxer. It extends Muxer with route introspection,
+	// per-route middleware and custom error handlers.
+	//
+	// The routes and handlers must be configured before the muxer starts
+	// serving requests.
+	RouteMuxer interface {
+		Muxer
+
+		// Routes returns the registered routes in registration order.
+		Routes() []Route
+
+		// MatchedPattern returns the pattern of the route that matches
+		// the given request, an empty string if no route matches.
+		MatchedPattern(*http.Request) string
+
+		// UseRoute wraps the handler of the route registered for the
+		// given method and pattern with the given middleware. It
+		// returns an error if no such route is registered.
+		UseRoute(method, pattern string, m func(http.Handler) http.Handler) error
+
+		// SetNotFoundHandler sets the handler called when no route
+		// matches the request path. The default handler writes a goa
+		// error response with status code 404.
+		SetNotFoundHandler(http.HandlerFunc)
+
+		// SetMethodNotAllowedHandler sets the handler called when a
+		// route matches the request path but not the request method.
+		// The muxer sets the Allow response header before calling the
+		// handler. The default handler writes a goa error response
+		// with status code 405.
+		SetMethodNotAllowedHandler(http.HandlerFunc)
+	}
+
+	// Route describes a route registered on a muxer.
+	Route struct {
+		// Method is the route HTTP method.
+		Method string
+		// Pattern is the route pattern.
+		Pattern string
+	}
+
 	// mux is the default Muxer implementation. It leverages the
 	// httptreemux router and simply substitutes the syntax used to define
 	// wildcards from ":wildcard" and "*wildcard" to "{wildcard}" and
 	// "{*wildcard}" respectively.
 	mux struct {
 		*httptreemux.ContextMux
+		routes           *routeTable
+		notFound         http.HandlerFunc
+		methodNotAllowed http.HandlerFunc
+		preflights
 	}
+
+	// patternKey is the context key used by MatchedPattern to retrieve
+	// the pattern of the route selected by httptreemux.
+	patternKey struct{}
 )
 
 // NewMuxer returns a Muxer implementation based on the httptreemux router.
-func NewMuxer() Muxer {
+func NewMuxer() RouteMuxer {
 	r := httptreemux.NewContextMux()
 	r.EscapeAddedRoutes = true
+	m := &mux{
+		ContextMux:       r,
+		routes:           newRouteTable(),
+		notFound:         notFoundHandler,
+		methodNotAllowed: methodNotAllowedHandler,
+	}
 	r.NotFoundHandler = func(w http.ResponseWriter, req *http.Request) {
-		ctx := context.WithValue(req.Context(), AcceptTypeKey, req.Header.Get("Accept"))
-		enc := ResponseEncoder(ctx, w)
-		w.WriteHeader(http.StatusNotFound)
-		enc.Encode(NewErrorResponse(fmt.Errorf("404 page not found")))
+		m.notFound(w, req)
 	}
-	return &mux{r}
+	r.MethodNotAllowedHandler = func(w http.ResponseWriter, req *http.Request, methods map[string]httptreemux.HandlerFunc) {
+		allowed := make([]string, 0, len(methods))
+		for method := range methods {
+			allowed = append(allowed, method)
+		}
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		m.methodNotAllowed(w, req)
+	}
+	return m
 }
 
 // Handle maps the wildcard format used by goa to the one used by httptreemux.
+// It also records the pattern in the request information so that it may be
+// logged by the log middleware.
 func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
-	m.ContextMux.Handle(method, treemuxify(pattern), handler)
+	var rt *route
+	m.ContextMux.Handle(method, treemuxify(pattern), func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := r.Context().Value(patternKey{}).(*string); ok {
+			// Route lookup made by MatchedPattern.
+			*p = pattern
+			return
+		}
+		if ri := middleware.ContextRequestInfo(r.Context()); ri != nil {
+			ri.Route = pattern
+		}
+		rt.handler.ServeHTTP(w, r)
+	})
+	rt = m.routes.add(method, pattern, handler)
+}
+
+// Routes returns the registered routes.
+func (m *mux) Routes() []Route {
+	return m.routes.list()
+}
+
+// MatchedPattern returns the pattern of the route that httptreemux selects
+// for r. It looks up the route and calls the route handler with a context
+// that causes the handler to record its pattern instead of serving the
+// request.
+func (m *mux) MatchedPattern(r *http.Request) string {
+	lr, found := m.ContextMux.Lookup(nil, r)
+	if !found || lr.StatusCode != http.StatusOK {
+		return ""
+	}
+	var pattern string
+	m.ContextMux.ServeLookupResult(nil, r.WithContext(context.WithValue(r.Context(), patternKey{}, &pattern)), lr)
+	return pattern
+}
+
+// UseRoute wraps the handler of the given route with mw.
+func (m *mux) UseRoute(method, pattern string, mw func(http.Handler) http.Handler) error {
+	return m.routes.use(method, pattern, mw)
+}
+
+// SetNotFoundHandler sets the handler called when no route matches.
+func (m *mux) SetNotFoundHandler(h http.HandlerFunc) {
+	m.notFound = h
+}
+
+// SetMethodNotAllowedHandler sets the handler called when the request
+// method is not allowed.
+func (m *mux) SetMethodNotAllowedHandler(h http.HandlerFunc) {
+	m.methodNotAllowed = h
 }
 
 // Vars extracts the path variables from the request context.
@@ -85,6 +199,25 @@ func (m *mux) Vars(r *http.Request) map[string]string {
 var wildSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
 var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
 
+// notFoundHandler writes a goa error response with status code 404.
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	writeMuxError(w, r, http.StatusNotFound, fmt.Errorf("404 page not found"))
+}
+
+// methodNotAllowedHandler writes a goa error response with status code 405.
+func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeMuxError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("405 method not allowed"))
+}
+
+// writeMuxError writes a goa error response with the given status code and
+// error using the encoding negotiated with the request Accept header.
+func writeMuxError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	w.WriteHeader(status)
+	enc.Encode(NewErrorResponse(err))
+}
+
 func treemuxify(pattern string) string {
 	pattern = wildSeg.ReplaceAllString(pattern, "/:$1")
 	pattern = wildPath.ReplaceAllString(pattern, "/*$1")
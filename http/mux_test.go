@@ -1,6 +1,12 @@
 package http
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestMuxRegexp(t *testing.T) {
 	cases := []struct{ Name, Pattern, Expected string }{
@@ -24,3 +30,130 @@ func TestMuxRegexp(t *testing.T) {
 		}
 	}
 }
+
+func TestRouteMuxers(t *testing.T) {
+	muxers := map[string]func() RouteMuxer{
+		"treemux": NewMuxer,
+		"std":     NewStdMuxer,
+	}
+	cases := map[string]struct {
+		Method  string
+		Path    string
+		Status  int
+		Pattern string
+		Vars    map[string]string
+		Allow   string
+	}{
+		"static":           {"GET", "/items/new", http.StatusOK, "/items/new", map[string]string{}, ""},
+		"wildcard":         {"GET", "/items/42", http.StatusOK, "/items/{id}", map[string]string{"id": "42"}, ""},
+		"escaped-wildcard": {"GET", "/items/a%2Fb", http.StatusOK, "/items/{id}", map[string]string{"id": "a/b"}, ""},
+		"catch-all":        {"GET", "/files/a/b.txt", http.StatusOK, "/files/{*path}", map[string]string{"path": "a/b.txt"}, ""},
+		"backtrack":        {"GET", "/items/new/edit", http.StatusOK, "/items/{id}/edit", map[string]string{"id": "new"}, ""},
+		"head-uses-get":    {"HEAD", "/items/42", http.StatusOK, "/items/{id}", map[string]string{"id": "42"}, ""},
+		"not-found":        {"GET", "/unknown", http.StatusNotFound, "", nil, ""},
+		"not-allowed":      {"DELETE", "/items/42", http.StatusMethodNotAllowed, "", nil, "GET, HEAD, PUT"},
+	}
+	for name, newMux := range muxers {
+		t.Run(name, func(t *testing.T) {
+			mux := newMux()
+			var vars map[string]string
+			h := func(w http.ResponseWriter, r *http.Request) { vars = mux.Vars(r) }
+			mux.Handle("GET", "/items/new", h)
+			mux.Handle("GET", "/items/{id}", h)
+			mux.Handle("PUT", "/items/{id}", h)
+			mux.Handle("GET", "/files/{*path}", h)
+			mux.Handle("GET", "/items/{id}/edit", h)
+			for k, tc := range cases {
+				t.Run(k, func(t *testing.T) {
+					vars = nil
+					r := httptest.NewRequest(tc.Method, tc.Path, nil)
+					w := httptest.NewRecorder()
+
+					mux.ServeHTTP(w, r)
+
+					if w.Code != tc.Status {
+						t.Errorf("got status %d, expected %d", w.Code, tc.Status)
+					}
+					if p := mux.MatchedPattern(r); p != tc.Pattern {
+						t.Errorf("got pattern %q, expected %q", p, tc.Pattern)
+					}
+					if tc.Vars != nil && (len(vars) != 0 || len(tc.Vars) != 0) && !reflect.DeepEqual(vars, tc.Vars) {
+						t.Errorf("got vars %v, expected %v", vars, tc.Vars)
+					}
+					if a := w.Header().Get("Allow"); a != tc.Allow {
+						t.Errorf("got Allow %q, expected %q", a, tc.Allow)
+					}
+					if tc.Status >= 400 && !strings.Contains(w.Body.String(), `"fault":true`) {
+						t.Errorf("got body %q, expected a goa error response", w.Body.String())
+					}
+				})
+			}
+			expected := []Route{
+				{"GET", "/items/new"},
+				{"GET", "/items/{id}"},
+				{"PUT", "/items/{id}"},
+				{"GET", "/files/{*path}"},
+				{"GET", "/items/{id}/edit"},
+			}
+			if routes := mux.Routes(); !reflect.DeepEqual(routes, expected) {
+				t.Errorf("got routes %v, expected %v", routes, expected)
+			}
+		})
+	}
+}
+
+func TestRouteMuxersMiddleware(t *testing.T) {
+	muxers := map[string]func() RouteMuxer{
+		"treemux": NewMuxer,
+		"std":     NewStdMuxer,
+	}
+	for name, newMux := range muxers {
+		t.Run(name, func(t *testing.T) {
+			var (
+				mux   = newMux()
+				calls []string
+			)
+			mw := func(name string) func(http.Handler) http.Handler {
+				return func(h http.Handler) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						calls = append(calls, name)
+						h.ServeHTTP(w, r)
+					})
+				}
+			}
+			mux.Handle("GET", "/a", func(http.ResponseWriter, *http.Request) { calls = append(calls, "a") })
+			mux.Handle("GET", "/b", func(http.ResponseWriter, *http.Request) { calls = append(calls, "b") })
+			if err := mux.UseRoute("GET", "/a", mw("first")); err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if err := mux.UseRoute("GET", "/a", mw("second")); err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if err := mux.UseRoute("POST", "/a", mw("none")); err == nil {
+				t.Error("got nil error for unknown route, expected an error")
+			}
+			mux.SetNotFoundHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+			mux.SetMethodNotAllowedHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusConflict) })
+
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/a", nil))
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/b", nil))
+			notFound := httptest.NewRecorder()
+			mux.ServeHTTP(notFound, httptest.NewRequest("GET", "/c", nil))
+			notAllowed := httptest.NewRecorder()
+			mux.ServeHTTP(notAllowed, httptest.NewRequest("POST", "/a", nil))
+
+			if expected := []string{"second", "first", "a", "b"}; !reflect.DeepEqual(calls, expected) {
+				t.Errorf("got calls %v, expected %v", calls, expected)
+			}
+			if notFound.Code != http.StatusTeapot {
+				t.Errorf("got not found status %d, expected %d", notFound.Code, http.StatusTeapot)
+			}
+			if notAllowed.Code != http.StatusConflict {
+				t.Errorf("got method not allowed status %d, expected %d", notAllowed.Code, http.StatusConflict)
+			}
+			if a := notAllowed.Header().Get("Allow"); a != "GET, HEAD" {
+				t.Errorf("got Allow %q, expected %q", a, "GET, HEAD")
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// NextPageLink returns the value of the Link header that points to the next
+// page of results as defined in RFC 8288. The link is the path and query of u
+// with the query string parameter param set to value.
+func NextPageLink(u *url.URL, param, value string) string {
+	q := u.Query()
+	q.Set(param, value)
+	next := url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: q.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"next\"", next.String())
+}
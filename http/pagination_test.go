@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestNextPageLink(t *testing.T) {
+	cases := map[string]struct {
+		url      string
+		param    string
+		value    string
+		expected string
+	}{
+		"no query":      {"http://localhost/items", "cursor", "abc", `</items?cursor=abc>; rel="next"`},
+		"keep query":    {"/items?limit=10&filter=a", "cursor", "abc", `</items?cursor=abc&filter=a&limit=10>; rel="next"`},
+		"replace value": {"/items?page=2", "page", "3", `</items?page=3>; rel="next"`},
+		"escape value":  {"/items", "cursor", "a b&c", `</items?cursor=a+b%26c>; rel="next"`},
+	}
+	for k, tc := range cases {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatalf("%s: invalid URL: %s", k, err)
+		}
+		if actual := NextPageLink(u, tc.param, tc.value); actual != tc.expected {
+			t.Errorf("%s: got %s, expected %s", k, actual, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type (
+	// routeTable records the routes registered on a muxer and matches the
+	// requests against their patterns.
+	routeTable struct {
+		// routes lists the routes in registration order.
+		routes []*route
+		// patterns indexes the parsed patterns by pattern.
+		patterns map[string]*routePattern
+		// index indexes the routes by method and pattern.
+		index map[string]*route
+		// tree is the root of the tree of pattern segments used to match
+		// the requests.
+		tree *routeNode
+	}
+
+	// routeNode is a node of the tree of pattern segments. The path from
+	// the root to a node describes the leading segments of the patterns
+	// below it.
+	routeNode struct {
+		// static contains the child nodes of the static segments
+		// indexed by value.
+		static map[string]*routeNode
+		// wildcard is the child node of the "{name}" wildcard segments
+		// if any.
+		wildcard *routeNode
+		// catchAll is the pattern that ends with a "{*name}" wildcard
+		// segment at this position if any.
+		catchAll *routePattern
+		// pattern is the pattern that ends at this node if any.
+		pattern *routePattern
+	}
+
+	// route is a registered route.
+	route struct {
+		Route
+		// handler is the route handler wrapped with the route middleware.
+		handler http.Handler
+	}
+
+	// routePattern is a parsed pattern and the routes registered for it
+	// indexed by method.
+	routePattern struct {
+		pattern  string
+		segments []patternSegment
+		methods  map[string]*route
+	}
+
+	// patternSegment is a segment of a parsed pattern.
+	patternSegment struct {
+		// kind is the kind of segment, see the segment constants.
+		kind int
+		// value is the segment value for static segments, the wildcard
+		// name otherwise.
+		value string
+	}
+)
+
+const (
+	// catchAllSegment is a "{*name}" wildcard segment.
+	catchAllSegment = iota
+	// wildcardSegment is a "{name}" wildcard segment.
+	wildcardSegment
+	// staticSegment is a static segment.
+	staticSegment
+)
+
+// newRouteTable returns an empty route table.
+func newRouteTable() *routeTable {
+	return &routeTable{
+		patterns: make(map[string]*routePattern),
+		index:    make(map[string]*route),
+		tree:     &routeNode{},
+	}
+}
+
+// add records a route and returns it. add replaces the handler of the route
+// if it is already registered.
+func (t *routeTable) add(method, pattern string, h http.Handler) *route {
+	if r, ok := t.index[method+" "+pattern]; ok {
+		r.handler = h
+		return r
+	}
+	r := &route{Route: Route{Method: method, Pattern: pattern}, handler: h}
+	t.routes = append(t.routes, r)
+	t.index[method+" "+pattern] = r
+	p, ok := t.patterns[pattern]
+	if !ok {
+		p = &routePattern{pattern: pattern, segments: parsePattern(pattern), methods: make(map[string]*route)}
+		t.patterns[pattern] = p
+		t.tree.insert(p)
+	}
+	p.methods[method] = r
+	return r
+}
+
+// get returns the route registered for the given method and pattern, nil if
+// there is none.
+func (t *routeTable) get(method, pattern string) *route {
+	return t.index[method+" "+pattern]
+}
+
+// use wraps the handler of the route registered for the given method and
+// pattern with m.
+func (t *routeTable) use(method, pattern string, m func(http.Handler) http.Handler) error {
+	r := t.get(method, pattern)
+	if r == nil {
+		return fmt.Errorf("no route registered for %s %q", method, pattern)
+	}
+	r.handler = m(r.handler)
+	return nil
+}
+
+// list returns the registered routes in registration order.
+func (t *routeTable) list() []Route {
+	res := make([]Route, len(t.routes))
+	for i, r := range t.routes {
+		res[i] = r.Route
+	}
+	return res
+}
+
+// match returns the route whose pattern most closely matches the given
+// request and the values of the pattern wildcards. Static segments take
+// precedence over "{name}" wildcards which take precedence over "{*name}"
+// wildcards. If the path matches a pattern that is not registered for the
+// request method then match returns the pattern with a nil route. HEAD
+// requests use the GET routes if there is no HEAD route.
+func (t *routeTable) match(r *http.Request) (*routePattern, *route, map[string]string) {
+	segments := pathSegments(r)
+	p := t.tree.lookup(segments, 0)
+	if p == nil {
+		return nil, nil, nil
+	}
+	return p, p.route(r.Method), p.params(segments)
+}
+
+// matchedRoute returns the route that matches the given request, nil if there
+// is none. It does not compute the values of the pattern wildcards.
+func (t *routeTable) matchedRoute(r *http.Request) *route {
+	if p := t.tree.lookup(pathSegments(r), 0); p != nil {
+		return p.route(r.Method)
+	}
+	return nil
+}
+
+// insert adds the pattern to the tree rooted at n.
+func (n *routeNode) insert(p *routePattern) {
+	for _, s := range p.segments {
+		switch s.kind {
+		case catchAllSegment:
+			n.catchAll = p
+			return
+		case wildcardSegment:
+			if n.wildcard == nil {
+				n.wildcard = &routeNode{}
+			}
+			n = n.wildcard
+		default:
+			if n.static == nil {
+				n.static = make(map[string]*routeNode)
+			}
+			c, ok := n.static[s.value]
+			if !ok {
+				c = &routeNode{}
+				n.static[s.value] = c
+			}
+			n = c
+		}
+	}
+	n.pattern = p
+}
+
+// lookup returns the pattern that most closely matches the escaped path
+// segments starting at index i, nil if there is none. Static segments take
+// precedence over "{name}" wildcards which take precedence over "{*name}"
+// wildcards.
+func (n *routeNode) lookup(segments []string, i int) *routePattern {
+	if i == len(segments) {
+		if n.pattern != nil {
+			return n.pattern
+		}
+		return n.catchAll
+	}
+	if v, err := url.PathUnescape(segments[i]); err == nil {
+		if c, ok := n.static[v]; ok {
+			if p := c.lookup(segments, i+1); p != nil {
+				return p
+			}
+		}
+		if n.wildcard != nil && v != "" {
+			if p := n.wildcard.lookup(segments, i+1); p != nil {
+				return p
+			}
+		}
+	}
+	if n.catchAll != nil {
+		if _, err := url.PathUnescape(strings.Join(segments[i:], "/")); err == nil {
+			return n.catchAll
+		}
+	}
+	return nil
+}
+
+// pathSegments returns the escaped segments of the request path.
+func pathSegments(r *http.Request) []string {
+	return strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+}
+
+// route returns the route registered for the given method, nil if there is
+// none. HEAD requests use the GET route if there is no HEAD route.
+func (p *routePattern) route(method string) *route {
+	rt, ok := p.methods[method]
+	if !ok && method == "HEAD" {
+		rt = p.methods["GET"]
+	}
+	return rt
+}
+
+// allow returns the value of the Allow header for the pattern. HEAD is
+// allowed if GET is.
+func (p *routePattern) allow() string {
+	var methods []string
+	for _, r := range p.methods {
+		methods = append(methods, r.Method)
+	}
+	if _, ok := p.methods["GET"]; ok {
+		if _, ok := p.methods["HEAD"]; !ok {
+			methods = append(methods, "HEAD")
+		}
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+// params returns the unescaped values of the pattern wildcards given the
+// escaped segments of a path matched by the pattern.
+func (p *routePattern) params(segments []string) map[string]string {
+	params := make(map[string]string)
+	for i, s := range p.segments {
+		switch s.kind {
+		case catchAllSegment:
+			params[s.value], _ = url.PathUnescape(strings.Join(segments[i:], "/"))
+		case wildcardSegment:
+			params[s.value], _ = url.PathUnescape(segments[i])
+		}
+	}
+	return params
+}
+
+// parsePattern parses the given muxer pattern. The pattern may also use the
+// httptreemux ":name" and "*name" wildcard syntax, the generated file servers
+// use the latter.
+func parsePattern(pattern string) []patternSegment {
+	parts := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	segments := make([]patternSegment, len(parts))
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, "{*") && strings.HasSuffix(part, "}"):
+			segments[i] = patternSegment{kind: catchAllSegment, value: part[2 : len(part)-1]}
+		case strings.HasPrefix(part, "*"):
+			segments[i] = patternSegment{kind: catchAllSegment, value: part[1:]}
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			segments[i] = patternSegment{kind: wildcardSegment, value: part[1 : len(part)-1]}
+		case strings.HasPrefix(part, ":"):
+			segments[i] = patternSegment{kind: wildcardSegment, value: part[1:]}
+		default:
+			segments[i] = patternSegment{kind: staticSegment, value: part}
+		}
+	}
+	return segments
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"crypto/x509"
+	"net/http"
+)
+
+// PeerCertificate returns the client certificate verified by the TLS
+// connection of the given request, nil if the request was not made over TLS or
+// the client did not provide a verified certificate.
+func PeerCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
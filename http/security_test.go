@@ -0,0 +1,35 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPeerCertificate(t *testing.T) {
+	var (
+		leaf = &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}
+		ca   = &x509.Certificate{Subject: pkix.Name{CommonName: "ca"}}
+	)
+	cases := map[string]struct {
+		TLS      *tls.ConnectionState
+		Expected *x509.Certificate
+	}{
+		"no-tls":          {TLS: nil, Expected: nil},
+		"no-chain":        {TLS: &tls.ConnectionState{}, Expected: nil},
+		"empty-chain":     {TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}, Expected: nil},
+		"unverified-cert": {TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}, Expected: nil},
+		"verified":        {TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, ca}}}, Expected: leaf},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.TLS = tc.TLS
+			if cert := PeerCertificate(r); cert != tc.Expected {
+				t.Errorf("got certificate %v, expected %v", cert, tc.Expected)
+			}
+		})
+	}
+}
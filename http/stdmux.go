@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// stdMux is a Muxer implementation that only depends on the standard
+	// library.
+	stdMux struct {
+		routes           *routeTable
+		notFound         http.HandlerFunc
+		methodNotAllowed http.HandlerFunc
+		preflights
+	}
+
+	// varsKey is the context key used to store the path variables.
+	varsKey struct{}
+)
+
+// NewStdMuxer returns a Muxer implementation that only depends on the standard
+// library. The muxer supports the same patterns as the muxer returned by
+// NewMuxer: static segments take precedence over "{name}" wildcards which take
+// precedence over "{*name}" wildcards. Unlike the muxer returned by NewMuxer
+// it does not redirect requests whose path differs from a registered pattern
+// by a trailing slash.
+func NewStdMuxer() RouteMuxer {
+	return &stdMux{
+		routes:           newRouteTable(),
+		notFound:         notFoundHandler,
+		methodNotAllowed: methodNotAllowedHandler,
+	}
+}
+
+// Handle registers the handler for the given method and pattern. It panics if
+// a handler is already registered for the method and pattern.
+func (m *stdMux) Handle(method, pattern string, handler http.HandlerFunc) {
+	if m.routes.get(method, pattern) != nil {
+		panic(fmt.Sprintf("goa: handler already registered for %s %q", method, pattern))
+	}
+	m.routes.add(method, pattern, handler)
+}
+
+// ServeHTTP dispatches the request to the handler of the route that most
+// closely matches the request. It also records the route pattern in the
+// request information so that it may be logged by the log middleware.
+func (m *stdMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p, rt, vars := m.routes.match(r)
+	if p == nil {
+		m.notFound(w, r)
+		return
+	}
+	if rt == nil {
+		w.Header().Set("Allow", p.allow())
+		m.methodNotAllowed(w, r)
+		return
+	}
+	ctx := r.Context()
+	if ri := middleware.ContextRequestInfo(ctx); ri != nil {
+		ri.Route = rt.Pattern
+	}
+	rt.handler.ServeHTTP(w, r.WithContext(context.WithValue(ctx, varsKey{}, vars)))
+}
+
+// Vars returns the path variables captured for the given request.
+func (m *stdMux) Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(varsKey{}).(map[string]string)
+	return vars
+}
+
+// Routes returns the registered routes.
+func (m *stdMux) Routes() []Route {
+	return m.routes.list()
+}
+
+// MatchedPattern returns the pattern of the route matching r.
+func (m *stdMux) MatchedPattern(r *http.Request) string {
+	if rt := m.routes.matchedRoute(r); rt != nil {
+		return rt.Pattern
+	}
+	return ""
+}
+
+// UseRoute wraps the handler of the given route with mw.
+func (m *stdMux) UseRoute(method, pattern string, mw func(http.Handler) http.Handler) error {
+	return m.routes.use(method, pattern, mw)
+}
+
+// SetNotFoundHandler sets the handler called when no route matches.
+func (m *stdMux) SetNotFoundHandler(h http.HandlerFunc) {
+	m.notFound = h
+}
+
+// SetMethodNotAllowedHandler sets the handler called when the request
+// method is not allowed.
+func (m *stdMux) SetMethodNotAllowedHandler(h http.HandlerFunc) {
+	m.methodNotAllowed = h
+}
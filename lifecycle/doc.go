@@ -0,0 +1,12 @@
+/*
+Package lifecycle runs the HTTP and gRPC servers of a service. A Group starts
+any number of servers, reports whether all of them are ready to accept
+requests and shuts them down gracefully when the process receives a signal,
+when the context given to Run is canceled or when one of the servers fails.
+Servers are drained concurrently within a configurable deadline and hooks may
+be registered to run before and after the shutdown.
+
+The package also provides helpers that configure the read, write and idle
+timeouts of the HTTP and gRPC servers.
+*/
+package lifecycle
@@ -0,0 +1,226 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+type (
+	// Server is a server whose lifecycle is managed by a Group.
+	Server interface {
+		// Listen announces on the server network address.
+		Listen() (net.Listener, error)
+		// Serve accepts and handles incoming connections on the given
+		// listener. Serve blocks until the server stops and returns nil
+		// if the server was shut down.
+		Serve(net.Listener) error
+		// Shutdown stops the server from accepting new connections and
+		// waits for the in-flight requests to complete. Shutdown closes
+		// the remaining connections and returns the context error if
+		// the context is done before the requests complete.
+		Shutdown(context.Context) error
+	}
+
+	// Group runs a set of servers. The zero value is a group with no
+	// servers that uses the default shutdown timeout and signals.
+	Group struct {
+		// ShutdownTimeout is the maximum duration given to the servers
+		// to complete the in-flight requests once the shutdown starts.
+		// Defaults to DefaultShutdownTimeout.
+		ShutdownTimeout time.Duration
+		// Signals lists the signals that cause the servers to shut
+		// down. Defaults to SIGINT and SIGTERM.
+		Signals []os.Signal
+
+		mu      sync.Mutex
+		servers []Server
+		before  []func(context.Context)
+		after   []func(error)
+		// ready is 0 when the group is not running, -1 when it is
+		// starting or shutting down and 1 when it is serving.
+		ready int32
+	}
+
+	// SignalError is the error returned by Run when the servers shut down
+	// because the process received a signal.
+	SignalError struct {
+		// Signal is the signal received by the process.
+		Signal os.Signal
+	}
+)
+
+// DefaultShutdownTimeout is the shutdown timeout used by groups that do not
+// define one.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// ErrRunning is the error returned by Run when the group is already running.
+var ErrRunning = errors.New("lifecycle: group is already running")
+
+// Add adds the given servers to the group. Servers added once the group is
+// running are ignored.
+func (g *Group) Add(servers ...Server) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.servers = append(g.servers, servers...)
+}
+
+// BeforeShutdown registers a function that runs once the shutdown starts and
+// before the servers are shut down. The context given to the function is done
+// when the shutdown deadline expires. Functions run in the order they were
+// registered.
+func (g *Group) BeforeShutdown(fn func(context.Context)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.before = append(g.before, fn)
+}
+
+// AfterShutdown registers a function that runs once all the servers have shut
+// down. The function is given the first error returned by the servers
+// Shutdown method, nil if all the servers completed their in-flight requests.
+// Functions run in the order they were registered.
+func (g *Group) AfterShutdown(fn func(error)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.after = append(g.after, fn)
+}
+
+// Ready returns true if all the servers of the group are listening and the
+// shutdown has not started.
+func (g *Group) Ready() bool {
+	return atomic.LoadInt32(&g.ready) == 1
+}
+
+// ReadinessHandler returns a HTTP handler that writes a 200 OK response if
+// the group is ready and a 503 Service Unavailable response otherwise. The
+// handler is intended to be used as a readiness probe.
+func (g *Group) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !g.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// Run starts the servers of the group and blocks until they shut down. Run
+// first listens on the network addresses of all the servers and returns an
+// error if any of them fails. It then serves the requests until the context is
+// canceled, the process receives one of the group signals or one of the
+// servers fails. Run then shuts down all the servers concurrently and waits
+// for them to complete the in-flight requests until the shutdown timeout
+// expires.
+//
+// Run returns the reason of the shutdown: the context error, a SignalError or
+// the error returned by the server that failed.
+func (g *Group) Run(ctx context.Context) error {
+	g.mu.Lock()
+	if !atomic.CompareAndSwapInt32(&g.ready, 0, -1) {
+		g.mu.Unlock()
+		return ErrRunning
+	}
+	var (
+		servers = make([]Server, len(g.servers))
+		before  = make([]func(context.Context), len(g.before))
+		after   = make([]func(error), len(g.after))
+	)
+	copy(servers, g.servers)
+	copy(before, g.before)
+	copy(after, g.after)
+	g.mu.Unlock()
+	defer atomic.StoreInt32(&g.ready, 0)
+
+	sigs := g.Signals
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, sigs...)
+	defer signal.Stop(sigc)
+
+	listeners := make([]net.Listener, len(servers))
+	for i, s := range servers {
+		l, err := s.Listen()
+		if err != nil {
+			for _, l := range listeners[:i] {
+				l.Close()
+			}
+			return err
+		}
+		listeners[i] = l
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errc = make(chan error, len(servers))
+	)
+	for i, s := range servers {
+		wg.Add(1)
+		go func(s Server, l net.Listener) {
+			defer wg.Done()
+			if err := s.Serve(l); err != nil {
+				errc <- err
+			}
+		}(s, listeners[i])
+	}
+	atomic.StoreInt32(&g.ready, 1)
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		cause = ctx.Err()
+	case sig := <-sigc:
+		cause = &SignalError{Signal: sig}
+	case err := <-errc:
+		cause = err
+	}
+	atomic.StoreInt32(&g.ready, -1)
+
+	timeout := g.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	sctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, fn := range before {
+		fn(sctx)
+	}
+
+	serrs := make([]error, len(servers))
+	var swg sync.WaitGroup
+	for i, s := range servers {
+		swg.Add(1)
+		go func(i int, s Server) {
+			defer swg.Done()
+			serrs[i] = s.Shutdown(sctx)
+		}(i, s)
+	}
+	swg.Wait()
+	wg.Wait()
+
+	var serr error
+	for _, err := range serrs {
+		if err != nil {
+			serr = err
+			break
+		}
+	}
+	for _, fn := range after {
+		fn(serr)
+	}
+
+	return cause
+}
+
+// Error returns the name of the signal.
+func (e *SignalError) Error() string {
+	return e.Signal.String()
+}
@@ -0,0 +1,225 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type testServer struct {
+	listenErr   error
+	serveErr    error
+	stop        chan struct{}
+	shutdownCtx context.Context
+}
+
+func newTestServer() *testServer {
+	return &testServer{stop: make(chan struct{})}
+}
+
+func (s *testServer) Listen() (net.Listener, error) {
+	if s.listenErr != nil {
+		return nil, s.listenErr
+	}
+	return net.Listen("tcp", "127.0.0.1:0")
+}
+
+func (s *testServer) Serve(l net.Listener) error {
+	defer l.Close()
+	if s.serveErr != nil {
+		return s.serveErr
+	}
+	<-s.stop
+	return nil
+}
+
+func (s *testServer) Shutdown(ctx context.Context) error {
+	s.shutdownCtx = ctx
+	close(s.stop)
+	return nil
+}
+
+func TestGroupRun(t *testing.T) {
+	var (
+		errListen = errors.New("listen")
+		errServe  = errors.New("serve")
+	)
+	cases := map[string]struct {
+		Servers []*testServer
+		Cancel  bool
+		Error   error
+	}{
+		"canceled":     {Servers: []*testServer{newTestServer(), newTestServer()}, Cancel: true, Error: context.Canceled},
+		"serve-error":  {Servers: []*testServer{newTestServer(), {serveErr: errServe, stop: make(chan struct{})}}, Error: errServe},
+		"listen-error": {Servers: []*testServer{newTestServer(), {listenErr: errListen}}, Error: errListen},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var (
+				g     Group
+				calls []string
+			)
+			for _, s := range tc.Servers {
+				g.Add(s)
+			}
+			g.BeforeShutdown(func(context.Context) {
+				if g.Ready() {
+					t.Error("group is ready during shutdown")
+				}
+				calls = append(calls, "before")
+			})
+			g.AfterShutdown(func(err error) {
+				if err != nil {
+					t.Errorf("got shutdown error %v, expected nil", err)
+				}
+				calls = append(calls, "after")
+			})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.Cancel {
+				go func() {
+					for !g.Ready() {
+						time.Sleep(time.Millisecond)
+					}
+					cancel()
+				}()
+			}
+
+			err := g.Run(ctx)
+
+			if err != tc.Error {
+				t.Errorf("got error %v, expected %v", err, tc.Error)
+			}
+			if g.Ready() {
+				t.Error("group is ready after shutdown")
+			}
+			if tc.Error == errListen {
+				if len(calls) != 0 {
+					t.Errorf("got hook calls %v, expected none", calls)
+				}
+				return
+			}
+			if len(calls) != 2 || calls[0] != "before" || calls[1] != "after" {
+				t.Errorf("got hook calls %v, expected [before after]", calls)
+			}
+			for i, s := range tc.Servers {
+				if s.serveErr == nil && s.shutdownCtx == nil {
+					t.Errorf("server %d was not shut down", i)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupRunShutdownTimeout(t *testing.T) {
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+		srv     = NewHTTPServer("127.0.0.1:0", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}), Timeouts{})
+		g = Group{ShutdownTimeout: 10 * time.Millisecond}
+	)
+	defer close(release)
+	hs := &recordListener{Server: HTTP(srv), addr: make(chan string, 1)}
+	g.Add(hs)
+	var shutdownErr error
+	g.AfterShutdown(func(err error) { shutdownErr = err })
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		go http.Get("http://" + <-hs.addr)
+		<-started
+		cancel()
+	}()
+
+	if err := g.Run(ctx); err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+	if shutdownErr != context.DeadlineExceeded {
+		t.Errorf("got shutdown error %v, expected %v", shutdownErr, context.DeadlineExceeded)
+	}
+}
+
+func TestGroupRunning(t *testing.T) {
+	var (
+		g   Group
+		s   = newTestServer()
+		res = make(chan error)
+	)
+	g.Add(s)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { res <- g.Run(ctx) }()
+	for !g.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := g.Run(ctx); err != ErrRunning {
+		t.Errorf("got error %v, expected %v", err, ErrRunning)
+	}
+	cancel()
+	<-res
+}
+
+func TestGroupReadinessHandler(t *testing.T) {
+	var (
+		g   Group
+		res = make(chan error)
+	)
+	g.Add(newTestServer())
+	h := g.ReadinessHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d before run, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { res <- g.Run(ctx) }()
+	for !g.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d while running, expected %d", w.Code, http.StatusOK)
+	}
+	cancel()
+	<-res
+}
+
+func TestGRPC(t *testing.T) {
+	var (
+		g   Group
+		res = make(chan error)
+	)
+	g.Add(GRPC(grpc.NewServer(GRPCServerOptions(Timeouts{Read: time.Second, Idle: time.Second})...), "127.0.0.1:0"))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { res <- g.Run(ctx) }()
+	for !g.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-res; err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+}
+
+// recordListener sends the address of the listener to addr.
+type recordListener struct {
+	Server
+	addr chan string
+}
+
+func (r *recordListener) Listen() (net.Listener, error) {
+	l, err := r.Server.Listen()
+	if err == nil {
+		r.addr <- l.Addr().String()
+	}
+	return l, err
+}
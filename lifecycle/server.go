@@ -0,0 +1,154 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+type (
+	// Timeouts configures the timeouts of a server. A zero value means no
+	// timeout.
+	Timeouts struct {
+		// Read is the maximum duration for reading a request including
+		// its body. The gRPC servers use it as the timeout of the
+		// connection handshake.
+		Read time.Duration
+		// Write is the maximum duration before timing out the writes of
+		// a response. The gRPC servers ignore it.
+		Write time.Duration
+		// Idle is the maximum duration a connection remains open
+		// without any request in flight.
+		Idle time.Duration
+	}
+
+	// httpServer is a Server that serves HTTP requests.
+	httpServer struct {
+		srv      *http.Server
+		tls      bool
+		certFile string
+		keyFile  string
+	}
+
+	// grpcServer is a Server that serves gRPC requests.
+	grpcServer struct {
+		srv  *grpc.Server
+		addr string
+	}
+)
+
+// NewHTTPServer returns a HTTP server listening on addr that uses the given
+// handler and timeouts.
+func NewHTTPServer(addr string, h http.Handler, t Timeouts) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      h,
+		ReadTimeout:  t.Read,
+		WriteTimeout: t.Write,
+		IdleTimeout:  t.Idle,
+	}
+}
+
+// GRPCServerOptions returns the gRPC server options that configure the given
+// timeouts.
+func GRPCServerOptions(t Timeouts) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if t.Read > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(t.Read))
+	}
+	if t.Idle > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{MaxConnectionIdle: t.Idle}))
+	}
+	return opts
+}
+
+// HTTP returns a Server that serves the requests of the given HTTP server on
+// the server address.
+func HTTP(srv *http.Server) Server {
+	return &httpServer{srv: srv}
+}
+
+// HTTPS returns a Server that serves the requests of the given HTTP server on
+// the server address using TLS. certFile and keyFile are the paths to the
+// server certificate and private key. Both may be empty if the server TLS
+// configuration defines the certificates.
+func HTTPS(srv *http.Server, certFile, keyFile string) Server {
+	return &httpServer{srv: srv, tls: true, certFile: certFile, keyFile: keyFile}
+}
+
+// GRPC returns a Server that serves the requests of the given gRPC server on
+// the given TCP network address.
+func GRPC(srv *grpc.Server, addr string) Server {
+	return &grpcServer{srv: srv, addr: addr}
+}
+
+// Listen announces on the server address.
+func (s *httpServer) Listen() (net.Listener, error) {
+	addr := s.srv.Addr
+	if addr == "" {
+		addr = ":http"
+		if s.tls {
+			addr = ":https"
+		}
+	}
+	return net.Listen("tcp", addr)
+}
+
+// Serve serves the HTTP requests received on l.
+func (s *httpServer) Serve(l net.Listener) error {
+	var err error
+	if s.tls {
+		err = s.srv.ServeTLS(l, s.certFile, s.keyFile)
+	} else {
+		err = s.srv.Serve(l)
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown shuts down the server gracefully and closes the remaining
+// connections if ctx is done first.
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.srv.Close()
+		return err
+	}
+	return nil
+}
+
+// Listen announces on the server address.
+func (s *grpcServer) Listen() (net.Listener, error) {
+	return net.Listen("tcp", s.addr)
+}
+
+// Serve serves the gRPC requests received on l.
+func (s *grpcServer) Serve(l net.Listener) error {
+	if err := s.srv.Serve(l); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Shutdown shuts down the server gracefully and closes the remaining
+// connections if ctx is done first.
+func (s *grpcServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
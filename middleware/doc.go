@@ -6,5 +6,10 @@ apply additional transformations prior to and after calling the original. The
 middlewares included in this package include a logger middleware to log incoming
 requests, a request ID middleware that makes sure every request as a unique ID
 stored in the context and a couple of middlewares used to implement tracing.
+
+The package also defines the Logger and LeveledLogger interfaces used by the
+transport specific logging middlewares together with adapters that write
+entries using the stdlib logger, JSON lines or logfmt, and the Metrics type
+used by the metrics middlewares to record and render request metrics.
 */
 package middleware
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -16,18 +17,274 @@ type (
 		Log(keyvals ...interface{}) error
 	}
 
+	// LeveledLogger is a structured logger that associates a severity
+	// level with each log entry. The middleware uses the leveled methods
+	// when the logger given to them implements this interface.
+	LeveledLogger interface {
+		Logger
+		// Debug creates a log entry with the debug level.
+		Debug(keyvals ...interface{}) error
+		// Info creates a log entry with the info level.
+		Info(keyvals ...interface{}) error
+		// Warn creates a log entry with the warn level.
+		Warn(keyvals ...interface{}) error
+		// Error creates a log entry with the error level.
+		Error(keyvals ...interface{}) error
+		// With returns a logger that adds the given sequence of
+		// alternating keys and values to all the entries it creates.
+		With(keyvals ...interface{}) LeveledLogger
+	}
+
+	// Level is the severity of a log entry.
+	Level int
+
+	// LogField identifies an optional field logged by the log middleware.
+	// Fields may be combined using the bitwise OR operator.
+	LogField int
+
+	// LogOption uses a constructor pattern to customize the log middleware.
+	LogOption func(*LogOptions) *LogOptions
+
+	// LogOptions is the struct storing all the options for the log
+	// middleware.
+	LogOptions struct {
+		// fields is the set of optional fields being logged.
+		fields LogField
+		// sampler if not nil is used to decide whether successful
+		// requests get logged.
+		sampler Sampler
+	}
+
+	// leveledLogger implements LeveledLogger on top of a function that
+	// writes a single log entry.
+	leveledLogger struct {
+		write   func(keyvals ...interface{}) error
+		keyvals []interface{}
+		// noLevel causes the entry levels to be omitted.
+		noLevel bool
+	}
+
+	// levelFilter is a leveled logger that discards the entries whose
+	// level is lower than a minimum level.
+	levelFilter struct {
+		LeveledLogger
+		min Level
+	}
+
 	// adapter is a thin wrapper around the stdlib logger that adapts it to
 	// the Logger interface.
 	adapter struct {
 		*log.Logger
 	}
+
+	// private type used to define the log context keys
+	logCtxKey int
 )
 
-// NewLogger creates a Logger backed by a stdlib logger.
+const (
+	// DebugLevel is the level of verbose entries useful for debugging.
+	DebugLevel Level = iota + 1
+	// InfoLevel is the level of informational entries.
+	InfoLevel
+	// WarnLevel is the level of entries that describe abnormal
+	// conditions which do not prevent the service from functioning.
+	WarnLevel
+	// ErrorLevel is the level of entries that describe failures.
+	ErrorLevel
+)
+
+// The length of the response body and of unary gRPC messages is always logged.
+const (
+	// LogRequestSize causes the length of the HTTP request body or the
+	// total length of the messages received by a gRPC stream to be logged.
+	LogRequestSize LogField = 1 << iota
+	// LogUserAgent causes the request user agent to be logged.
+	LogUserAgent
+	// LogRoute causes the pattern of the HTTP route that matched the
+	// request or the names of the gRPC service and method as defined in
+	// the design to be logged.
+	LogRoute
+	// LogErrorName causes the name of the error returned by the method to
+	// be logged.
+	LogErrorName
+)
+
+const (
+	// loggerKey is the context key used to store the request logger.
+	loggerKey logCtxKey = iota + 1
+)
+
+// NewLogger creates a Logger backed by a stdlib logger. The logger returned
+// by NewLogger implements LeveledLogger but does not log the entry levels so
+// that its output is the same as the one of a plain Logger. Use
+// NewLogfmtLogger to log the levels.
 func NewLogger(l *log.Logger) Logger {
 	return &adapter{l}
 }
 
+// AsLeveledLogger returns l if it implements LeveledLogger or a leveled logger
+// that logs the level of each entry using the "level" key otherwise.
+func AsLeveledLogger(l Logger) LeveledLogger {
+	if ll, ok := l.(LeveledLogger); ok {
+		return ll
+	}
+	return &leveledLogger{write: l.Log}
+}
+
+// LevelFilter returns a leveled logger that discards the entries whose level
+// is lower than min.
+func LevelFilter(l LeveledLogger, min Level) LeveledLogger {
+	return &levelFilter{LeveledLogger: l, min: min}
+}
+
+// WithLogger returns a context containing the given logger. The log
+// middleware uses WithLogger to make a logger which includes the request ID
+// available to the request handlers.
+func WithLogger(ctx context.Context, l LeveledLogger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// ContextLogger returns the logger stored in the given context by
+// WithLogger. It returns a logger which discards all entries if there is
+// none.
+func ContextLogger(ctx context.Context) LeveledLogger {
+	if l, ok := ctx.Value(loggerKey).(LeveledLogger); ok {
+		return l
+	}
+	return &leveledLogger{write: func(...interface{}) error { return nil }}
+}
+
+// NewLogOptions initializes the options for the log middleware.
+func NewLogOptions(options ...LogOption) *LogOptions {
+	o := new(LogOptions)
+	for _, option := range options {
+		o = option(o)
+	}
+	return o
+}
+
+// LogFieldsOption enables logging the given fields in addition to the fields
+// always logged by the middleware.
+func LogFieldsOption(fields LogField) LogOption {
+	return func(o *LogOptions) *LogOptions {
+		o.fields |= fields
+		return o
+	}
+}
+
+// LogSamplerOption sets the sampler used to decide whether successful
+// requests are logged. Failed requests are always logged.
+func LogSamplerOption(s Sampler) LogOption {
+	return func(o *LogOptions) *LogOptions {
+		o.sampler = s
+		return o
+	}
+}
+
+// Logs returns true if the given field is logged.
+func (o *LogOptions) Logs(f LogField) bool {
+	return o.fields&f == f
+}
+
+// Sample returns true if a successful request should be logged.
+func (o *LogOptions) Sample() bool {
+	return o.sampler == nil || o.sampler.Sample()
+}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// MarshalText returns the name of the level.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *leveledLogger) Log(keyvals ...interface{}) error {
+	return l.write(l.entry(nil, keyvals)...)
+}
+
+func (l *leveledLogger) Debug(keyvals ...interface{}) error {
+	return l.write(l.entry(l.level(DebugLevel), keyvals)...)
+}
+
+func (l *leveledLogger) Info(keyvals ...interface{}) error {
+	return l.write(l.entry(l.level(InfoLevel), keyvals)...)
+}
+
+func (l *leveledLogger) Warn(keyvals ...interface{}) error {
+	return l.write(l.entry(l.level(WarnLevel), keyvals)...)
+}
+
+func (l *leveledLogger) Error(keyvals ...interface{}) error {
+	return l.write(l.entry(l.level(ErrorLevel), keyvals)...)
+}
+
+func (l *leveledLogger) With(keyvals ...interface{}) LeveledLogger {
+	return &leveledLogger{write: l.write, keyvals: l.entry(nil, keyvals), noLevel: l.noLevel}
+}
+
+// level returns the key/value pair that logs the given level, nil if the
+// logger omits the levels.
+func (l *leveledLogger) level(lvl Level) []interface{} {
+	if l.noLevel {
+		return nil
+	}
+	return []interface{}{"level", lvl}
+}
+
+// entry returns the key/value pairs of a log entry made of prefix, the
+// logger key/value pairs and keyvals in this order.
+func (l *leveledLogger) entry(prefix, keyvals []interface{}) []interface{} {
+	kv := make([]interface{}, 0, len(prefix)+len(l.keyvals)+len(keyvals))
+	kv = append(kv, prefix...)
+	kv = append(kv, l.keyvals...)
+	return append(kv, keyvals...)
+}
+
+func (f *levelFilter) Debug(keyvals ...interface{}) error {
+	if f.min > DebugLevel {
+		return nil
+	}
+	return f.LeveledLogger.Debug(keyvals...)
+}
+
+func (f *levelFilter) Info(keyvals ...interface{}) error {
+	if f.min > InfoLevel {
+		return nil
+	}
+	return f.LeveledLogger.Info(keyvals...)
+}
+
+func (f *levelFilter) Warn(keyvals ...interface{}) error {
+	if f.min > WarnLevel {
+		return nil
+	}
+	return f.LeveledLogger.Warn(keyvals...)
+}
+
+func (f *levelFilter) Error(keyvals ...interface{}) error {
+	if f.min > ErrorLevel {
+		return nil
+	}
+	return f.LeveledLogger.Error(keyvals...)
+}
+
+func (f *levelFilter) With(keyvals ...interface{}) LeveledLogger {
+	return &levelFilter{LeveledLogger: f.LeveledLogger.With(keyvals...), min: f.min}
+}
+
 func (a *adapter) Log(keyvals ...interface{}) error {
 	n := (len(keyvals) + 1) / 2
 	if len(keyvals)%2 != 0 {
@@ -44,3 +301,29 @@ func (a *adapter) Log(keyvals ...interface{}) error {
 	a.Logger.Printf(strings.TrimSpace(fm.String()), vals...)
 	return nil
 }
+
+func (a *adapter) Debug(keyvals ...interface{}) error {
+	return a.leveled().Debug(keyvals...)
+}
+
+func (a *adapter) Info(keyvals ...interface{}) error {
+	return a.leveled().Info(keyvals...)
+}
+
+func (a *adapter) Warn(keyvals ...interface{}) error {
+	return a.leveled().Warn(keyvals...)
+}
+
+func (a *adapter) Error(keyvals ...interface{}) error {
+	return a.leveled().Error(keyvals...)
+}
+
+func (a *adapter) With(keyvals ...interface{}) LeveledLogger {
+	return a.leveled().With(keyvals...)
+}
+
+// leveled returns a leveled logger that writes to the stdlib logger without
+// the entry levels.
+func (a *adapter) leveled() *leveledLogger {
+	return &leveledLogger{write: a.Log, noLevel: true}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestLeveledLoggers(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	cases := []struct {
+		Name     string
+		Logger   func(*bytes.Buffer) LeveledLogger
+		Expected string
+	}{
+		{"stdlib", func(b *bytes.Buffer) LeveledLogger { return NewLogger(log.New(b, "", 0)).(LeveledLogger) },
+			"id=123 msg=debug\nid=123 msg=hello world err=boom\nid=123 status=500\n"},
+		{"json", func(b *bytes.Buffer) LeveledLogger { return NewJSONLogger(b) },
+			`{"time":"2020-01-02T03:04:05Z","level":"debug","id":"123","msg":"debug"}` + "\n" +
+				`{"time":"2020-01-02T03:04:05Z","level":"info","id":"123","msg":"hello world","err":"boom"}` + "\n" +
+				`{"time":"2020-01-02T03:04:05Z","level":"error","id":"123","status":500}` + "\n"},
+		{"logfmt", func(b *bytes.Buffer) LeveledLogger { return NewLogfmtLogger(b) },
+			`time=2020-01-02T03:04:05Z level=debug id=123 msg=debug` + "\n" +
+				`time=2020-01-02T03:04:05Z level=info id=123 msg="hello world" err=boom` + "\n" +
+				`time=2020-01-02T03:04:05Z level=error id=123 status=500` + "\n"},
+		{"filter", func(b *bytes.Buffer) LeveledLogger { return LevelFilter(NewLogfmtLogger(b), ErrorLevel) },
+			`time=2020-01-02T03:04:05Z level=error id=123 status=500` + "\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var b bytes.Buffer
+			l := c.Logger(&b).With("id", "123")
+			l.Debug("msg", "debug")
+			l.Info("msg", "hello world", "err", errors.New("boom"))
+			l.Error("status", 500)
+			if b.String() != c.Expected {
+				t.Errorf("got:\n%s\nexpected:\n%s", b.String(), c.Expected)
+			}
+		})
+	}
+}
+
+func TestAsLeveledLogger(t *testing.T) {
+	var b bytes.Buffer
+	l := AsLeveledLogger(WrapLogger(NewLogger(log.New(&b, "", 0)), ""))
+	l.Warn("msg", "warning")
+	if b.String() != "level=warn msg=warning\n" {
+		t.Errorf("got %q, expected %q", b.String(), "level=warn msg=warning\n")
+	}
+}
+
+func TestContextLogger(t *testing.T) {
+	if ContextLogger(context.Background()) == nil {
+		t.Fatal("got nil logger, expected not nil")
+	}
+	var b bytes.Buffer
+	ctx := WithLogger(context.Background(), NewLogfmtLogger(&b).With("id", "123"))
+	ContextLogger(ctx).Info()
+	if b.String() == "" {
+		t.Error("got empty log, expected entry")
+	}
+}
+
+func TestLogOptions(t *testing.T) {
+	o := NewLogOptions(LogFieldsOption(LogRoute|LogUserAgent), LogSamplerOption(NewFixedSampler(0)))
+	if !o.Logs(LogRoute) || !o.Logs(LogUserAgent) {
+		t.Error("expected route and user agent to be logged")
+	}
+	if o.Logs(LogErrorName) {
+		t.Error("expected error name not to be logged")
+	}
+	if o.Sample() {
+		t.Error("expected sample to be false")
+	}
+	if !NewLogOptions().Sample() {
+		t.Error("expected sample to be true by default")
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// formatLogger writes log entries formatted by a format function to
+	// a writer. Writes are serialized so that the logger may be used
+	// concurrently.
+	formatLogger struct {
+		mu     sync.Mutex
+		w      io.Writer
+		format func(b *bytes.Buffer, keyvals []interface{})
+	}
+)
+
+// now returns the current time, overridden by tests.
+var now = time.Now
+
+// NewJSONLogger creates a leveled logger that writes each log entry to w as
+// a single line JSON object. Each entry includes the "time" key.
+func NewJSONLogger(w io.Writer) LeveledLogger {
+	l := &formatLogger{w: w, format: formatJSON}
+	return &leveledLogger{write: l.write}
+}
+
+// NewLogfmtLogger creates a leveled logger that writes each log entry to w as
+// a single line of logfmt formatted key/value pairs. Each entry includes the
+// "time" key.
+func NewLogfmtLogger(w io.Writer) LeveledLogger {
+	l := &formatLogger{w: w, format: formatLogfmt}
+	return &leveledLogger{write: l.write}
+}
+
+// write formats and writes a single log entry.
+func (l *formatLogger) write(keyvals ...interface{}) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "MISSING")
+	}
+	keyvals = append([]interface{}{"time", now().UTC().Format(time.RFC3339Nano)}, keyvals...)
+	var b bytes.Buffer
+	l.format(&b, keyvals)
+	b.WriteByte('\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := l.w.Write(b.Bytes())
+	return err
+}
+
+// formatJSON writes the given key/value pairs as a JSON object.
+func formatJSON(b *bytes.Buffer, keyvals []interface{}) {
+	b.WriteByte('{')
+	for i := 0; i < len(keyvals); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(fmt.Sprint(keyvals[i]))
+		b.Write(k)
+		b.WriteByte(':')
+		v := keyvals[i+1]
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		js, err := json.Marshal(v)
+		if err != nil {
+			js, _ = json.Marshal(fmt.Sprintf("%+v", v))
+		}
+		b.Write(js)
+	}
+	b.WriteByte('}')
+}
+
+// formatLogfmt writes the given key/value pairs using the logfmt format.
+func formatLogfmt(b *bytes.Buffer, keyvals []interface{}) {
+	for i := 0; i < len(keyvals); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(logfmtValue(fmt.Sprint(keyvals[i])))
+		b.WriteByte('=')
+		var v string
+		switch actual := keyvals[i+1].(type) {
+		case error:
+			v = actual.Error()
+		case encoding.TextMarshaler:
+			t, err := actual.MarshalText()
+			if err != nil {
+				v = err.Error()
+			} else {
+				v = string(t)
+			}
+		default:
+			v = fmt.Sprintf("%+v", actual)
+		}
+		b.WriteString(logfmtValue(v))
+	}
+}
+
+// logfmtValue quotes v if it contains spaces, quotes, equal signs or control
+// characters.
+func logfmtValue(v string) string {
+	if v == "" {
+		return `""`
+	}
+	if strings.IndexFunc(v, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == 0x7f
+	}) == -1 {
+		return v
+	}
+	return strconv.Quote(v)
+}
@@ -0,0 +1,301 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Metrics records the number, latency and payload sizes of the requests
+	// handled by a service as well as the number of requests being handled
+	// and renders them using the Prometheus text exposition format. The
+	// metrics are labeled with the service and method names as defined in
+	// the design and, except for the number of requests being handled, the
+	// name of the error returned by the method if any. Metrics is safe for
+	// concurrent use.
+	Metrics struct {
+		namespace string
+		mu        sync.Mutex
+		inFlight  map[metricLabels]int64
+		requests  map[metricLabels]*requestMetrics
+	}
+
+	// metricLabels identifies a time series.
+	metricLabels struct {
+		subsystem string
+		service   string
+		method    string
+		errName   string
+	}
+
+	// requestMetrics holds the metrics of a single time series.
+	requestMetrics struct {
+		count    uint64
+		duration *histogram
+		reqSize  *histogram
+		respSize *histogram
+	}
+
+	// histogram counts observations in cumulative buckets.
+	histogram struct {
+		buckets []float64
+		counts  []uint64
+		count   uint64
+		sum     float64
+	}
+)
+
+var (
+	// DurationBuckets lists the upper bounds in seconds of the buckets of
+	// the request duration histograms.
+	DurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	// SizeBuckets lists the upper bounds in bytes of the buckets of the
+	// request and response size histograms.
+	SizeBuckets = []float64{100, 1000, 10000, 100000, 1000000, 10000000}
+)
+
+// NewMetrics returns a Metrics value that prefixes the names of all the
+// metrics it renders with the given namespace if not empty.
+func NewMetrics(namespace string) *Metrics {
+	return &Metrics{
+		namespace: namespace,
+		inFlight:  make(map[metricLabels]int64),
+		requests:  make(map[metricLabels]*requestMetrics),
+	}
+}
+
+// EndpointMetrics returns a goa endpoint middleware that records the metrics
+// of the requests handled by the endpoint using the "endpoint" subsystem. The
+// service and method names are read from the request context.
+func EndpointMetrics(m *Metrics) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			started := time.Now()
+			svc, _ := ctx.Value(goa.ServiceKey).(string)
+			meth, _ := ctx.Value(goa.MethodKey).(string)
+			m.RequestStarted("endpoint", svc, meth)
+			res, err := e(ctx, req)
+			m.RequestCompleted("endpoint", svc, meth, ErrorName(err), time.Since(started), -1, -1)
+			return res, err
+		}
+	}
+}
+
+// RequestStarted increments the number of in-flight requests of the given
+// subsystem (e.g. "http" or "grpc"), service and method.
+func (m *Metrics) RequestStarted(subsystem, service, method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[metricLabels{subsystem: subsystem, service: service, method: method}]++
+}
+
+// RequestCompleted decrements the number of in-flight requests of the given
+// subsystem, service and method and records the completion of a request.
+// reqSize and respSize are ignored if negative.
+func (m *Metrics) RequestCompleted(subsystem, service, method, errName string, d time.Duration, reqSize, respSize int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[metricLabels{subsystem: subsystem, service: service, method: method}]--
+	labels := metricLabels{subsystem, service, method, errName}
+	rm, ok := m.requests[labels]
+	if !ok {
+		rm = &requestMetrics{
+			duration: newHistogram(DurationBuckets),
+			reqSize:  newHistogram(SizeBuckets),
+			respSize: newHistogram(SizeBuckets),
+		}
+		m.requests[labels] = rm
+	}
+	rm.count++
+	rm.duration.observe(d.Seconds())
+	if reqSize >= 0 {
+		rm.reqSize.observe(float64(reqSize))
+	}
+	if respSize >= 0 {
+		rm.respSize.observe(float64(respSize))
+	}
+}
+
+// TrackRequest counts the transport request described by ri as in flight
+// once the generated code records its service and method names in ri. The
+// transport middlewares use it as the names are not known when the request
+// starts. The returned function records the completion of the request and must
+// be called once the request completes.
+func (m *Metrics) TrackRequest(subsystem string, ri *RequestInfo) func(errName string, d time.Duration, reqSize, respSize int64) {
+	started := false
+	ri.OnServiceMethod(func(ri *RequestInfo) {
+		m.RequestStarted(subsystem, ri.Service, ri.Method)
+		started = true
+	})
+	return func(errName string, d time.Duration, reqSize, respSize int64) {
+		if !started {
+			m.RequestStarted(subsystem, ri.Service, ri.Method)
+		}
+		m.RequestCompleted(subsystem, ri.Service, ri.Method, errName, d, reqSize, respSize)
+	}
+}
+
+// WriteText writes the metrics to w using the Prometheus text exposition
+// format.
+func (m *Metrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var (
+		inFlight   = make(map[string][]metricLabels)
+		subsystems = make(map[string][]metricLabels)
+	)
+	for l := range m.inFlight {
+		inFlight[l.subsystem] = append(inFlight[l.subsystem], l)
+		if _, ok := subsystems[l.subsystem]; !ok {
+			subsystems[l.subsystem] = nil
+		}
+	}
+	for l := range m.requests {
+		subsystems[l.subsystem] = append(subsystems[l.subsystem], l)
+	}
+	names := make([]string, 0, len(subsystems))
+	for s, ls := range subsystems {
+		names = append(names, s)
+		sortLabels(ls)
+		sortLabels(inFlight[s])
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, s := range names {
+		ls := subsystems[s]
+		prefix := m.metricName(s)
+
+		name := prefix + "_requests_in_flight"
+		fmt.Fprintf(bw, "# HELP %s Number of requests being handled.\n", name)
+		fmt.Fprintf(bw, "# TYPE %s gauge\n", name)
+		for _, l := range inFlight[s] {
+			fmt.Fprintf(bw, "%s{%s} %d\n", name, l.methodString(), m.inFlight[l])
+		}
+		if len(ls) == 0 {
+			continue
+		}
+
+		name = prefix + "_requests_total"
+		fmt.Fprintf(bw, "# HELP %s Number of handled requests.\n", name)
+		fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+		for _, l := range ls {
+			fmt.Fprintf(bw, "%s{%s} %d\n", name, l.String(), m.requests[l].count)
+		}
+
+		writeHistograms(bw, prefix+"_request_duration_seconds", "Duration of requests in seconds.",
+			ls, func(l metricLabels) *histogram { return m.requests[l].duration })
+		writeHistograms(bw, prefix+"_request_size_bytes", "Size of request messages in bytes.",
+			ls, func(l metricLabels) *histogram { return m.requests[l].reqSize })
+		writeHistograms(bw, prefix+"_response_size_bytes", "Size of response messages in bytes.",
+			ls, func(l metricLabels) *histogram { return m.requests[l].respSize })
+	}
+	return bw.Flush()
+}
+
+// metricName returns the prefix of the names of the metrics of the given
+// subsystem.
+func (m *Metrics) metricName(subsystem string) string {
+	if m.namespace == "" {
+		return subsystem
+	}
+	return m.namespace + "_" + subsystem
+}
+
+// String returns the labels formatted using the Prometheus text exposition
+// format.
+func (l metricLabels) String() string {
+	return fmt.Sprintf("service=%s,method=%s,error=%s",
+		quoteLabel(l.service), quoteLabel(l.method), quoteLabel(l.errName))
+}
+
+// methodString returns the service and method labels formatted using the
+// Prometheus text exposition format.
+func (l metricLabels) methodString() string {
+	return fmt.Sprintf("service=%s,method=%s", quoteLabel(l.service), quoteLabel(l.method))
+}
+
+// sortLabels sorts ls by service, method and error name.
+func sortLabels(ls []metricLabels) {
+	sort.Slice(ls, func(i, j int) bool {
+		if ls[i].service != ls[j].service {
+			return ls[i].service < ls[j].service
+		}
+		if ls[i].method != ls[j].method {
+			return ls[i].method < ls[j].method
+		}
+		return ls[i].errName < ls[j].errName
+	})
+}
+
+// writeHistograms writes the non-empty histograms returned by h for each of
+// the given labels.
+func writeHistograms(w io.Writer, name, help string, ls []metricLabels, h func(metricLabels) *histogram) {
+	headerWritten := false
+	for _, l := range ls {
+		hist := h(l)
+		if hist.count == 0 {
+			continue
+		}
+		if !headerWritten {
+			fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+			fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+			headerWritten = true
+		}
+		labels := l.String()
+		var cumul uint64
+		for i, b := range hist.buckets {
+			cumul += hist.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=%q} %d\n", name, labels, formatFloat(b), cumul)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, hist.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, hist.count)
+	}
+}
+
+// newHistogram creates a histogram with the given bucket upper bounds.
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+// observe adds an observation to the histogram.
+func (h *histogram) observe(v float64) {
+	h.count++
+	h.sum += v
+	for i, b := range h.buckets {
+		if v <= b {
+			h.counts[i]++
+			return
+		}
+	}
+}
+
+// quoteLabel quotes a label value escaping backslashes, double quotes and
+// line feeds.
+func quoteLabel(v string) string {
+	v = strings.Replace(v, `\`, `\\`, -1)
+	v = strings.Replace(v, `"`, `\"`, -1)
+	v = strings.Replace(v, "\n", `\n`, -1)
+	return `"` + v + `"`
+}
+
+// formatFloat formats f using the shortest representation.
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
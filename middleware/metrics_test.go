@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestEndpointMetrics(t *testing.T) {
+	var (
+		m   = NewMetrics("test")
+		ctx = context.WithValue(context.Background(), goa.ServiceKey, "svc")
+	)
+	ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+	ok := EndpointMetrics(m)(func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	fail := EndpointMetrics(m)(func(context.Context, interface{}) (interface{}, error) {
+		return nil, goa.PermanentError("bad_request", "bad request")
+	})
+	ok(ctx, nil)
+	ok(ctx, nil)
+	fail(ctx, nil)
+
+	var b bytes.Buffer
+	if err := m.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"# TYPE test_endpoint_requests_in_flight gauge\n" +
+			`test_endpoint_requests_in_flight{service="svc",method="meth"} 0` + "\n",
+		"# TYPE test_endpoint_requests_total counter\n" +
+			`test_endpoint_requests_total{service="svc",method="meth",error=""} 2` + "\n" +
+			`test_endpoint_requests_total{service="svc",method="meth",error="bad_request"} 1` + "\n",
+		"# TYPE test_endpoint_request_duration_seconds histogram\n",
+		`test_endpoint_request_duration_seconds_bucket{service="svc",method="meth",error="",le="+Inf"} 2` + "\n",
+		`test_endpoint_request_duration_seconds_count{service="svc",method="meth",error="bad_request"} 1` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("metrics:\n%s\ndo not contain:\n%s", b.String(), e)
+		}
+	}
+	if strings.Contains(b.String(), "size_bytes") {
+		t.Errorf("metrics:\n%s\nexpected no size histogram", b.String())
+	}
+}
+
+func TestMetricsSizes(t *testing.T) {
+	m := NewMetrics("")
+	m.RequestStarted("http", "svc", "meth")
+	m.RequestStarted("http", "svc", "meth")
+	m.RequestStarted("http", "svc", "other")
+	m.RequestCompleted("http", "svc", "meth", "", 0, 150, 50)
+
+	var b bytes.Buffer
+	if err := m.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`http_requests_in_flight{service="svc",method="meth"} 1` + "\n" +
+			`http_requests_in_flight{service="svc",method="other"} 1` + "\n",
+		`http_request_size_bytes_bucket{service="svc",method="meth",error="",le="100"} 0` + "\n",
+		`http_request_size_bytes_bucket{service="svc",method="meth",error="",le="1000"} 1` + "\n",
+		`http_request_size_bytes_sum{service="svc",method="meth",error=""} 150` + "\n",
+		`http_response_size_bytes_bucket{service="svc",method="meth",error="",le="100"} 1` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("metrics:\n%s\ndo not contain:\n%s", b.String(), e)
+		}
+	}
+}
+
+func TestTrackRequest(t *testing.T) {
+	m := NewMetrics("")
+	ctx, ri := WithRequestInfo(context.Background())
+	completed := m.TrackRequest("http", ri)
+	ctx = context.WithValue(ctx, goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+	SetServiceMethod(ctx)
+	unmatched := m.TrackRequest("http", &RequestInfo{})
+
+	var b bytes.Buffer
+	if err := m.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	if e := `http_requests_in_flight{service="svc",method="meth"} 1` + "\n"; !strings.Contains(b.String(), e) {
+		t.Errorf("metrics:\n%s\ndo not contain:\n%s", b.String(), e)
+	}
+	completed("", 0, -1, -1)
+	unmatched("", 0, -1, -1)
+
+	b.Reset()
+	if err := m.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`http_requests_in_flight{service="",method=""} 0` + "\n" +
+			`http_requests_in_flight{service="svc",method="meth"} 0` + "\n",
+		`http_requests_total{service="",method="",error=""} 1` + "\n" +
+			`http_requests_total{service="svc",method="meth",error=""} 1` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("metrics:\n%s\ndo not contain:\n%s", b.String(), e)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Recover returns a goa endpoint middleware that recovers from panics raised
+// by the endpoint. The middleware logs the panic value and stack trace using
+// l and returns the error created by PanicError so that the generated error
+// encoders render it as any other server fault.
+func Recover(l Logger) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (res interface{}, err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					res, err = nil, PanicError(ctx, l, rec)
+				}
+			}()
+			return e(ctx, req)
+		}
+	}
+}
+
+// PanicError logs the given recovered panic value together with the current
+// stack trace using l and returns a goa fault error. The message of the error
+// does not include the panic value, instead the error ID is logged alongside
+// the panic value so that the error returned to the client can be correlated
+// with the log entry. PanicError must be called from the deferred function
+// that recovered the panic for the stack trace to be relevant.
+func PanicError(ctx context.Context, l Logger, rec interface{}) *goa.ServiceError {
+	err := goa.Fault("internal server error")
+	keyvals := []interface{}{
+		"panic", rec,
+		"error_id", err.ID,
+		"stack", string(debug.Stack()),
+	}
+	if id, ok := ctx.Value(RequestIDKey).(string); ok {
+		keyvals = append([]interface{}{"id", id}, keyvals...)
+	}
+	AsLeveledLogger(l).Error(keyvals...)
+	return err
+}
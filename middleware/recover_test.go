@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestRecover(t *testing.T) {
+	var b bytes.Buffer
+	e := Recover(NewLogfmtLogger(&b))(func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	ctx := context.WithValue(context.Background(), RequestIDKey, "abc")
+
+	res, err := e(ctx, nil)
+
+	if res != nil {
+		t.Errorf("got result %v, expected nil", res)
+	}
+	serr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %T, expected *goa.ServiceError", err)
+	}
+	if !serr.Fault || serr.ID == "" {
+		t.Errorf("got error %+v, expected fault with ID", serr)
+	}
+	if strings.Contains(serr.Message, "boom") {
+		t.Errorf("error message %q contains panic value", serr.Message)
+	}
+	for _, s := range []string{"level=error", "id=abc", "panic=boom", "error_id=" + serr.ID, "stack="} {
+		if !strings.Contains(b.String(), s) {
+			t.Errorf("log %q does not contain %q", b.String(), s)
+		}
+	}
+}
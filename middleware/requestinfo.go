@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// RequestInfo holds information about a request recorded by the
+	// transport layers while the request is being handled. The log and
+	// metrics middlewares initialize it and use it once the request
+	// completes.
+	RequestInfo struct {
+		// Service is the name of the service as defined in the design.
+		Service string
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Route is the pattern of the HTTP route that matched the
+		// request.
+		Route string
+		// ErrorName is the name of the error returned by the method if
+		// any.
+		ErrorName string
+
+		// onServiceMethod lists the functions called once the service
+		// and method names are recorded.
+		onServiceMethod []func(*RequestInfo)
+	}
+
+	// private type used to define the request info context key
+	requestInfoCtxKey int
+)
+
+// requestInfoKey is the context key used to store the request RequestInfo.
+const requestInfoKey requestInfoCtxKey = 1
+
+// WithRequestInfo returns a context containing an empty RequestInfo value
+// that the transport layers initialize while handling the request.
+func WithRequestInfo(ctx context.Context) (context.Context, *RequestInfo) {
+	ri := &RequestInfo{}
+	return context.WithValue(ctx, requestInfoKey, ri), ri
+}
+
+// ContextRequestInfo returns the RequestInfo value stored in the given context
+// by WithRequestInfo or nil if there is none.
+func ContextRequestInfo(ctx context.Context) *RequestInfo {
+	ri, _ := ctx.Value(requestInfoKey).(*RequestInfo)
+	return ri
+}
+
+// SetServiceMethod records the service and method names stored in the given
+// context under the goa.ServiceKey and goa.MethodKey keys in the context
+// RequestInfo value if any. The generated transport code calls
+// SetServiceMethod prior to invoking the endpoint.
+func SetServiceMethod(ctx context.Context) {
+	ri := ContextRequestInfo(ctx)
+	if ri == nil {
+		return
+	}
+	ri.Service, _ = ctx.Value(goa.ServiceKey).(string)
+	ri.Method, _ = ctx.Value(goa.MethodKey).(string)
+	fns := ri.onServiceMethod
+	ri.onServiceMethod = nil
+	for _, fn := range fns {
+		fn(ri)
+	}
+}
+
+// OnServiceMethod registers a function that SetServiceMethod calls once it
+// records the service and method names in ri.
+func (ri *RequestInfo) OnServiceMethod(fn func(*RequestInfo)) {
+	ri.onServiceMethod = append(ri.onServiceMethod, fn)
+}
+
+// ErrorName returns the name of the given error if it implements the
+// ErrorName method (as goa.ServiceError and the generated error types do),
+// the empty string otherwise.
+func ErrorName(err error) string {
+	if en, ok := err.(interface{ ErrorName() string }); ok {
+		return en.ErrorName()
+	}
+	return ""
+}
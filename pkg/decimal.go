@@ -0,0 +1,164 @@
+package goa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an arbitrary-precision decimal number. Decimal is the Go type of
+// the Decimal design primitive. The zero value represents 0.
+//
+// Decimal values are immutable and comparable, two decimal values are equal
+// if they have the same value and the same number of fractional digits (i.e.
+// "1.50" and "1.5" are different). Use Cmp to compare the numerical values.
+//
+// Decimal values are encoded as JSON strings to avoid losing precision.
+type Decimal struct {
+	// s is the normalized representation of the decimal, "" for 0.
+	s string
+}
+
+// maxDecimalExponent is the largest exponent accepted by ParseDecimal.
+const maxDecimalExponent = 1000
+
+// decimalRegexp matches decimal literals, the groups capture the sign, the
+// integer part, the fractional part and the exponent.
+var decimalRegexp = regexp.MustCompile(`^([+-]?)(\d*)(?:\.(\d*))?(?:[eE]([+-]?\d+))?$`)
+
+// ParseDecimal parses a decimal literal such as "-12.50" or "1.2e3".
+func ParseDecimal(s string) (Decimal, error) {
+	m := decimalRegexp.FindStringSubmatch(s)
+	if m == nil || m[2] == "" && m[3] == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	var exp int
+	if m[4] != "" {
+		e, err := strconv.Atoi(m[4])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+		}
+		exp = e
+	}
+	digits := m[2] + m[3]
+	scale := len(m[3]) - exp
+	if scale < 0 {
+		digits += strings.Repeat("0", -scale)
+		scale = 0
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	intPart := strings.TrimLeft(digits[:len(digits)-scale], "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	res := intPart
+	if scale > 0 {
+		res += "." + digits[len(digits)-scale:]
+	}
+	if strings.Trim(res, "0.") == "" {
+		if scale == 0 {
+			return Decimal{}, nil
+		}
+		return Decimal{s: res}, nil
+	}
+	if m[1] == "-" {
+		res = "-" + res
+	}
+	return Decimal{s: res}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is not a valid
+// decimal literal.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String returns the decimal representation of d.
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// IsZero returns true if d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Sign returns -1 if d is negative, 0 if d is 0 and +1 if d is positive.
+func (d Decimal) Sign() int {
+	if strings.Trim(d.s, "0.") == "" {
+		return 0
+	}
+	if d.s[0] == '-' {
+		return -1
+	}
+	return 1
+}
+
+// Cmp compares the values of d and other and returns -1 if d < other, 0 if
+// d == other and +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Rat returns the value of d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}
+
+// Float64 returns the float64 value nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Decimal values are encoded as JSON
+// strings.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts both JSON strings and
+// JSON numbers.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return d.UnmarshalText(data)
+}
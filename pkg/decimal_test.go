@@ -0,0 +1,85 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]struct {
+		val      string
+		expected string
+		err      bool
+	}{
+		"integer":          {"42", "42", false},
+		"negative":         {"-12.50", "-12.50", false},
+		"plus sign":        {"+1.5", "1.5", false},
+		"leading zeros":    {"007.10", "7.10", false},
+		"fraction only":    {".5", "0.5", false},
+		"exponent":         {"1.2e3", "1200", false},
+		"negative exp":     {"12e-3", "0.012", false},
+		"zero":             {"-0", "0", false},
+		"zero with scale":  {"-0.00", "0.00", false},
+		"empty":            {"", "", true},
+		"dot only":         {".", "", true},
+		"letters":          {"1.2a", "", true},
+		"exponent too big": {"1e100000", "", true},
+	}
+	for k, tc := range cases {
+		d, err := ParseDecimal(tc.val)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected error, got %q", k, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", k, err)
+			continue
+		}
+		if d.String() != tc.expected {
+			t.Errorf("%s: got %q, expected %q", k, d, tc.expected)
+		}
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	cases := map[string]struct {
+		a, b     string
+		expected int
+	}{
+		"equal":         {"1.5", "1.50", 0},
+		"lower":         {"-2", "1", -1},
+		"greater":       {"0.30", "0.2999", 1},
+		"zero and zero": {"0", "0.000", 0},
+	}
+	for k, tc := range cases {
+		if actual := MustParseDecimal(tc.a).Cmp(MustParseDecimal(tc.b)); actual != tc.expected {
+			t.Errorf("%s: got %d, expected %d", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		A Decimal  `json:"a"`
+		B *Decimal `json:"b"`
+		C Decimal  `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"12.30","b":0.1}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "12.30" || v.B == nil || v.B.String() != "0.1" || !v.C.IsZero() {
+		t.Errorf("got %v %v %v", v.A, v.B, v.C)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"a":"12.30","b":"0.1","c":"0"}`; string(b) != expected {
+		t.Errorf("got %s, expected %s", b, expected)
+	}
+	if err := json.Unmarshal([]byte(`{"a":"foo"}`), &v); err == nil {
+		t.Error("expected error for invalid decimal")
+	}
+}
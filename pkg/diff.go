@@ -0,0 +1,32 @@
+package goa
+
+// FieldChange describes a field whose value differs between two values of the
+// same type. The Diff methods generated for the types that set the
+// "type:generate:helpers" meta return the list of changed fields.
+type FieldChange struct {
+	// Field is the path to the field using the attribute names defined in
+	// the design, e.g. "address.street". Field is empty if the change
+	// concerns the whole value, for example when one of the values is nil.
+	Field string
+	// Old is the value of the field in the receiver of Diff.
+	Old interface{}
+	// New is the value of the field in the argument of Diff.
+	New interface{}
+}
+
+// NestFieldChanges prefixes the field paths of the given changes with the
+// given field name. It is used by the generated Diff methods to report the
+// changes made to nested values.
+func NestFieldChanges(name string, changes []FieldChange) []FieldChange {
+	if name == "" {
+		return changes
+	}
+	for i, c := range changes {
+		if c.Field == "" {
+			changes[i].Field = name
+		} else {
+			changes[i].Field = name + "." + c.Field
+		}
+	}
+	return changes
+}
@@ -0,0 +1,25 @@
+package goa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNestFieldChanges(t *testing.T) {
+	cases := map[string]struct {
+		name     string
+		changes  []FieldChange
+		expected []FieldChange
+	}{
+		"no change":  {"foo", nil, nil},
+		"whole":      {"foo", []FieldChange{{Old: 1, New: 2}}, []FieldChange{{Field: "foo", Old: 1, New: 2}}},
+		"nested":     {"foo", []FieldChange{{Field: "bar", Old: 1, New: 2}}, []FieldChange{{Field: "foo.bar", Old: 1, New: 2}}},
+		"empty name": {"", []FieldChange{{Field: "bar", Old: 1, New: 2}}, []FieldChange{{Field: "bar", Old: 1, New: 2}}},
+	}
+	for k, tc := range cases {
+		actual := NestFieldChanges(tc.name, tc.changes)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
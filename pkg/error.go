@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 type (
@@ -120,6 +121,31 @@ func InvalidRangeError(name string, target interface{}, value interface{}, min b
 	return PermanentError("invalid_range", "%s must be %s than %d but got value %#v", name, comp, value, target)
 }
 
+// InvalidExclusiveRangeError is the error produced by the generated code when
+// the value of a payload field does not match the exclusive range validation
+// defined in the design. value may be an int or a float64.
+func InvalidExclusiveRangeError(name string, target interface{}, value interface{}, min bool) error {
+	comp := "greater"
+	if !min {
+		comp = "lesser"
+	}
+	return PermanentError("invalid_range", "%s must be %s than %v but got value %#v", name, comp, value, target)
+}
+
+// InvalidMultipleOfError is the error produced by the generated code when the
+// value of a payload field is not a multiple of the value defined in the
+// design.
+func InvalidMultipleOfError(name string, target interface{}, value interface{}) error {
+	return PermanentError("invalid_multiple_of", "%s must be a multiple of %v but got value %#v", name, value, target)
+}
+
+// InvalidUniqueItemsError is the error produced by the generated code when an
+// array payload field defined with the unique items validation contains
+// duplicate elements. dup is the first duplicate element.
+func InvalidUniqueItemsError(name string, dup interface{}) error {
+	return PermanentError("invalid_unique_items", "%s must contain unique items but got duplicate value %#v", name, dup)
+}
+
 // InvalidLengthError is the error produced by the generated code when the value
 // of a payload field does not match the length validation defined in the
 // design.
@@ -131,6 +157,18 @@ func InvalidLengthError(name string, target interface{}, ln, value int, min bool
 	return PermanentError("invalid_length", "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
 }
 
+// RequestBodyTooLargeError is the error produced by the generated code when
+// the size of a request body exceeds the limit defined in the design.
+func RequestBodyTooLargeError(limit int64) error {
+	return PermanentError("request_body_too_large", "request body must be lesser or equal than %d bytes", limit)
+}
+
+// DeadlineExceededError is the error produced by the generated code when a
+// request is not handled within the timeout defined in the design.
+func DeadlineExceededError(timeout time.Duration) error {
+	return PermanentTimeoutError("deadline_exceeded", "request not handled within %s", timeout)
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -0,0 +1,83 @@
+package goa
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+//go:generate go run nullable_gen.go
+
+// The Nullable types defined in nullable_types.go hold the values of
+// attributes defined with the Nullable DSL. They make it possible to
+// distinguish an attribute that is not set from an attribute explicitly set to
+// null, e.g. when decoding the body of a JSON merge patch request. The zero
+// value of a Nullable type represents a value that is not set.
+//
+// Nullable values are encoded in JSON as null when they are null. The
+// generated HTTP body types that have Nullable fields implement MarshalJSON
+// with MarshalOmitUnset so that the values that are not set are omitted from
+// the JSON encoding.
+
+// nullable is implemented by the Nullable types.
+type nullable interface {
+	isSet() bool
+}
+
+// MarshalOmitUnset returns the JSON encoding of the struct v omitting the
+// Nullable fields that are not set. The struct fields are otherwise encoded as
+// json.Marshal does. MarshalOmitUnset returns the JSON encoding of v if v is
+// not a struct or a pointer to a struct.
+func MarshalOmitUnset(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return []byte("null"), nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return json.Marshal(v)
+	}
+	var (
+		fields []reflect.StructField
+		values []reflect.Value
+	)
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+		if f.PkgPath != "" {
+			continue // unexported fields are not encoded
+		}
+		fv := rv.Field(i)
+		if n, ok := fv.Interface().(nullable); ok && !n.isSet() {
+			continue
+		}
+		fields = append(fields, f)
+		values = append(values, fv)
+	}
+	// The anonymous struct does not implement json.Marshaler so that
+	// MarshalOmitUnset may be called by the MarshalJSON method of v.
+	res := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, fv := range values {
+		res.Field(i).Set(fv)
+	}
+	return json.Marshal(res.Interface())
+}
+
+// marshalNullable returns the JSON encoding of v if valid is true, null
+// otherwise.
+func marshalNullable(valid bool, v interface{}) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalNullable decodes data into v unless data is the JSON null literal
+// in which case it returns true.
+func unmarshalNullable(data []byte, v interface{}) (bool, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return true, nil
+	}
+	return false, json.Unmarshal(data, v)
+}
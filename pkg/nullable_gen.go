@@ -0,0 +1,109 @@
+//go:build ignore
+// +build ignore
+
+// nullable_gen generates the Nullable types defined in nullable_types.go, run
+// it with "go generate" from the pkg directory.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"text/template"
+)
+
+// nullable describes a Nullable type.
+type nullable struct {
+	// Name is the type name suffix, e.g. "String" for NullableString.
+	Name string
+	// GoType is the Go type of the value.
+	GoType string
+	// Desc describes the value in the type doc comment.
+	Desc string
+}
+
+var nullables = []nullable{
+	{"Bool", "bool", "a bool"},
+	{"Int", "int", "an int"},
+	{"Int32", "int32", "an int32"},
+	{"Int64", "int64", "an int64"},
+	{"UInt", "uint", "a uint"},
+	{"UInt32", "uint32", "a uint32"},
+	{"UInt64", "uint64", "a uint64"},
+	{"Float32", "float32", "a float32"},
+	{"Float64", "float64", "a float64"},
+	{"String", "string", "a string"},
+	{"Bytes", "[]byte", "a byte slice"},
+	{"Any", "interface{}", "an arbitrary value"},
+	{"Time", "time.Time", "a time"},
+	{"Duration", "time.Duration", "a duration"},
+	{"Decimal", "Decimal", "a decimal"},
+}
+
+func main() {
+	var buf bytes.Buffer
+	if err := nullableT.Execute(&buf, nullables); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("nullable_types.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var nullableT = template.Must(template.New("nullable").Parse(`// Code generated by nullable_gen.go, DO NOT EDIT.
+
+package goa
+
+import "time"
+{{ range . }}
+// Nullable{{ .Name }} is {{ .Desc }} that may be null or not set.
+type Nullable{{ .Name }} struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value {{ .GoType }}
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullable{{ .Name }} returns a Nullable{{ .Name }} set to v.
+func NewNullable{{ .Name }}(v {{ .GoType }}) Nullable{{ .Name }} {
+	return Nullable{{ .Name }}{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n Nullable{{ .Name }}) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n Nullable{{ .Name }}) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n Nullable{{ .Name }}) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Nullable{{ .Name }}) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Nullable{{ .Name }}) UnmarshalJSON(data []byte) error {
+	var v {{ .GoType }}
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = Nullable{{ .Name }}{Value: v, Set: true, Null: null}
+	return nil
+}
+{{ end }}`))
@@ -0,0 +1,112 @@
+package goa
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNullableUnmarshalJSON(t *testing.T) {
+	type body struct {
+		Name NullableString `json:"name,omitempty"`
+		Age  NullableInt    `json:"age,omitempty"`
+	}
+	cases := map[string]struct {
+		json string
+		name NullableString
+		age  NullableInt
+	}{
+		"not set": {`{}`, NullableString{}, NullableInt{}},
+		"null":    {`{"name":null,"age":null}`, NullableString{Set: true, Null: true}, NullableInt{Set: true, Null: true}},
+		"set":     {`{"name":"foo","age":0}`, NewNullableString("foo"), NewNullableInt(0)},
+	}
+	for k, tc := range cases {
+		var b body
+		if err := json.Unmarshal([]byte(tc.json), &b); err != nil {
+			t.Errorf("%s: unexpected error %v", k, err)
+			continue
+		}
+		if b.Name != tc.name {
+			t.Errorf("%s: got name %#v, expected %#v", k, b.Name, tc.name)
+		}
+		if b.Age != tc.age {
+			t.Errorf("%s: got age %#v, expected %#v", k, b.Age, tc.age)
+		}
+	}
+	var b body
+	if err := json.Unmarshal([]byte(`{"age":"foo"}`), &b); err == nil {
+		t.Errorf("invalid value: expected error, got %#v", b.Age)
+	}
+}
+
+func TestNullableMarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		val      NullableString
+		expected string
+	}{
+		"not set": {NullableString{}, `null`},
+		"null":    {NullableString{Set: true, Null: true}, `null`},
+		"set":     {NewNullableString("foo"), `"foo"`},
+		"empty":   {NewNullableString(""), `""`},
+	}
+	for k, tc := range cases {
+		b, err := json.Marshal(tc.val)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", k, err)
+			continue
+		}
+		if string(b) != tc.expected {
+			t.Errorf("%s: got %s, expected %s", k, string(b), tc.expected)
+		}
+	}
+}
+
+// nullableBody mirrors the HTTP body types generated for nullable attributes.
+type nullableBody struct {
+	Name     *string        `json:"name,omitempty"`
+	Nickname NullableString `json:"nickname,omitempty"`
+	Age      NullableInt    `json:"age,omitempty"`
+	internal string
+}
+
+func (body nullableBody) MarshalJSON() ([]byte, error) {
+	return MarshalOmitUnset(body)
+}
+
+func TestNullableRoundTrip(t *testing.T) {
+	type body = nullableBody
+	name := "foo"
+	cases := map[string]struct {
+		sent     body
+		expected string
+	}{
+		"not set":    {body{Name: &name}, `{"name":"foo"}`},
+		"null":       {body{Nickname: NullableString{Set: true, Null: true}, Age: NullableInt{Set: true, Null: true}}, `{"nickname":null,"age":null}`},
+		"set":        {body{Nickname: NewNullableString(""), Age: NewNullableInt(0)}, `{"nickname":"","age":0}`},
+		"unexported": {body{Name: &name, internal: "bar"}, `{"name":"foo"}`},
+	}
+	for k, tc := range cases {
+		b, err := json.Marshal(tc.sent)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", k, err)
+			continue
+		}
+		if string(b) != tc.expected {
+			t.Errorf("%s: got %s, expected %s", k, string(b), tc.expected)
+		}
+		var received body
+		if err := json.Unmarshal(b, &received); err != nil {
+			t.Errorf("%s: unexpected error %v", k, err)
+			continue
+		}
+		if !reflect.DeepEqual(received.Name, tc.sent.Name) {
+			t.Errorf("%s: got name %v, expected %v", k, received.Name, tc.sent.Name)
+		}
+		if received.Nickname != tc.sent.Nickname {
+			t.Errorf("%s: got nickname %#v, expected %#v", k, received.Nickname, tc.sent.Nickname)
+		}
+		if received.Age != tc.sent.Age {
+			t.Errorf("%s: got age %#v, expected %#v", k, received.Age, tc.sent.Age)
+		}
+	}
+}
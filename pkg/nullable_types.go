@@ -0,0 +1,695 @@
+// Code generated by nullable_gen.go, DO NOT EDIT.
+
+package goa
+
+import "time"
+
+// NullableBool is a bool that may be null or not set.
+type NullableBool struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value bool
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableBool returns a NullableBool set to v.
+func NewNullableBool(v bool) NullableBool {
+	return NullableBool{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableBool) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableBool) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableBool) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableBool) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableBool) UnmarshalJSON(data []byte) error {
+	var v bool
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableBool{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableInt is an int that may be null or not set.
+type NullableInt struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value int
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableInt returns a NullableInt set to v.
+func NewNullableInt(v int) NullableInt {
+	return NullableInt{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableInt) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableInt) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableInt) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableInt) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableInt) UnmarshalJSON(data []byte) error {
+	var v int
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableInt{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableInt32 is an int32 that may be null or not set.
+type NullableInt32 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value int32
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableInt32 returns a NullableInt32 set to v.
+func NewNullableInt32(v int32) NullableInt32 {
+	return NullableInt32{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableInt32) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableInt32) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableInt32) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableInt32) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableInt32) UnmarshalJSON(data []byte) error {
+	var v int32
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableInt32{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableInt64 is an int64 that may be null or not set.
+type NullableInt64 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value int64
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableInt64 returns a NullableInt64 set to v.
+func NewNullableInt64(v int64) NullableInt64 {
+	return NullableInt64{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableInt64) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableInt64) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableInt64) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableInt64) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableInt64) UnmarshalJSON(data []byte) error {
+	var v int64
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableInt64{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableUInt is a uint that may be null or not set.
+type NullableUInt struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value uint
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableUInt returns a NullableUInt set to v.
+func NewNullableUInt(v uint) NullableUInt {
+	return NullableUInt{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableUInt) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableUInt) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableUInt) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableUInt) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableUInt) UnmarshalJSON(data []byte) error {
+	var v uint
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableUInt{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableUInt32 is a uint32 that may be null or not set.
+type NullableUInt32 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value uint32
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableUInt32 returns a NullableUInt32 set to v.
+func NewNullableUInt32(v uint32) NullableUInt32 {
+	return NullableUInt32{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableUInt32) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableUInt32) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableUInt32) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableUInt32) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableUInt32) UnmarshalJSON(data []byte) error {
+	var v uint32
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableUInt32{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableUInt64 is a uint64 that may be null or not set.
+type NullableUInt64 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value uint64
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableUInt64 returns a NullableUInt64 set to v.
+func NewNullableUInt64(v uint64) NullableUInt64 {
+	return NullableUInt64{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableUInt64) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableUInt64) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableUInt64) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableUInt64) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableUInt64) UnmarshalJSON(data []byte) error {
+	var v uint64
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableUInt64{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableFloat32 is a float32 that may be null or not set.
+type NullableFloat32 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value float32
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableFloat32 returns a NullableFloat32 set to v.
+func NewNullableFloat32(v float32) NullableFloat32 {
+	return NullableFloat32{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableFloat32) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableFloat32) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableFloat32) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableFloat32) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableFloat32) UnmarshalJSON(data []byte) error {
+	var v float32
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableFloat32{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableFloat64 is a float64 that may be null or not set.
+type NullableFloat64 struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value float64
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableFloat64 returns a NullableFloat64 set to v.
+func NewNullableFloat64(v float64) NullableFloat64 {
+	return NullableFloat64{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableFloat64) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableFloat64) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableFloat64) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableFloat64) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableFloat64) UnmarshalJSON(data []byte) error {
+	var v float64
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableFloat64{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableString is a string that may be null or not set.
+type NullableString struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value string
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableString returns a NullableString set to v.
+func NewNullableString(v string) NullableString {
+	return NullableString{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableString) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableString) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableString) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableString) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	var v string
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableString{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableBytes is a byte slice that may be null or not set.
+type NullableBytes struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value []byte
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableBytes returns a NullableBytes set to v.
+func NewNullableBytes(v []byte) NullableBytes {
+	return NullableBytes{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableBytes) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableBytes) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableBytes) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableBytes) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableBytes) UnmarshalJSON(data []byte) error {
+	var v []byte
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableBytes{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableAny is an arbitrary value that may be null or not set.
+type NullableAny struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value interface{}
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableAny returns a NullableAny set to v.
+func NewNullableAny(v interface{}) NullableAny {
+	return NullableAny{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableAny) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableAny) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableAny) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableAny) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableAny) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableAny{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableTime is a time that may be null or not set.
+type NullableTime struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value time.Time
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableTime returns a NullableTime set to v.
+func NewNullableTime(v time.Time) NullableTime {
+	return NullableTime{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableTime) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableTime) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableTime) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableTime) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableTime) UnmarshalJSON(data []byte) error {
+	var v time.Time
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableTime{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableDuration is a duration that may be null or not set.
+type NullableDuration struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value time.Duration
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableDuration returns a NullableDuration set to v.
+func NewNullableDuration(v time.Duration) NullableDuration {
+	return NullableDuration{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableDuration) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableDuration) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableDuration) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableDuration) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableDuration) UnmarshalJSON(data []byte) error {
+	var v time.Duration
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableDuration{Value: v, Set: true, Null: null}
+	return nil
+}
+
+// NullableDecimal is a decimal that may be null or not set.
+type NullableDecimal struct {
+	// Value is the value, the zero value if the value is null or not set.
+	Value Decimal
+	// Set is true if the value is set, including to null.
+	Set bool
+	// Null is true if the value is explicitly set to null.
+	Null bool
+}
+
+// NewNullableDecimal returns a NullableDecimal set to v.
+func NewNullableDecimal(v Decimal) NullableDecimal {
+	return NullableDecimal{Value: v, Set: true}
+}
+
+// Valid returns true if the value is set and not null.
+func (n NullableDecimal) Valid() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if the value is not set.
+func (n NullableDecimal) IsZero() bool {
+	return !n.Set
+}
+
+// isSet returns true if the value is set, see MarshalOmitUnset.
+func (n NullableDecimal) isSet() bool {
+	return n.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableDecimal) MarshalJSON() ([]byte, error) {
+	return marshalNullable(n.Valid(), n.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableDecimal) UnmarshalJSON(data []byte) error {
+	var v Decimal
+	null, err := unmarshalNullable(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = NullableDecimal{Value: v, Set: true, Null: null}
+	return nil
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"net/mail"
 	"net/url"
+	"reflect"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 )
@@ -57,15 +60,61 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatTime describes RFC3339 partial time values.
+	FormatTime = "time"
+
+	// FormatDuration describes ISO8601 duration values.
+	FormatDuration = "duration"
+
+	// FormatURIReference describes RFC3986 URI reference values.
+	FormatURIReference = "uri-reference"
+
+	// FormatIRI describes RFC3987 IRI values.
+	FormatIRI = "iri"
+
+	// FormatJSONPointer describes RFC6901 JSON pointer values.
+	FormatJSONPointer = "json-pointer"
+
+	// FormatIDNEmail describes RFC6531 internationalized email addresses.
+	FormatIDNEmail = "idn-email"
+
+	// FormatSemver describes semantic version 2.0.0 values.
+	FormatSemver = "semver"
+
+	// FormatDecimal describes arbitrary-precision decimal number literals.
+	FormatDecimal = "decimal"
 )
 
 var (
-	hostnameRegex  = regexp.MustCompile(`^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`)
-	ipv4Regex      = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
-	uuidURNPrefix  = []byte("urn:uuid:")
-	uuidByteGroups = []int{8, 4, 4, 4, 12}
+	hostnameRegex    = regexp.MustCompile(`^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`)
+	ipv4Regex        = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+	durationRegex    = regexp.MustCompile(`^P(?:\d+Y)?(?:\d+M)?(?:\d+W)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:[.,]\d+)?S)?)?$`)
+	jsonPointerRegex = regexp.MustCompile(`^(?:/(?:[^~/]|~[01])*)*$`)
+	semverRegex      = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	uuidURNPrefix    = []byte("urn:uuid:")
+	uuidByteGroups   = []int{8, 4, 4, 4, 12}
 )
 
+// customFormats records the validators of the formats registered with
+// RegisterFormat.
+var customFormats = make(map[Format]func(string) error)
+
+// customFormatsLock is the mutex used to access customFormats.
+var customFormatsLock = &sync.RWMutex{}
+
+// RegisterFormat registers the validator used by ValidateFormat to validate
+// values of the custom format f. The validator returns an error if the value
+// does not conform to the format. RegisterFormat is typically called in an
+// init function of the service implementation package for each format
+// registered in the design with the RegisterFormat DSL function. Registering
+// a validator for a format supported by goa overrides the default validation.
+func RegisterFormat(f Format, validator func(string) error) {
+	customFormatsLock.Lock()
+	defer customFormatsLock.Unlock()
+	customFormats[f] = validator
+}
+
 // ValidateFormat validates val against f. It returns nil if the string conforms
 // to the format, an error otherwise. name is the name of the variable used in
 // error messages. where in a data structure the error occurred if any. The
@@ -83,7 +132,25 @@ var (
 //     - "cidr": RFC4632 and RFC4291 CIDR notation IP address value
 //     - "regexp": Regular expression syntax accepted by RE2
 //     - "rfc1123": RFC1123 date time value
+//     - "time": RFC3339 partial time value
+//     - "duration": ISO8601 duration value
+//     - "uri-reference": RFC3986 URI reference value
+//     - "iri": RFC3987 IRI value
+//     - "json-pointer": RFC6901 JSON pointer value
+//     - "idn-email": RFC6531 internationalized email address
+//     - "semver": semantic version 2.0.0 value
+//
+// Additional formats may be registered with RegisterFormat.
 func ValidateFormat(name string, val string, f Format) error {
+	customFormatsLock.RLock()
+	validator, ok := customFormats[f]
+	customFormatsLock.RUnlock()
+	if ok {
+		if err := validator(val); err != nil {
+			return InvalidFormatError(name, val, f, err)
+		}
+		return nil
+	}
 	var err error
 	switch f {
 	case FormatDate:
@@ -128,6 +195,32 @@ func ValidateFormat(name string, val string, f Format) error {
 		}
 	case FormatRFC1123:
 		_, err = time.Parse(time.RFC1123, val)
+	case FormatTime:
+		_, err = time.Parse("15:04:05.999999999", val)
+	case FormatDuration:
+		if !durationRegex.MatchString(val) || val == "P" || strings.HasSuffix(val, "T") {
+			err = fmt.Errorf("\"%s\" is an invalid ISO8601 duration value", val)
+		}
+	case FormatURIReference:
+		_, err = url.Parse(val)
+	case FormatIRI:
+		var u *url.URL
+		u, err = url.Parse(val)
+		if err == nil && !u.IsAbs() {
+			err = fmt.Errorf("\"%s\" is a relative IRI", val)
+		}
+	case FormatJSONPointer:
+		if !jsonPointerRegex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid JSON pointer value", val)
+		}
+	case FormatIDNEmail:
+		_, err = mail.ParseAddress(val)
+	case FormatSemver:
+		if !semverRegex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid semantic version value", val)
+		}
+	case FormatDecimal:
+		_, err = ParseDecimal(val)
 	default:
 		return fmt.Errorf("unknown format %#v", f)
 	}
@@ -164,6 +257,59 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// ValidateMultipleOf returns an error if val is not a multiple of m. The
+// comparison tolerates the rounding errors inherent to floating point
+// arithmetic. name is the name of the variable used in error messages.
+func ValidateMultipleOf(name string, val, m float64) error {
+	q := val / m
+	if math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+		return InvalidMultipleOfError(name, val, m)
+	}
+	return nil
+}
+
+// ValidateUniqueItems returns an error if the slice val contains duplicate
+// elements. Elements are compared using reflect.DeepEqual so that pointers are
+// compared by the values they point to. name is the name of the variable used
+// in error messages.
+func ValidateUniqueItems(name string, val interface{}) error {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	for i := 1; i < v.Len(); i++ {
+		e := v.Index(i).Interface()
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(e, v.Index(j).Interface()) {
+				return InvalidUniqueItemsError(name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// SelectFields returns the set of the attribute names listed in fields. It
+// returns an error if fields lists a name that is not listed in allowed. name
+// is the name of the variable used in error messages.
+func SelectFields(name string, fields, allowed []string) (map[string]struct{}, error) {
+	all := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		all[a] = struct{}{}
+	}
+	selected := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if _, ok := all[f]; !ok {
+			vals := make([]interface{}, len(allowed))
+			for i, a := range allowed {
+				vals[i] = a
+			}
+			return nil, InvalidEnumValueError(name, f, vals)
+		}
+		selected[f] = struct{}{}
+	}
+	return selected, nil
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
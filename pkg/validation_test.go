@@ -41,6 +41,22 @@ func TestValidateFormat(t *testing.T) {
 		invalidJSON     = "{"
 		validRFC1123    = "Mon, 04 Jun 2017 23:52:05 MST"
 		invalidRFC1123  = "Mon 04 Jun 2017 23:52:05 MST"
+		validTime       = "08:31:23.5"
+		invalidTime     = "08:31"
+		validDuration   = "P1Y2M10DT2H30M1.5S"
+		invalidDuration = "P1DT"
+		validURIRef     = "../contact?q=1#top"
+		invalidURIRef   = "%zz"
+		validIRI        = "https://例え.jp/パス"
+		invalidIRI      = "/パス"
+		validPointer    = "/foo/0/a~1b"
+		invalidPointer  = "foo/~2"
+		validIDNEmail   = "用户@例子.广告"
+		invalidIDNEmail = "用户"
+		validSemver     = "1.0.0-rc.1+build.5"
+		invalidSemver   = "1.0"
+		validDecimal    = "-12.50e3"
+		invalidDecimal  = "12,5"
 	)
 	cases := map[string]struct {
 		name     string
@@ -59,30 +75,46 @@ func TestValidateFormat(t *testing.T) {
 		// Re-enable once CircleCI uses Go 1.13
 		// "invalid email":      {"invalidEmail", invalidEmail, FormatEmail, InvalidFormatError("invalidEmail", invalidEmail, FormatEmail, errors.New("mail: missing '@' or angle-addr"))},
 
-		"valid hostname":     {"validHostname", validHostname, FormatHostname, nil},
-		"invalid hostname":   {"invalidHostname", invalidHostname, FormatHostname, InvalidFormatError("invalidHostname", invalidHostname, FormatHostname, fmt.Errorf("hostname value '%s' does not match %s", invalidHostname, `^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`))},
-		"valid ipv4":         {"validIPv4", validIPv4, FormatIPv4, nil},
-		"valid ipv6 as ipv4": {"validIPv6", validIPv6, FormatIPv4, InvalidFormatError("validIPv6", validIPv6, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", validIPv6, FormatIPv4))},
-		"invalid ipv4":       {"invalidIPv4", invalidIPv4, FormatIPv4, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIPv4))},
-		"valid ipv6":         {"validIPv6", validIPv6, FormatIPv6, nil},
-		"valid ipv4 as ipv6": {"validIPv4", validIPv4, FormatIPv6, InvalidFormatError("validIPv4", validIPv4, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", validIPv4, FormatIPv6))},
-		"invalid ipv6":       {"invalidIPv6", invalidIPv6, FormatIPv6, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIPv6))},
-		"valid ipv4 as ip":   {"validIPv4", validIPv4, FormatIP, nil},
-		"valid ipv6 as ip":   {"validIPv6", validIPv6, FormatIP, nil},
-		"invalid ipv4 as ip": {"invalidIPv4", invalidIPv4, FormatIP, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIP))},
-		"invalid ipv6 as ip": {"invalidIPv6", invalidIPv6, FormatIP, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIP))},
-		"valid uri":          {"validURI", validURI, FormatURI, nil},
-		"invalid uri":        {"invalidURI", invalidURI, FormatURI, InvalidFormatError("invalidURI", invalidURI, FormatURI, &url.Error{Op: "parse", URL: invalidURI, Err: errors.New("invalid URI for request")})},
-		"valid mac":          {"validMAC", validMAC, FormatMAC, nil},
-		"invalid mac":        {"invalidMAC", invalidMAC, FormatMAC, InvalidFormatError("invalidMAC", invalidMAC, FormatMAC, &net.AddrError{Err: "invalid MAC address", Addr: invalidMAC})},
-		"valid cidr":         {"validCIDR", validCIDR, FormatCIDR, nil},
-		"invalid cidr":       {"invalidCIDR", invalidCIDR, FormatCIDR, InvalidFormatError("invalidCIDR", invalidCIDR, FormatCIDR, &net.ParseError{Type: "CIDR address", Text: invalidCIDR})},
-		"valid regexp":       {"validRegexp", validRegexp, FormatRegexp, nil},
-		"invalid regexp":     {"invalidRegexp", invalidRegexp, FormatRegexp, InvalidFormatError("invalidRegexp", invalidRegexp, FormatRegexp, &syntax.Error{Code: syntax.ErrMissingBracket, Expr: invalidRegexp[3:4]})},
-		"valid json":         {"validJSON", validJSON, FormatJSON, nil},
-		"invalid json":       {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
-		"valid rfc1123":      {"validRFC1123", validRFC1123, FormatRFC1123, nil},
-		"invalid rfc1123":    {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid hostname":        {"validHostname", validHostname, FormatHostname, nil},
+		"invalid hostname":      {"invalidHostname", invalidHostname, FormatHostname, InvalidFormatError("invalidHostname", invalidHostname, FormatHostname, fmt.Errorf("hostname value '%s' does not match %s", invalidHostname, `^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`))},
+		"valid ipv4":            {"validIPv4", validIPv4, FormatIPv4, nil},
+		"valid ipv6 as ipv4":    {"validIPv6", validIPv6, FormatIPv4, InvalidFormatError("validIPv6", validIPv6, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", validIPv6, FormatIPv4))},
+		"invalid ipv4":          {"invalidIPv4", invalidIPv4, FormatIPv4, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIPv4))},
+		"valid ipv6":            {"validIPv6", validIPv6, FormatIPv6, nil},
+		"valid ipv4 as ipv6":    {"validIPv4", validIPv4, FormatIPv6, InvalidFormatError("validIPv4", validIPv4, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", validIPv4, FormatIPv6))},
+		"invalid ipv6":          {"invalidIPv6", invalidIPv6, FormatIPv6, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIPv6))},
+		"valid ipv4 as ip":      {"validIPv4", validIPv4, FormatIP, nil},
+		"valid ipv6 as ip":      {"validIPv6", validIPv6, FormatIP, nil},
+		"invalid ipv4 as ip":    {"invalidIPv4", invalidIPv4, FormatIP, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIP))},
+		"invalid ipv6 as ip":    {"invalidIPv6", invalidIPv6, FormatIP, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIP))},
+		"valid uri":             {"validURI", validURI, FormatURI, nil},
+		"invalid uri":           {"invalidURI", invalidURI, FormatURI, InvalidFormatError("invalidURI", invalidURI, FormatURI, &url.Error{Op: "parse", URL: invalidURI, Err: errors.New("invalid URI for request")})},
+		"valid mac":             {"validMAC", validMAC, FormatMAC, nil},
+		"invalid mac":           {"invalidMAC", invalidMAC, FormatMAC, InvalidFormatError("invalidMAC", invalidMAC, FormatMAC, &net.AddrError{Err: "invalid MAC address", Addr: invalidMAC})},
+		"valid cidr":            {"validCIDR", validCIDR, FormatCIDR, nil},
+		"invalid cidr":          {"invalidCIDR", invalidCIDR, FormatCIDR, InvalidFormatError("invalidCIDR", invalidCIDR, FormatCIDR, &net.ParseError{Type: "CIDR address", Text: invalidCIDR})},
+		"valid regexp":          {"validRegexp", validRegexp, FormatRegexp, nil},
+		"invalid regexp":        {"invalidRegexp", invalidRegexp, FormatRegexp, InvalidFormatError("invalidRegexp", invalidRegexp, FormatRegexp, &syntax.Error{Code: syntax.ErrMissingBracket, Expr: invalidRegexp[3:4]})},
+		"valid json":            {"validJSON", validJSON, FormatJSON, nil},
+		"invalid json":          {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
+		"valid rfc1123":         {"validRFC1123", validRFC1123, FormatRFC1123, nil},
+		"invalid rfc1123":       {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid time":            {"validTime", validTime, FormatTime, nil},
+		"invalid time":          {"invalidTime", invalidTime, FormatTime, InvalidFormatError("invalidTime", invalidTime, FormatTime, &time.ParseError{Layout: "15:04:05.999999999", Value: invalidTime, LayoutElem: ":", ValueElem: ""})},
+		"valid duration":        {"validDuration", validDuration, FormatDuration, nil},
+		"invalid duration":      {"invalidDuration", invalidDuration, FormatDuration, InvalidFormatError("invalidDuration", invalidDuration, FormatDuration, fmt.Errorf("\"%s\" is an invalid ISO8601 duration value", invalidDuration))},
+		"valid uri-reference":   {"validURIRef", validURIRef, FormatURIReference, nil},
+		"invalid uri-reference": {"invalidURIRef", invalidURIRef, FormatURIReference, InvalidFormatError("invalidURIRef", invalidURIRef, FormatURIReference, &url.Error{Op: "parse", URL: invalidURIRef, Err: url.EscapeError("%zz")})},
+		"valid iri":             {"validIRI", validIRI, FormatIRI, nil},
+		"invalid iri":           {"invalidIRI", invalidIRI, FormatIRI, InvalidFormatError("invalidIRI", invalidIRI, FormatIRI, fmt.Errorf("\"%s\" is a relative IRI", invalidIRI))},
+		"valid json-pointer":    {"validPointer", validPointer, FormatJSONPointer, nil},
+		"invalid json-pointer":  {"invalidPointer", invalidPointer, FormatJSONPointer, InvalidFormatError("invalidPointer", invalidPointer, FormatJSONPointer, fmt.Errorf("\"%s\" is an invalid JSON pointer value", invalidPointer))},
+		"valid idn-email":       {"validIDNEmail", validIDNEmail, FormatIDNEmail, nil},
+		"invalid idn-email":     {"invalidIDNEmail", invalidIDNEmail, FormatIDNEmail, InvalidFormatError("invalidIDNEmail", invalidIDNEmail, FormatIDNEmail, errors.New("mail: missing '@' or angle-addr"))},
+		"valid semver":          {"validSemver", validSemver, FormatSemver, nil},
+		"invalid semver":        {"invalidSemver", invalidSemver, FormatSemver, InvalidFormatError("invalidSemver", invalidSemver, FormatSemver, fmt.Errorf("\"%s\" is an invalid semantic version value", invalidSemver))},
+		"valid decimal":         {"validDecimal", validDecimal, FormatDecimal, nil},
+		"invalid decimal":       {"invalidDecimal", invalidDecimal, FormatDecimal, InvalidFormatError("invalidDecimal", invalidDecimal, FormatDecimal, fmt.Errorf("invalid decimal %q", invalidDecimal))},
 	}
 
 	for k, tc := range cases {
@@ -96,6 +128,32 @@ func TestValidateFormat(t *testing.T) {
 	}
 }
 
+func TestRegisterFormat(t *testing.T) {
+	const sku Format = "sku"
+	RegisterFormat(sku, func(val string) error {
+		if len(val) != 8 {
+			return errors.New("SKU must be 8 characters long")
+		}
+		return nil
+	})
+	defer func() {
+		customFormatsLock.Lock()
+		delete(customFormats, sku)
+		customFormatsLock.Unlock()
+	}()
+	if err := ValidateFormat("sku", "AB123456", sku); err != nil {
+		t.Errorf("got error %s, expected nil", err)
+	}
+	err := ValidateFormat("sku", "AB12", sku)
+	expected := InvalidFormatError("sku", "AB12", sku, errors.New("SKU must be 8 characters long"))
+	if err == nil || err.Error() != expected.Error() {
+		t.Errorf("got %v, expected %v", err, expected)
+	}
+	if err := ValidateFormat("unknown", "foo", Format("unknown")); err == nil {
+		t.Error("expected error for unregistered format")
+	}
+}
+
 func TestValidatePattern(t *testing.T) {
 	var (
 		name      = "foo"
@@ -123,3 +181,94 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMultipleOf(t *testing.T) {
+	cases := map[string]struct {
+		val      float64
+		multiple float64
+		expected error
+	}{
+		"integer multiple":  {10, 5, nil},
+		"decimal multiple":  {0.3, 0.1, nil},
+		"integer not":       {7, 5, InvalidMultipleOfError("foo", 7.0, 5.0)},
+		"decimal not":       {0.35, 0.1, InvalidMultipleOfError("foo", 0.35, 0.1)},
+		"negative multiple": {-15, 5, nil},
+		"zero":              {0, 5, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateMultipleOf("foo", tc.val, tc.multiple)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: got %#v, expected nil", k, actual)
+			}
+			continue
+		}
+		if actual == nil || actual.Error() != tc.expected.Error() {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestValidateUniqueItems(t *testing.T) {
+	cases := map[string]struct {
+		val      interface{}
+		expected error
+	}{
+		"unique strings":    {[]string{"a", "b"}, nil},
+		"duplicate strings": {[]string{"a", "b", "a"}, InvalidUniqueItemsError("foo", "a")},
+		"unique structs":    {[]*struct{ A int }{{1}, {2}}, nil},
+		"duplicate structs": {[]*struct{ A int }{{1}, {1}}, InvalidUniqueItemsError("foo", &struct{ A int }{1})},
+		"empty":             {[]int{}, nil},
+		"not a slice":       {42, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateUniqueItems("foo", tc.val)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: got %#v, expected nil", k, actual)
+			}
+			continue
+		}
+		if actual == nil || actual.Error() != tc.expected.Error() {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	allowed := []string{"a", "b", "c"}
+	cases := map[string]struct {
+		fields   []string
+		selected []string
+		expected error
+	}{
+		"empty":     {nil, nil, nil},
+		"subset":    {[]string{"a", "c"}, []string{"a", "c"}, nil},
+		"duplicate": {[]string{"b", "b"}, []string{"b"}, nil},
+		"unknown":   {[]string{"a", "d"}, nil, InvalidEnumValueError("fields", "d", []interface{}{"a", "b", "c"})},
+	}
+
+	for k, tc := range cases {
+		actual, err := SelectFields("fields", tc.fields, allowed)
+		if tc.expected != nil {
+			if err == nil || err.Error() != tc.expected.Error() {
+				t.Errorf("%s: got error %#v, expected %#v", k, err, tc.expected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got error %#v, expected nil", k, err)
+			continue
+		}
+		if len(actual) != len(tc.selected) {
+			t.Errorf("%s: got %d fields, expected %d", k, len(actual), len(tc.selected))
+		}
+		for _, f := range tc.selected {
+			if _, ok := actual[f]; !ok {
+				t.Errorf("%s: field %q not selected", k, f)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type (
+	// AuthorizationPolicy represents the authorization requirements defined
+	// by a method. Authenticated requests must satisfy at least one
+	// requirement to be authorized.
+	AuthorizationPolicy struct {
+		// Service is the name of the service as defined in the design.
+		Service string
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Requirements lists the authorization requirements.
+		Requirements []*AuthorizationRequirement
+	}
+
+	// AuthorizationRequirement represents a single authorization
+	// requirement.
+	AuthorizationRequirement struct {
+		// Roles holds the list of roles the caller must have.
+		Roles []string
+		// Scopes holds the list of scopes the caller must have.
+		Scopes []string
+		// Attributes holds the names of the payload attributes
+		// relevant to the requirement. The values of these attributes
+		// are given to the authorizer.
+		Attributes []string
+	}
+
+	// AuthorizeFunc is the function type that implements the authorization
+	// logic of the methods that define authorization requirements. attrs
+	// contains the values of the payload attributes listed in the policy
+	// requirements indexed by attribute name. Optional attributes that are
+	// not set are absent from attrs.
+	AuthorizeFunc func(ctx context.Context, attrs map[string]interface{}, p *AuthorizationPolicy) (context.Context, error)
+)
+
+// Validate returns a non-nil error if the given roles and scopes do not
+// satisfy any of the policy requirements. It does not take into account the
+// requirement attributes which must be checked by the authorizer.
+func (p *AuthorizationPolicy) Validate(roles, scopes []string) error {
+	if len(p.Requirements) == 0 {
+		return nil
+	}
+	var errs []string
+	for _, r := range p.Requirements {
+		err := r.Validate(roles, scopes)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return fmt.Errorf("unauthorized: %s", strings.Join(errs, " or "))
+}
+
+// Validate returns a non-nil error if roles does not contain all the
+// requirement roles or scopes does not contain all the requirement scopes.
+func (r *AuthorizationRequirement) Validate(roles, scopes []string) error {
+	missingRoles := missing(r.Roles, roles)
+	missingScopes := missing(r.Scopes, scopes)
+	if len(missingRoles) == 0 && len(missingScopes) == 0 {
+		return nil
+	}
+	var msgs []string
+	if len(missingRoles) > 0 {
+		msgs = append(msgs, "missing roles: "+strings.Join(missingRoles, ", "))
+	}
+	if len(missingScopes) > 0 {
+		msgs = append(msgs, "missing scopes: "+strings.Join(missingScopes, ", "))
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,46 @@
+package security
+
+import "testing"
+
+func TestAuthorizationPolicyValidate(t *testing.T) {
+	policy := &AuthorizationPolicy{
+		Service: "svc",
+		Method:  "method",
+		Requirements: []*AuthorizationRequirement{
+			{Roles: []string{"admin"}},
+			{Roles: []string{"user"}, Scopes: []string{"api:read", "api:write"}},
+		},
+	}
+	cases := map[string]struct {
+		Policy *AuthorizationPolicy
+		Roles  []string
+		Scopes []string
+		Error  string
+	}{
+		"no-requirement":    {Policy: &AuthorizationPolicy{}},
+		"first":             {Policy: policy, Roles: []string{"admin"}},
+		"second":            {Policy: policy, Roles: []string{"user"}, Scopes: []string{"api:write", "api:read"}},
+		"extra":             {Policy: policy, Roles: []string{"guest", "admin"}, Scopes: []string{"api:read"}},
+		"missing-role":      {Policy: policy, Scopes: []string{"api:read", "api:write"}, Error: "unauthorized: missing roles: admin or missing roles: user"},
+		"missing-scope":     {Policy: policy, Roles: []string{"user"}, Scopes: []string{"api:read"}, Error: "unauthorized: missing roles: admin or missing scopes: api:write"},
+		"missing-all":       {Policy: policy, Error: "unauthorized: missing roles: admin or missing roles: user; missing scopes: api:read, api:write"},
+		"scope-is-not-role": {Policy: policy, Scopes: []string{"admin"}, Error: "unauthorized: missing roles: admin or missing roles: user; missing scopes: api:read, api:write"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			err := tc.Policy.Validate(tc.Roles, tc.Scopes)
+			if tc.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, expected %q", tc.Error)
+			}
+			if err.Error() != tc.Error {
+				t.Errorf("got error %q, expected %q", err.Error(), tc.Error)
+			}
+		})
+	}
+}
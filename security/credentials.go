@@ -0,0 +1,278 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// CredentialSource provides the credentials used by the generated
+	// clients to make requests to secured endpoints. The clients obtain the
+	// credentials from the source for each request whose payload does not
+	// set them.
+	CredentialSource interface {
+		// Credential returns the API key or token used to make requests
+		// secured by the given scheme or an empty string if the source
+		// does not provide credentials for the scheme. scheme is a
+		// *APIKeyScheme, a *JWTScheme or a *OAuth2Scheme.
+		Credential(ctx context.Context, scheme interface{}) (string, error)
+	}
+
+	// StaticCredentials is a credential source that provides the same
+	// credentials for all the requests.
+	StaticCredentials struct {
+		// Token is the token used by the JWT and OAuth2 schemes.
+		Token string
+		// APIKey is the key used by the API key schemes.
+		APIKey string
+	}
+
+	// ClientCredentials is a credential source that obtains OAuth2 access
+	// tokens using the client credentials flow (RFC 6749 section 4.4).
+	// Tokens are cached and requested again once they expire or are
+	// invalidated, see Invalidate and Transport. Concurrent requests for the
+	// same token share a single token request.
+	ClientCredentials struct {
+		// ClientID is the client identifier.
+		ClientID string
+		// ClientSecret is the client secret.
+		ClientSecret string
+		// TokenURL is the URL of the token endpoint. If empty the token
+		// URL of the scheme client credentials flow defined in the
+		// design is used.
+		TokenURL string
+		// Scopes lists the requested scopes. If empty the scopes
+		// required by the scheme are requested.
+		Scopes []string
+		// Client is the HTTP client used to request the tokens,
+		// http.DefaultClient if nil.
+		Client *http.Client
+
+		mu       sync.Mutex
+		tokens   map[string]*cachedToken
+		inflight map[string]*tokenRequest
+	}
+
+	// cachedToken is an access token obtained by ClientCredentials.
+	cachedToken struct {
+		value  string
+		expiry time.Time
+	}
+
+	// tokenRequest is an in-flight token request shared by the concurrent
+	// callers of ClientCredentials.Credential.
+	tokenRequest struct {
+		done  chan struct{}
+		token *cachedToken
+		err   error
+		// canceled is true if the request failed because the context of
+		// the caller that made it was canceled.
+		canceled bool
+	}
+
+	// invalidatingTransport is the round tripper returned by
+	// ClientCredentials.Transport.
+	invalidatingTransport struct {
+		creds *ClientCredentials
+		base  http.RoundTripper
+	}
+)
+
+// expiryDelta is the time before their actual expiry at which access tokens
+// are considered expired so that they do not expire while in flight.
+const expiryDelta = 10 * time.Second
+
+// Credential returns the token for the JWT and OAuth2 schemes and the API key
+// for the API key schemes.
+func (c *StaticCredentials) Credential(_ context.Context, scheme interface{}) (string, error) {
+	switch scheme.(type) {
+	case *APIKeyScheme:
+		return c.APIKey, nil
+	case *JWTScheme, *OAuth2Scheme:
+		return c.Token, nil
+	}
+	return "", nil
+}
+
+// Credential returns an access token for the OAuth2 schemes. It returns an
+// empty string for the other schemes and for the OAuth2 schemes that do not
+// define a client credentials flow if TokenURL is empty.
+func (c *ClientCredentials) Credential(ctx context.Context, scheme interface{}) (string, error) {
+	s, ok := scheme.(*OAuth2Scheme)
+	if !ok {
+		return "", nil
+	}
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		for _, f := range s.Flows {
+			if f.Type == "client_credentials" {
+				tokenURL = f.TokenURL
+				break
+			}
+		}
+		if tokenURL == "" {
+			return "", nil
+		}
+	}
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = s.RequiredScopes
+	}
+	key := tokenURL + " " + strings.Join(scopes, " ")
+
+	for {
+		c.mu.Lock()
+		if t, ok := c.tokens[key]; ok && (t.expiry.IsZero() || time.Now().Before(t.expiry)) {
+			c.mu.Unlock()
+			return t.value, nil
+		}
+		r, shared := c.inflight[key]
+		if !shared {
+			r = &tokenRequest{done: make(chan struct{})}
+			if c.inflight == nil {
+				c.inflight = make(map[string]*tokenRequest)
+			}
+			c.inflight[key] = r
+			c.mu.Unlock()
+			c.refresh(ctx, key, tokenURL, scopes, r)
+		} else {
+			c.mu.Unlock()
+		}
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if r.canceled && shared {
+			// The caller that made the shared request gave up, try again.
+			continue
+		}
+		if r.err != nil {
+			return "", r.err
+		}
+		return r.token.value, nil
+	}
+}
+
+// Invalidate removes the given access token from the cache so that the next
+// call to Credential requests a new token. Clients call Invalidate when a
+// request made with the token is rejected, e.g. with a 401 Unauthorized HTTP
+// response or an Unauthenticated gRPC status.
+func (c *ClientCredentials) Invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, t := range c.tokens {
+		if t.value == token {
+			delete(c.tokens, key)
+		}
+	}
+}
+
+// Transport returns a round tripper that makes requests using base, or
+// http.DefaultTransport if nil, and that invalidates the access token set in
+// the Authorization header of the requests whose response status is 401
+// Unauthorized. It makes it possible for HTTP clients to recover from tokens
+// revoked before they expire:
+//
+//	creds := &security.ClientCredentials{ClientID: id, ClientSecret: secret}
+//	doer := &http.Client{Transport: creds.Transport(nil)}
+func (c *ClientCredentials) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &invalidatingTransport{creds: c, base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *invalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	auth := req.Header.Get("Authorization")
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || auth == "" {
+		return resp, err
+	}
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		auth = auth[7:]
+	}
+	t.creds.Invalidate(auth)
+	return resp, err
+}
+
+// refresh requests the token identified by key, caches it and completes r. It
+// does not hold the lock while the request is in flight.
+func (c *ClientCredentials) refresh(ctx context.Context, key, tokenURL string, scopes []string, r *tokenRequest) {
+	r.token, r.err = c.requestToken(ctx, tokenURL, scopes)
+	r.canceled = r.err != nil && ctx.Err() != nil
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if r.err == nil {
+		if c.tokens == nil {
+			c.tokens = make(map[string]*cachedToken)
+		}
+		c.tokens[key] = r.token
+	}
+	c.mu.Unlock()
+	close(r.done)
+}
+
+// requestToken requests an access token with the given scopes from the token
+// endpoint at tokenURL.
+func (c *ClientCredentials) requestToken(ctx context.Context, tokenURL string, scopes []string) (*cachedToken, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: invalid token URL: %s", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: token request failed: %s", err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: failed to read token response: %s", err)
+	}
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if json.Unmarshal(b, &body) == nil && body.Error != "" {
+			if body.ErrorDescription != "" {
+				return nil, fmt.Errorf("oauth2: token request failed: %s: %s", body.Error, body.ErrorDescription)
+			}
+			return nil, fmt.Errorf("oauth2: token request failed: %s", body.Error)
+		}
+		return nil, fmt.Errorf("oauth2: token request failed: %s", resp.Status)
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, fmt.Errorf("oauth2: invalid token response: %s", err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2: invalid token response: missing access_token")
+	}
+	t := &cachedToken{value: body.AccessToken}
+	if body.ExpiresIn > 0 {
+		t.expiry = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - expiryDelta)
+	}
+	return t, nil
+}
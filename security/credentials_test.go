@@ -0,0 +1,214 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	c := &StaticCredentials{Token: "token", APIKey: "key"}
+	cases := map[string]struct {
+		Scheme   interface{}
+		Expected string
+	}{
+		"api-key": {&APIKeyScheme{Name: "api_key"}, "key"},
+		"jwt":     {&JWTScheme{Name: "jwt"}, "token"},
+		"oauth2":  {&OAuth2Scheme{Name: "oauth2"}, "token"},
+		"basic":   {&BasicScheme{Name: "basic"}, ""},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			cred, err := c.Credential(context.Background(), tc.Scheme)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if cred != tc.Expected {
+				t.Errorf("got %q, expected %q", cred, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		id, secret, _ := r.BasicAuth()
+		if id != "client" || secret != "s%40cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client","error_description":"unknown client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if gt := r.PostForm.Get("grant_type"); gt != "client_credentials" {
+			t.Errorf("got grant type %q, expected client_credentials", gt)
+		}
+		switch r.PostForm.Get("scope") {
+		case "api:read":
+			w.Write([]byte(`{"access_token":"read-token","token_type":"bearer","expires_in":3600}`))
+		case "":
+			w.Write([]byte(`{"access_token":"expired-token","token_type":"bearer","expires_in":1}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_scope"}`))
+		}
+	}))
+	defer srv.Close()
+
+	scheme := &OAuth2Scheme{
+		Name:           "oauth2",
+		RequiredScopes: []string{"api:read"},
+		Flows:          []*OAuthFlow{{Type: "client_credentials", TokenURL: srv.URL}},
+	}
+	ctx := context.Background()
+
+	c := &ClientCredentials{ClientID: "client", ClientSecret: "s@cret"}
+	for i := 0; i < 2; i++ {
+		cred, err := c.Credential(ctx, scheme)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if cred != "read-token" {
+			t.Errorf("got %q, expected read-token", cred)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d token requests, expected the token to be cached", requests)
+	}
+
+	requests = 0
+	expired := &OAuth2Scheme{Name: "oauth2", Flows: scheme.Flows}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Credential(ctx, expired); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d token requests, expected the expired token to be requested again", requests)
+	}
+
+	if cred, err := c.Credential(ctx, &JWTScheme{Name: "jwt"}); err != nil || cred != "" {
+		t.Errorf("got %q, %v for JWT scheme, expected no credential", cred, err)
+	}
+	if cred, err := c.Credential(ctx, &OAuth2Scheme{Name: "implicit"}); err != nil || cred != "" {
+		t.Errorf("got %q, %v for scheme without token URL, expected no credential", cred, err)
+	}
+
+	c = &ClientCredentials{ClientID: "client", ClientSecret: "s@cret", TokenURL: srv.URL, Scopes: []string{"api:write"}}
+	if _, err := c.Credential(ctx, scheme); err == nil || !strings.Contains(err.Error(), "invalid_scope") {
+		t.Errorf("got error %v, expected invalid_scope", err)
+	}
+	c = &ClientCredentials{ClientID: "other", TokenURL: srv.URL}
+	if _, err := c.Credential(ctx, scheme); err == nil || !strings.Contains(err.Error(), "invalid_client: unknown client") {
+		t.Errorf("got error %v, expected invalid_client", err)
+	}
+}
+
+func TestClientCredentialsSingleFlight(t *testing.T) {
+	var requests int32
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if r.PostForm.Get("scope") == "slow" {
+			close(started)
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"` + r.PostForm.Get("scope") + `-token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	scheme := &OAuth2Scheme{Name: "oauth2", RequiredScopes: []string{"slow"}}
+
+	c := &ClientCredentials{TokenURL: srv.URL}
+	var wg sync.WaitGroup
+	creds := make([]string, 5)
+	for i := range creds {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cred, err := c.Credential(ctx, scheme)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			creds[i] = cred
+		}(i)
+	}
+
+	<-started
+
+	// The lock must not be held while the slow token request is in flight.
+	fast := &OAuth2Scheme{Name: "oauth2", RequiredScopes: []string{"fast"}}
+	if cred, err := c.Credential(ctx, fast); err != nil || cred != "fast-token" {
+		t.Errorf("got %q, %v, expected fast-token", cred, err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := c.Credential(canceled, scheme); err != context.Canceled {
+		t.Errorf("got error %v, expected context canceled", err)
+	}
+
+	close(release)
+	wg.Wait()
+	for _, cred := range creds {
+		if cred != "slow-token" {
+			t.Errorf("got %q, expected slow-token", cred)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d token requests, expected 2", n)
+	}
+}
+
+func TestClientCredentialsTransport(t *testing.T) {
+	var tokens int
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token-` + string(rune('0'+tokens)) + `","expires_in":3600}`))
+	}))
+	defer auth.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer api.Close()
+	ctx := context.Background()
+	scheme := &OAuth2Scheme{Name: "oauth2"}
+	c := &ClientCredentials{TokenURL: auth.URL}
+	client := &http.Client{Transport: c.Transport(nil)}
+
+	expected := []int{http.StatusUnauthorized, http.StatusOK, http.StatusOK}
+	for i, status := range expected {
+		cred, err := c.Credential(ctx, scheme)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		req, _ := http.NewRequest("GET", api.URL, nil)
+		req.Header.Set("Authorization", "Bearer "+cred)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("request %d: got status %d, expected %d", i, resp.StatusCode, status)
+		}
+	}
+	if tokens != 2 {
+		t.Errorf("got %d token requests, expected the rejected token to be requested again", tokens)
+	}
+}
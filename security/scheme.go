@@ -5,11 +5,17 @@ secure goa endpoint. It supports the following security schemes:
   * API key security using keys.
   * JWT security using JWT tokens.
   * OAuth2 security using OAuth2 tokens.
+  * Mutual TLS security using client certificates.
+
+It also contains the types used to authorize authenticated requests using
+roles, scopes and payload attributes and the credential sources used by the
+generated clients to authenticate requests.
 */
 package security
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"strings"
 )
@@ -64,6 +70,18 @@ type (
 		Flows []*OAuthFlow
 	}
 
+	// MTLSScheme represents the mutual TLS security scheme.
+	// It consists of the client certificate verified by the server.
+	MTLSScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+	}
+
 	// OAuthFlow represents the OAuth2 flow defined by the scheme.
 	OAuthFlow struct {
 		// Type is the type of grant.
@@ -91,6 +109,20 @@ type (
 	// AuthJWTFunc is the function type that implements the JWT
 	// scheme of using a JWT token.
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// AuthMTLSFunc is the function type that implements the mutual TLS
+	// scheme of using a client certificate. cert is nil if the client
+	// did not provide a verified certificate.
+	AuthMTLSFunc func(ctx context.Context, cert *x509.Certificate, s *MTLSScheme) (context.Context, error)
+
+	// private type used to define context keys
+	ctxKey int
+)
+
+const (
+	// peerCertificateKey is the context key used to store the verified
+	// client certificate.
+	peerCertificateKey ctxKey = iota + 1
 )
 
 // Validate returns a non-nil error if scopes does not contain all of
@@ -117,7 +149,39 @@ func (s *JWTScheme) Validate(scopes []string) error {
 	return validateScopes(s.RequiredScopes, scopes)
 }
 
+// Validate returns a non-nil error if scopes does not contain all of
+// MTLS scheme's required scopes.
+func (s *MTLSScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes)
+}
+
+// WithPeerCertificate returns a copy of ctx that holds the given verified
+// client certificate. The generated HTTP and gRPC servers use it to make the
+// certificate available to the mutual TLS authorization functions.
+func WithPeerCertificate(ctx context.Context, cert *x509.Certificate) context.Context {
+	if cert == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, peerCertificateKey, cert)
+}
+
+// PeerCertificate returns the verified client certificate stored in ctx, nil
+// if there isn't one.
+func PeerCertificate(ctx context.Context) *x509.Certificate {
+	cert, _ := ctx.Value(peerCertificateKey).(*x509.Certificate)
+	return cert
+}
+
 func validateScopes(expected, actual []string) error {
+	m := missing(expected, actual)
+	if len(m) == 0 {
+		return nil
+	}
+	return fmt.Errorf("missing scopes: %s", strings.Join(m, ", "))
+}
+
+// missing returns the elements of expected that are not in actual.
+func missing(expected, actual []string) []string {
 	var missing []string
 	for _, r := range expected {
 		found := false
@@ -131,8 +195,5 @@ func validateScopes(expected, actual []string) error {
 			missing = append(missing, r)
 		}
 	}
-	if len(missing) == 0 {
-		return nil
-	}
-	return fmt.Errorf("missing scopes: %s", strings.Join(missing, ", "))
+	return missing
 }